@@ -371,9 +371,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/load/generators": {
+        "/api/v1/load/capacity-searches": {
             "get": {
-                "description": "Retrieve a list of all installed load generators with pagination support.",
+                "description": "Retrieve a list of all capacity searches with their steps with pagination support.",
                 "consumes": [
                     "application/json"
                 ],
@@ -381,10 +381,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Load Generator Management]"
+                    "[Capacity Search Management]"
                 ],
-                "summary": "Get All Load Generator Install Info",
-                "operationId": "GetAllLoadGeneratorInstallInfo",
+                "summary": "Get All Capacity Searches",
+                "operationId": "GetAllCapacitySearches",
                 "parameters": [
                     {
                         "type": "integer",
@@ -397,19 +397,13 @@ const docTemplate = `{
                         "description": "Number of items per page (default 10, max 10)",
                         "name": "size",
                         "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by status",
-                        "name": "status",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved monitoring agent information",
+                        "description": "Successfully retrieved capacity searches",
                         "schema": {
-                            "$ref": "#/definitions/app.AntResponse-load_GetAllLoadGeneratorInstallInfoResult"
+                            "$ref": "#/definitions/app.AntResponse-load_GetAllCapacitySearchesResult"
                         }
                     },
                     "400": {
@@ -419,7 +413,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve monitoring agent information",
+                        "description": "Failed to retrieve capacity searches",
                         "schema": {
                             "$ref": "#/definitions/app.AntResponse-string"
                         }
@@ -427,7 +421,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Install a load generator either locally or remotely.",
+                "description": "Run load tests step by step from the start load, increasing the load by the step load up to the max load against the same target.\nThe load is the virtual users of the closed load model or the requests per second of the open load model, which is held for the step duration (seconds).\nAfter each step the error percent and the p95 (ms) of all requests are checked, and the search stops at the first step which exceeds the max error percent or the max p95.\nThe result reports the max sustainable load and throughput with the step where it broke. The load generator of the first step is reused by the following steps.",
                 "consumes": [
                     "application/json"
                 ],
@@ -435,36 +429,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Load Generator Management]"
+                    "[Capacity Search Management]"
                 ],
-                "summary": "Install Load Generator",
-                "operationId": "InstallLoadGenerator",
+                "summary": "Run Capacity Search",
+                "operationId": "RunCapacitySearch",
                 "parameters": [
                     {
-                        "description": "Load Generator Installation Request",
+                        "description": "Capacity Search Request",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/app.InstallLoadGeneratorReq"
+                            "$ref": "#/definitions/app.RunCapacitySearchReq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully installed load generator",
+                        "description": "Successfully started capacity search",
                         "schema": {
-                            "$ref": "#/definitions/app.AntResponse-load_LoadGeneratorInstallInfoResult"
+                            "$ref": "#/definitions/app.AntResponse-load_CapacitySearchResult"
                         }
                     },
                     "400": {
-                        "description": "Load generator installation info is not correct.| available install locations are remote or local.",
-                        "schema": {
-                            "$ref": "#/definitions/app.AntResponse-string"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                        "description": "capacity search info is not correct.",
                         "schema": {
                             "$ref": "#/definitions/app.AntResponse-string"
                         }
@@ -472,9 +460,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/load/generators/{loadGeneratorInstallInfoId}": {
-            "delete": {
-                "description": "Uninstall a previously installed load generator.",
+        "/api/v1/load/capacity-searches/{capacitySearchId}": {
+            "get": {
+                "description": "Retrieve the status, the steps and the breaking point of the capacity search.",
                 "consumes": [
                     "application/json"
                 ],
@@ -482,34 +470,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Load Generator Management]"
+                    "[Capacity Search Management]"
                 ],
-                "summary": "Uninstall Load Generator",
-                "operationId": "UninstallLoadGenerator",
+                "summary": "Get Capacity Search",
+                "operationId": "GetCapacitySearch",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "load generator install info id",
-                        "name": "loadGeneratorInstallInfoId",
+                        "description": "Capacity search id",
+                        "name": "capacitySearchId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully uninstall load generator",
+                        "description": "Successfully retrieved capacity search",
                         "schema": {
-                            "$ref": "#/definitions/app.AntResponse-string"
+                            "$ref": "#/definitions/app.AntResponse-load_CapacitySearchResult"
                         }
                     },
                     "400": {
-                        "description": "Load generator install info id must be number.",
+                        "description": "Capacity search id must be number.",
                         "schema": {
                             "$ref": "#/definitions/app.AntResponse-string"
                         }
                     },
                     "500": {
-                        "description": "ant server has got error. please try again.",
+                        "description": "Failed to retrieve capacity search",
                         "schema": {
                             "$ref": "#/definitions/app.AntResponse-string"
                         }
@@ -517,9 +505,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/load/monitoring/agent/install": {
-            "post": {
-                "description": "Install a monitoring agent on specific mci.",
+        "/api/v1/load/generators": {
+            "get": {
+                "description": "Retrieve a list of all installed load generators with pagination support.",
                 "consumes": [
                     "application/json"
                 ],
@@ -527,46 +515,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Monitoring Agent Management]"
+                    "[Load Generator Management]"
                 ],
-                "summary": "Install Metrics Monitoring Agent",
-                "operationId": "InstallMonitoringAgent",
+                "summary": "Get All Load Generator Install Info",
+                "operationId": "GetAllLoadGeneratorInstallInfo",
                 "parameters": [
                     {
-                        "description": "Monitoring Agent Installation Request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/app.MonitoringAgentInstallationReq"
-                        }
+                        "type": "integer",
+                        "description": "Page number for pagination (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items per page (default 10, max 10)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully installed monitoring agent",
+                        "description": "Successfully retrieved monitoring agent information",
                         "schema": {
-                            "$ref": "#/definitions/app.AntResponse-load_MonitoringAgentInstallationResult"
+                            "$ref": "#/definitions/app.AntResponse-load_GetAllLoadGeneratorInstallInfoResult"
                         }
                     },
                     "400": {
-                        "description": "Monitoring agent installation info is not correct.",
+                        "description": "Invalid request parameters",
                         "schema": {
                             "$ref": "#/definitions/app.AntResponse-string"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Failed to retrieve monitoring agent information",
                         "schema": {
                             "$ref": "#/definitions/app.AntResponse-string"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/load/monitoring/agents": {
-            "get": {
-                "description": "Retrieve monitoring agent information based on specified criteria.",
+            },
+            "post": {
+                "description": "Install a load generator either locally or remotely. For remote installation, workerCount provisions additional worker VMs which run jmeter-server for distributed load test, and the security groups of the cluster are limited to ssh and the private ips of the cluster.\nThe remote load generator is provisioned near the coordinates such as 37.53/127.02 (seoul by default).\nWith regionName, a regional load generator is installed on its own MCI, which is used by the multi region load test.\nThe remote VM is recommended among the providers (aws by default) within minVCpu-maxVCpu (2-8) and minMemoryGiB-maxMemoryGiB (4-8) by the priority, which is location (default), cost or performance.\nspec (such as aws+ap-northeast-2+t3.xlarge) skips the recommendation, image is the common image or the os (ubuntu22.04 by default), and rootDiskSize is in GB. The VM options apply to the VMs which are newly provisioned.",
                 "consumes": [
                     "application/json"
                 ],
@@ -574,51 +569,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Monitoring Agent Management]"
+                    "[Load Generator Management]"
                 ],
-                "summary": "Retrieve Monitoring Agent Information",
-                "operationId": "GetAllMonitoringAgentInfos",
+                "summary": "Install Load Generator",
+                "operationId": "InstallLoadGenerator",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Namespace ID",
-                        "name": "nsId",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "MCI ID",
-                        "name": "mciId",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "VM ID",
-                        "name": "vmId",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of results per page",
-                        "name": "size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page number for pagination",
-                        "name": "page",
-                        "in": "query"
+                        "description": "Load Generator Installation Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app.InstallLoadGeneratorReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved monitoring agent information",
+                        "description": "Successfully installed load generator",
                         "schema": {
-                            "$ref": "#/definitions/app.AntResponse-load_GetAllMonitoringAgentInfoResult"
+                            "$ref": "#/definitions/app.AntResponse-load_LoadGeneratorInstallInfoResult"
                         }
                     },
                     "400": {
-                        "description": "Invalid request parameters",
+                        "description": "Load generator installation info is not correct.| available install locations are remote or local.",
                         "schema": {
                             "$ref": "#/definitions/app.AntResponse-string"
                         }
@@ -632,9 +606,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/load/monitoring/agents/uninstall": {
+        "/api/v1/load/generators/ssh": {
             "post": {
-                "description": "Uninstall monitoring agents from specified VMs or all VMs in an mci.",
+                "description": "Register an existing host, such as a bastion or an on-premise server which Tumblebug does not manage, as a load generator reached by SSH directly.\nprivateKeyName is the name of the private key file in ~/.ssh of the CM-ANT server, which the host accepts for the username. sshPort is 22 by default.\nThe host must be Ubuntu and allow sudo without a password, because install-jmeter.sh installs JMeter on it over SSH.\nThe load tests on the registered load generator run and fetch their results over SSH. It is not suspended, resumed or terminated, and uninstall only deregisters it after removing JMeter.",
                 "consumes": [
                     "application/json"
                 ],
@@ -642,36 +616,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Monitoring Agent Management]"
+                    "[Load Generator Management]"
                 ],
-                "summary": "Uninstall Monitoring Agents",
-                "operationId": "UninstallMonitoringAgent",
+                "summary": "Register Load Generator",
+                "operationId": "RegisterLoadGenerator",
                 "parameters": [
                     {
-                        "description": "Monitoring Agent Uninstallation Request",
+                        "description": "Load Generator Registration Request",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/app.MonitoringAgentInstallationReq"
+                            "$ref": "#/definitions/app.RegisterLoadGeneratorReq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Number of affected results",
+                        "description": "Successfully registered load generator",
                         "schema": {
-                            "$ref": "#/definitions/app.AntResponse-int64"
+                            "$ref": "#/definitions/app.AntResponse-load_LoadGeneratorInstallInfoResult"
                         }
                     },
                     "400": {
-                        "description": "Invalid request parameters",
-                        "schema": {
-                            "$ref": "#/definitions/app.AntResponse-string"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                        "description": "Host is already registered.",
                         "schema": {
                             "$ref": "#/definitions/app.AntResponse-string"
                         }
@@ -679,9 +647,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/load/test/metrics": {
-            "get": {
-                "description": "Retrieve load test metrics based on provided parameters.",
+        "/api/v1/load/generators/{loadGeneratorInstallInfoId}": {
+            "delete": {
+                "description": "Uninstall a previously installed load generator.\nThe VMs of a remote load generator are suspended after the uninstallation, and resumed by the next installation.",
                 "consumes": [
                     "application/json"
                 ],
@@ -689,34 +657,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Load Test Result]"
+                    "[Load Generator Management]"
                 ],
-                "summary": "Get load test metrics",
-                "operationId": "GetLoadTestMetrics",
+                "summary": "Uninstall Load Generator",
+                "operationId": "UninstallLoadGenerator",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Load test key",
-                        "name": "loadTestKey",
-                        "in": "query",
+                        "description": "load generator install info id",
+                        "name": "loadGeneratorInstallInfoId",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved load test metrics",
+                        "description": "Successfully uninstall load generator",
                         "schema": {
-                            "$ref": "#/definitions/app.AntResponse-array_load_MetricsSummary"
+                            "$ref": "#/definitions/app.AntResponse-string"
                         }
                     },
                     "400": {
-                        "description": "Invalid request parameters",
+                        "description": "Load generator install info id must be number.",
                         "schema": {
                             "$ref": "#/definitions/app.AntResponse-string"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve load test metrics",
+                        "description": "ant server has got error. please try again.",
                         "schema": {
                             "$ref": "#/definitions/app.AntResponse-string"
                         }
@@ -724,9 +692,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/load/test/result": {
-            "get": {
-                "description": "Retrieve load test result based on provided parameters.",
+        "/api/v1/load/generators/{loadGeneratorInstallInfoId}/keys/rotate": {
+            "post": {
+                "description": "Generate a new key pair for a remote or SSH load generator and authorize its public key on every load generator server.\nThe new key is checked by SSH on each server before it is activated, then the former key of the load generator and the key shared by the load generators installed before the keystore are removed from the authorized keys.\nThe key of a host registered by SSH is kept, because it was authorized by the user. The private keys are encrypted with load.keystore.masterKey, which must be set.\nThe load generator must not be suspended or have unfinished load tests.",
                 "consumes": [
                     "application/json"
                 ],
@@ -734,55 +702,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Load Test Result]"
+                    "[Load Generator Management]"
                 ],
-                "summary": "Get load test result",
-                "operationId": "GetLoadTestResult",
+                "summary": "Rotate Load Generator Key",
+                "operationId": "RotateLoadGeneratorKey",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Load test key",
-                        "name": "loadTestKey",
-                        "in": "query",
+                        "description": "load generator install info id",
+                        "name": "loadGeneratorInstallInfoId",
+                        "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Result format (normal or aggregate)",
-                        "name": "format",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved load test metrics",
+                        "description": "Successfully rotated load generator key",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/app.JsonResult"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "[aggregate]": {
-                                            "$ref": "#/definitions/app.AntResponse-array_load_LoadTestStatistics"
-                                        },
-                                        "[normal]": {
-                                            "$ref": "#/definitions/app.AntResponse-array_load_ResultSummary"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/app.AntResponse-load_LoadGeneratorKeyResult"
                         }
                     },
                     "400": {
-                        "description": "Invalid request parameters",
+                        "description": "Load generator install info id must be number.",
                         "schema": {
                             "$ref": "#/definitions/app.AntResponse-string"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve load test result",
+                        "description": "Failed to rotate load generator key.",
                         "schema": {
                             "$ref": "#/definitions/app.AntResponse-string"
                         }
@@ -790,9 +737,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/load/tests/infos": {
-            "get": {
-                "description": "Retrieve a list of all load test execution information with pagination support.",
+        "/api/v1/load/generators/{loadGeneratorInstallInfoId}/resume": {
+            "post": {
+                "description": "Resume the suspended VMs of a remote load generator and wait until they are running. The public IPs of the VMs are refreshed.",
                 "consumes": [
                     "application/json"
                 ],
@@ -800,39 +747,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Load Test Execution Management]"
+                    "[Load Generator Management]"
                 ],
-                "summary": "Get All Load Test Execution Information",
-                "operationId": "GetAllLoadTestExecutionInfos",
+                "summary": "Resume Load Generator",
+                "operationId": "ResumeLoadGenerator",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page number for pagination (default 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of items per page (default 10, max 10)",
-                        "name": "size",
-                        "in": "query"
+                        "type": "string",
+                        "description": "load generator install info id",
+                        "name": "loadGeneratorInstallInfoId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved load test execution information",
-                        "schema": {
-                            "$ref": "#/definitions/app.AntResponse-load_GetAllLoadTestExecutionInfosResult"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request parameters",
+                        "description": "Successfully resume load generator",
                         "schema": {
                             "$ref": "#/definitions/app.AntResponse-string"
                         }
                     },
-                    "500": {
-                        "description": "Failed to retrieve all load test execution information",
+                    "400": {
+                        "description": "Load generator install info id must be number.",
                         "schema": {
                             "$ref": "#/definitions/app.AntResponse-string"
                         }
@@ -840,9 +776,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/load/tests/infos/{loadTestKey}": {
-            "get": {
-                "description": "Retrieve the load test execution state information for a specific load test key.",
+        "/api/v1/load/generators/{loadGeneratorInstallInfoId}/suspend": {
+            "post": {
+                "description": "Suspend the VMs of a remote load generator which has no unfinished load test, so they are not billed while they are not used.\nThe suspended load generator is resumed automatically when a load test runs on it.\nRemote load generators are also suspended (or terminated) by the idle reaper after load.generator.idleTimeout (1h by default) since the last load test.",
                 "consumes": [
                     "application/json"
                 ],
@@ -850,34 +786,120 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Load Test Execution Management]"
+                    "[Load Generator Management]"
                 ],
-                "summary": "Get Load Test Execution State",
-                "operationId": "GetLoadTestExecutionInfo",
+                "summary": "Suspend Load Generator",
+                "operationId": "SuspendLoadGenerator",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Load test key",
-                        "name": "loadTestKey",
+                        "description": "load generator install info id",
+                        "name": "loadGeneratorInstallInfoId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved load test execution state information",
+                        "description": "Successfully suspend load generator",
                         "schema": {
-                            "$ref": "#/definitions/app.AntResponse-load_LoadTestExecutionInfoResult"
+                            "$ref": "#/definitions/app.AntResponse-string"
                         }
                     },
                     "400": {
-                        "description": "Load test key must be set.",
+                        "description": "Load generator has unfinished load tests.",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/load/generators/{loadGeneratorInstallInfoId}/terminate": {
+            "post": {
+                "description": "Terminate the VMs of a remote load generator which has no unfinished load test and delete its MCI.\nThe terminated load generator can not run load tests anymore, and the next installation provisions new VMs.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Load Generator Management]"
+                ],
+                "summary": "Terminate Load Generator",
+                "operationId": "TerminateLoadGenerator",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "load generator install info id",
+                        "name": "loadGeneratorInstallInfoId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully terminate load generator",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    },
+                    "400": {
+                        "description": "Load generator has unfinished load tests.",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/load/generators/{loadGeneratorInstallInfoId}/verify": {
+            "post": {
+                "description": "Check that the VMs of a load generator are running and reachable by SSH, Java and JMeter of the installed version are present, the install path has free disk (load.health.minFreeDiskMb) and the required JMeter plugins are installed.\nThe status of each load generator server is updated with the result, such as unreachable or unhealthy, and the problems are kept in the healthMessage of the load generator.\nWith reinstall, install-jmeter.sh runs again on the drifted load generator and the health is checked again.\nRunning remote load generators are also checked every load.health.checkInterval, and a JMeter load test fails at once when its load generator is unhealthy.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Load Generator Management]"
+                ],
+                "summary": "Verify Load Generator",
+                "operationId": "VerifyLoadGenerator",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "load generator install info id",
+                        "name": "loadGeneratorInstallInfoId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Load Generator Verification Request",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/app.VerifyLoadGeneratorReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully verified load generator",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-load_LoadGeneratorHealthResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Load generator install info id must be number.",
                         "schema": {
                             "$ref": "#/definitions/app.AntResponse-string"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve load test execution state information",
+                        "description": "Failed to verify load generator.",
                         "schema": {
                             "$ref": "#/definitions/app.AntResponse-string"
                         }
@@ -885,9 +907,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/load/tests/run": {
+        "/api/v1/load/monitoring/agent/install": {
             "post": {
-                "description": "Start a load test using the provided load test configuration.",
+                "description": "Install a monitoring agent on specific mci.",
                 "consumes": [
                     "application/json"
                 ],
@@ -895,36 +917,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Load Test Execution Management]"
+                    "[Monitoring Agent Management]"
                 ],
-                "summary": "Run Load Test",
-                "operationId": "RunLoadTest",
+                "summary": "Install Metrics Monitoring Agent",
+                "operationId": "InstallMonitoringAgent",
                 "parameters": [
                     {
-                        "description": "Run Load Test Request",
+                        "description": "Monitoring Agent Installation Request",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/app.RunLoadTestReq"
+                            "$ref": "#/definitions/app.MonitoringAgentInstallationReq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "{loadTestKey}",
+                        "description": "Successfully installed monitoring agent",
                         "schema": {
-                            "$ref": "#/definitions/app.AntResponse-string"
+                            "$ref": "#/definitions/app.AntResponse-load_MonitoringAgentInstallationResult"
                         }
                     },
                     "400": {
-                        "description": "load test install location is invalid.",
+                        "description": "Monitoring agent installation info is not correct.",
                         "schema": {
                             "$ref": "#/definitions/app.AntResponse-string"
                         }
                     },
                     "500": {
-                        "description": "ant server has got error. please try again.",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/app.AntResponse-string"
                         }
@@ -932,9 +954,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/load/tests/state": {
+        "/api/v1/load/monitoring/agents": {
             "get": {
-                "description": "Retrieve a list of all load test execution states with pagination support.",
+                "description": "Retrieve monitoring agent information based on specified criteria.",
                 "consumes": [
                     "application/json"
                 ],
@@ -942,41 +964,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Load Test State Management]"
+                    "[Monitoring Agent Management]"
                 ],
-                "summary": "Get All Load Test Execution State",
-                "operationId": "GetAllLoadTestExecutionState",
+                "summary": "Retrieve Monitoring Agent Information",
+                "operationId": "GetAllMonitoringAgentInfos",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page number for pagination (default 1)",
-                        "name": "page",
+                        "type": "string",
+                        "description": "Namespace ID",
+                        "name": "nsId",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Number of items per page (default 10, max 10)",
-                        "name": "size",
+                        "type": "string",
+                        "description": "MCI ID",
+                        "name": "mciId",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter by load test key",
-                        "name": "loadTestKey",
+                        "description": "VM ID",
+                        "name": "vmId",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Filter by execution status",
-                        "name": "executionStatus",
+                        "type": "integer",
+                        "description": "Number of results per page",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number for pagination",
+                        "name": "page",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved load test execution state information",
+                        "description": "Successfully retrieved monitoring agent information",
                         "schema": {
-                            "$ref": "#/definitions/app.AntResponse-load_GetAllLoadTestExecutionStateResult"
+                            "$ref": "#/definitions/app.AntResponse-load_GetAllMonitoringAgentInfoResult"
                         }
                     },
                     "400": {
@@ -986,7 +1014,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve load test execution state information",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/app.AntResponse-string"
                         }
@@ -994,9 +1022,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/load/tests/state/{loadTestKey}": {
-            "get": {
-                "description": "Retrieve a load test execution state by load test key.",
+        "/api/v1/load/monitoring/agents/uninstall": {
+            "post": {
+                "description": "Uninstall monitoring agents from specified VMs or all VMs in an mci.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1004,34 +1032,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Load Test State Management]"
+                    "[Monitoring Agent Management]"
                 ],
-                "summary": "Get Load Test Execution State",
-                "operationId": "GetLoadTestExecutionState",
+                "summary": "Uninstall Monitoring Agents",
+                "operationId": "UninstallMonitoringAgent",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Load test key",
-                        "name": "loadTestKey",
-                        "in": "path",
-                        "required": true
+                        "description": "Monitoring Agent Uninstallation Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app.MonitoringAgentInstallationReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved load test execution state information",
+                        "description": "Number of affected results",
                         "schema": {
-                            "$ref": "#/definitions/app.AntResponse-load_LoadTestExecutionStateResult"
+                            "$ref": "#/definitions/app.AntResponse-int64"
                         }
                     },
                     "400": {
-                        "description": "Load test key must be set.",
+                        "description": "Invalid request parameters",
                         "schema": {
                             "$ref": "#/definitions/app.AntResponse-string"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve load test execution state information",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/app.AntResponse-string"
                         }
@@ -1039,9 +1069,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/load/tests/stop": {
-            "post": {
-                "description": "Stop a running load test using the provided load test key.",
+        "/api/v1/load/multi-region-tests": {
+            "get": {
+                "description": "Retrieve a list of all multi region load tests with their regions with pagination support.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1049,46 +1079,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Load Test Execution Management]"
+                    "[Multi Region Load Test Management]"
                 ],
-                "summary": "Stop Load Test",
-                "operationId": "StopLoadTest",
+                "summary": "Get All Multi Region Load Tests",
+                "operationId": "GetAllMultiRegionLoadTests",
                 "parameters": [
                     {
-                        "description": "Stop Load Test Request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/app.StopLoadTestReq"
-                        }
+                        "type": "integer",
+                        "description": "Page number for pagination (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items per page (default 10, max 10)",
+                        "name": "size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "done",
+                        "description": "Successfully retrieved multi region load tests",
                         "schema": {
-                            "$ref": "#/definitions/app.AntResponse-string"
+                            "$ref": "#/definitions/app.AntResponse-load_GetAllMultiRegionLoadTestsResult"
                         }
                     },
                     "400": {
-                        "description": "load test running info is not correct.",
+                        "description": "Invalid request parameters",
                         "schema": {
                             "$ref": "#/definitions/app.AntResponse-string"
                         }
                     },
                     "500": {
-                        "description": "ant server has got error. please try again.",
+                        "description": "Failed to retrieve multi region load tests",
                         "schema": {
                             "$ref": "#/definitions/app.AntResponse-string"
                         }
                     }
                 }
-            }
-        },
-        "/readyz": {
-            "get": {
-                "description": "This endpoint checks if the CB-Ant API server is ready by verifying the status of both the load service and the cost service. If either service is unavailable, it returns a 503 status indicating the server is not ready.",
+            },
+            "post": {
+                "description": "Run the load test from the load generators in several regions at once against the same target.\nA regional load generator is installed near the coordinates of each region (such as 37.53/127.02) on its own MCI, and reused by the following multi region load tests with the same region name.\nThe virtual users, or the targets and the max virtual users of the load profile, are divided among the regions by their weights (1 by default).\nEach region runs its own load test, and the result reports the statistics of each label per region.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1096,400 +1127,3294 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "[Server Health]"
+                    "[Multi Region Load Test Management]"
+                ],
+                "summary": "Run Multi Region Load Test",
+                "operationId": "RunMultiRegionLoadTest",
+                "parameters": [
+                    {
+                        "description": "Multi Region Load Test Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app.RunMultiRegionLoadTestReq"
+                        }
+                    }
                 ],
-                "summary": "Check CB-Ant API server readiness",
-                "operationId": "AntServerReadiness",
                 "responses": {
                     "200": {
-                        "description": "CM-Ant API server is ready",
+                        "description": "Successfully started multi region load test",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/app.AntResponse-load_MultiRegionLoadTestResult"
                         }
                     },
-                    "503": {
-                        "description": "CB-Ant API server is not ready",
+                    "400": {
+                        "description": "multi region load test info is not correct.",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/app.AntResponse-string"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "app.AntResponse-array_load_LoadTestStatistics": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer"
-                },
-                "errorMessage": {
-                    "type": "string"
-                },
-                "result": {
-                    "type": "array",
+        },
+        "/api/v1/load/multi-region-tests/{multiRegionLoadTestId}": {
+            "get": {
+                "description": "Retrieve the status of the multi region load test with the load test key, the status and the location of the load generator of each region.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Multi Region Load Test Management]"
+                ],
+                "summary": "Get Multi Region Load Test",
+                "operationId": "GetMultiRegionLoadTest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Multi region load test id",
+                        "name": "multiRegionLoadTestId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved multi region load test",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-load_MultiRegionLoadTestResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Multi region load test id must be number.",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve multi region load test",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/load/multi-region-tests/{multiRegionLoadTestId}/result": {
+            "get": {
+                "description": "Retrieve the statistics of each label measured from each region, ordered by the label, so the latency of the same request can be compared between the regions.\nThe result of the region is included once its load test has fetched the result.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Multi Region Load Test Management]"
+                ],
+                "summary": "Get Multi Region Load Test Result",
+                "operationId": "GetMultiRegionLoadTestResult",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Multi region load test id",
+                        "name": "multiRegionLoadTestId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved multi region load test result",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-array_load_RegionLoadTestStatistics"
+                        }
+                    },
+                    "400": {
+                        "description": "Multi region load test id must be number.",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve multi region load test result",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/load/plans": {
+            "get": {
+                "description": "Retrieve a list of the stored test plans of the plan library with pagination support.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Load Test Plan Management]"
+                ],
+                "summary": "Get All Load Test Plans",
+                "operationId": "GetAllLoadTestPlans",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number for pagination (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items per page (default 10, max 10)",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved load test plans",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-load_GetAllLoadTestPlansResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameters",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve load test plans",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Upload a jmx test plan and the csv data files referenced by its csv data set configs into the plan library.\nThe plan is validated as a jmeter test plan with at least one thread group. When the plan is run, the csv data files and result listeners are bound to the load test key and the perfmon collectors are injected if the monitoring agent is used.\nThe stored plan is run by setting testPlanId of the run load test request. The thread groups of the plan are used as they are.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Load Test Plan Management]"
+                ],
+                "summary": "Upload Load Test Plan",
+                "operationId": "UploadLoadTestPlan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name of the test plan (default file name)",
+                        "name": "name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Description of the test plan",
+                        "name": "description",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Jmx test plan file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Csv data files referenced by the test plan",
+                        "name": "dataFiles",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully uploaded load test plan",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-load_LoadTestPlanResult"
+                        }
+                    },
+                    "400": {
+                        "description": "test plan is not valid.",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/load/plans/{planId}": {
+            "get": {
+                "description": "Retrieve a stored test plan and its data files by plan id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Load Test Plan Management]"
+                ],
+                "summary": "Get Load Test Plan",
+                "operationId": "GetLoadTestPlan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Load test plan id",
+                        "name": "planId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved load test plan",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-load_LoadTestPlanResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Load test plan id must be number.",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve load test plan",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a stored test plan and its data files. Load tests already run with the plan are not affected.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Load Test Plan Management]"
+                ],
+                "summary": "Delete Load Test Plan",
+                "operationId": "DeleteLoadTestPlan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Load test plan id",
+                        "name": "planId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully deleted load test plan",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    },
+                    "400": {
+                        "description": "Load test plan id must be number.",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete load test plan",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/load/queue": {
+            "get": {
+                "description": "Retrieve the running count and the queued load tests of each load generator in the order they will start.\nThe load tests on the same load generator run up to the configured max concurrent tests, and the others wait in the queue.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Load Test Queue Management]"
+                ],
+                "summary": "Get Load Test Queue",
+                "operationId": "GetLoadTestQueue",
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved load test queue",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-array_load_LoadGeneratorQueueResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/load/queue/{loadTestKey}": {
+            "put": {
+                "description": "Move the queued load test to the position in the queue of its load generator. The position starts from 1, and the position over the queue length moves it to the end.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Load Test Queue Management]"
+                ],
+                "summary": "Move Queued Load Test",
+                "operationId": "MoveQueuedLoadTest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Load test key",
+                        "name": "loadTestKey",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Move Queued Load Test Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app.MoveQueuedLoadTestReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully moved queued load test",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    },
+                    "400": {
+                        "description": "queue position is not correct.",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove the queued load test from the queue before it starts. The canceled load test is recorded as stopped.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Load Test Queue Management]"
+                ],
+                "summary": "Cancel Queued Load Test",
+                "operationId": "CancelQueuedLoadTest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Load test key",
+                        "name": "loadTestKey",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully canceled queued load test",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    },
+                    "400": {
+                        "description": "load test is not queued",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/load/schedules": {
+            "get": {
+                "description": "Retrieve a list of all load test schedules with pagination support.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Load Test Schedule Management]"
+                ],
+                "summary": "Get All Load Test Schedules",
+                "operationId": "GetAllLoadTestSchedules",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number for pagination (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items per page (default 10, max 10)",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved load test schedules",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-load_GetAllLoadTestSchedulesResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameters",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve load test schedules",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Register a load test which runs repeatedly according to the cron expression. (minute hour day-of-month month day-of-week or macros such as @daily)\nThe password and token of the auth, the values of the sensitive headers and the cookies are encrypted with load.keystore.masterKey, which must be set to schedule them, and are returned as ******.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Load Test Schedule Management]"
+                ],
+                "summary": "Create Load Test Schedule",
+                "operationId": "CreateLoadTestSchedule",
+                "parameters": [
+                    {
+                        "description": "Load Test Schedule Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app.LoadTestScheduleReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully created load test schedule",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-load_LoadTestScheduleResult"
+                        }
+                    },
+                    "400": {
+                        "description": "load test schedule info is not correct.",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create load test schedule",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/load/schedules/{scheduleId}": {
+            "get": {
+                "description": "Retrieve a load test schedule by schedule id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Load Test Schedule Management]"
+                ],
+                "summary": "Get Load Test Schedule",
+                "operationId": "GetLoadTestSchedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Load test schedule id",
+                        "name": "scheduleId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved load test schedule",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-load_LoadTestScheduleResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Load test schedule id must be number.",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    },
+                    "404": {
+                        "description": "Load test schedule is not found",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve load test schedule",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update the cron expression, enabled flag and load test configuration of the schedule.\nA credential given as ****** keeps the stored one of the http request with the same method, url and username.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Load Test Schedule Management]"
+                ],
+                "summary": "Update Load Test Schedule",
+                "operationId": "UpdateLoadTestSchedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Load test schedule id",
+                        "name": "scheduleId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Load Test Schedule Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app.LoadTestScheduleReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated load test schedule",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-load_LoadTestScheduleResult"
+                        }
+                    },
+                    "400": {
+                        "description": "load test schedule info is not correct.",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    },
+                    "404": {
+                        "description": "Load test schedule is not found",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update load test schedule",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a load test schedule. Load tests already started by the schedule are not affected.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Load Test Schedule Management]"
+                ],
+                "summary": "Delete Load Test Schedule",
+                "operationId": "DeleteLoadTestSchedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Load test schedule id",
+                        "name": "scheduleId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully deleted load test schedule",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    },
+                    "400": {
+                        "description": "Load test schedule id must be number.",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    },
+                    "404": {
+                        "description": "Load test schedule is not found",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete load test schedule",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/load/test/metrics": {
+            "get": {
+                "description": "Retrieve load test metrics based on provided parameters.\nThe metrics of the target servers collected by the monitoring agent are followed by the resource usage of the load generator\nsampled while the load test runs, labeled load_generator_cpu, load_generator_memory (%), load_generator_network_rx and load_generator_network_tx (Mbps).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Load Test Result]"
+                ],
+                "summary": "Get load test metrics",
+                "operationId": "GetLoadTestMetrics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Load test key",
+                        "name": "loadTestKey",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved load test metrics",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-array_load_MetricsSummary"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameters",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve load test metrics",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/load/test/result": {
+            "get": {
+                "description": "Retrieve load test result based on provided parameters.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Load Test Result]"
+                ],
+                "summary": "Get load test result",
+                "operationId": "GetLoadTestResult",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Load test key",
+                        "name": "loadTestKey",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Result format (normal, aggregate or timeseries)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bucket interval of timeseries format such as 1s, 10s or 1m (default 10s, min 1s). widened to its multiple when the result spans more than 10000 buckets",
+                        "name": "interval",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved load test metrics",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/app.JsonResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "[aggregate]": {
+                                            "$ref": "#/definitions/app.AntResponse-array_load_LoadTestStatistics"
+                                        },
+                                        "[normal]": {
+                                            "$ref": "#/definitions/app.AntResponse-array_load_ResultSummary"
+                                        },
+                                        "[timeseries]": {
+                                            "$ref": "#/definitions/app.AntResponse-array_load_ResultTimeSeries"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameters",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve load test result",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/load/tests/infos": {
+            "get": {
+                "description": "Retrieve a list of all load test execution information with pagination support.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Load Test Execution Management]"
+                ],
+                "summary": "Get All Load Test Execution Information",
+                "operationId": "GetAllLoadTestExecutionInfos",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number for pagination (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items per page (default 10, max 10)",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved load test execution information",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-load_GetAllLoadTestExecutionInfosResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameters",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve all load test execution information",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/load/tests/infos/{loadTestKey}": {
+            "get": {
+                "description": "Retrieve the load test execution state information for a specific load test key.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Load Test Execution Management]"
+                ],
+                "summary": "Get Load Test Execution State",
+                "operationId": "GetLoadTestExecutionInfo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Load test key",
+                        "name": "loadTestKey",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved load test execution state information",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-load_LoadTestExecutionInfoResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Load test key must be set.",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve load test execution state information",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/load/tests/result/compare": {
+            "get": {
+                "description": "Compare the aggregated results of the base load test (before migration) and the target load test (after migration) label by label.\nDeltas of average, p90, p95, p99, error percent and throughput are flagged as regressed when they exceed the thresholds.\nA delta from the base of 0 is marked fromZero, and any increase of the response time from 0 is regressed.\nLabels only in one of the results are marked added or removed, and a removed label is regressed.\nThresholds which are not passed use the values of the configuration.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Load Test Result]"
+                ],
+                "summary": "Compare load test results",
+                "operationId": "CompareLoadTestResult",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Load test key of the base (before migration) result",
+                        "name": "baseKey",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Load test key of the target (after migration) result",
+                        "name": "targetKey",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Allowed increase of response time in percent",
+                        "name": "latencyThreshold",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Allowed increase of error percent in percentage points",
+                        "name": "errorPercentThreshold",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Allowed decrease of throughput in percent",
+                        "name": "throughputThreshold",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully compared load test results",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-load_CompareLoadTestResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameters",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to compare load test results",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/load/tests/run": {
+            "post": {
+                "description": "Start a load test using the provided load test configuration.\nSLO rules such as ` + "`" + `{\"label\": \"home\", \"metric\": \"p95\", \"operator\": \"\u003c\", \"threshold\": 300}` + "`" + ` are evaluated after the result is fetched and the verdict is stored in the load test execution state.\nSupported metrics are average, median, p90, p95, p99, minTime, maxTime, errorPercent, throughput, receivedKB and sentKB. Supported operators are \u003c, \u003c=, \u003e and \u003e=.\nHttp requests support GET, HEAD, OPTIONS, POST, PUT, PATCH and DELETE methods with headers, cookies, content type, basic or bearer auth, timeouts in milliseconds and redirect policy (follow, auto or none).\nHttp requests are executed in order as the steps of a scenario. A step can extract variables from the response with jsonpath or regex extractors, and later steps refer to them as ` + "`" + `${variable}` + "`" + `.\nConsecutive steps with the same transaction name are grouped and reported as a transaction, and thinkTime pauses the virtual user for the given milliseconds after the step.\nSet testPlanId to run a test plan uploaded to the plan library instead of the plan generated from the http requests.\nSet engine to jmeter (default), k6 or native to choose the load generator engine. The k6 engine is installed on demand and runs on the master load generator only; uploaded test plans and worker VMs require jmeter.\nThe native engine sends the http requests from ant itself without any installation, so it only runs on the local load generator. Its results are written in the jmeter csv format.\nSet loadProfile to shape the load with stages instead of virtualUsers, rampUpTime, rampUpSteps and duration. Each stage changes the load linearly from the previous target to its target for the duration in seconds, starting from 0, and a stage of 0 duration changes the load at once.\nThe closed model shapes the concurrent virtual users, and the open model shapes the arrival rate, the iterations of the http requests started per second, limited by maxVirtualUsers.\nFor example, a spike is ` + "`" + `{\"model\": \"closed\", \"stages\": [{\"duration\": 60, \"target\": 10}, {\"duration\": 0, \"target\": 100}, {\"duration\": 120, \"target\": 100}, {\"duration\": 0, \"target\": 10}, {\"duration\": 60, \"target\": 10}]}` + "`" + ` and a soak is ` + "`" + `{\"model\": \"open\", \"stages\": [{\"duration\": 300, \"target\": 50}, {\"duration\": 14400, \"target\": 50}]}` + "`" + `.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Load Test Execution Management]"
+                ],
+                "summary": "Run Load Test",
+                "operationId": "RunLoadTest",
+                "parameters": [
+                    {
+                        "description": "Run Load Test Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app.RunLoadTestReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{loadTestKey}",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    },
+                    "400": {
+                        "description": "load test install location is invalid.",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "ant server has got error. please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/load/tests/state": {
+            "get": {
+                "description": "Retrieve a list of all load test execution states with pagination support.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Load Test State Management]"
+                ],
+                "summary": "Get All Load Test Execution State",
+                "operationId": "GetAllLoadTestExecutionState",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number for pagination (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items per page (default 10, max 10)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by load test key",
+                        "name": "loadTestKey",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by execution status",
+                        "name": "executionStatus",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved load test execution state information",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-load_GetAllLoadTestExecutionStateResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameters",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve load test execution state information",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/load/tests/state/{loadTestKey}": {
+            "get": {
+                "description": "Retrieve a load test execution state by load test key.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Load Test State Management]"
+                ],
+                "summary": "Get Load Test Execution State",
+                "operationId": "GetLoadTestExecutionState",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Load test key",
+                        "name": "loadTestKey",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved load test execution state information",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-load_LoadTestExecutionStateResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Load test key must be set.",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve load test execution state information",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/load/tests/stop": {
+            "post": {
+                "description": "Stop a running load test using the provided load test key.\nA load test in preparation is canceled before the load generator engine starts, and a running engine is killed.\nThe partial result is still fetched and the load test ends with the stopped status. Stopping a finished load test does nothing.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Load Test Execution Management]"
+                ],
+                "summary": "Stop Load Test",
+                "operationId": "StopLoadTest",
+                "parameters": [
+                    {
+                        "description": "Stop Load Test Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app.StopLoadTestReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "done",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    },
+                    "400": {
+                        "description": "load test running info is not correct.",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    },
+                    "500": {
+                        "description": "ant server has got error. please try again.",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/load/tests/{loadTestKey}/stream": {
+            "get": {
+                "description": "Push the progress of the load test as server-sent events until the load test is finished or the client disconnects.\nEach ` + "`" + `progress` + "`" + ` event carries the rolling rps, latency percentiles and error percent of each label and the latest perfmon metrics, which are computed from the partially fetched results.\nA ` + "`" + `done` + "`" + ` event is sent with the last progress when the load test is finished.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "[Load Test Result]"
+                ],
+                "summary": "Stream load test progress",
+                "operationId": "StreamLoadTestProgress",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Load test key",
+                        "name": "loadTestKey",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Rolling window of the statistics from 1s to 10m such as 10s or 1m (default 30s)",
+                        "name": "window",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of load test progress events",
+                        "schema": {
+                            "$ref": "#/definitions/load.LoadTestProgress"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameters",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    },
+                    "404": {
+                        "description": "Load test is not found",
+                        "schema": {
+                            "$ref": "#/definitions/app.AntResponse-string"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "This endpoint checks if the CB-Ant API server is ready by verifying the status of both the load service and the cost service. If either service is unavailable, it returns a 503 status indicating the server is not ready.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "[Server Health]"
+                ],
+                "summary": "Check CB-Ant API server readiness",
+                "operationId": "AntServerReadiness",
+                "responses": {
+                    "200": {
+                        "description": "CM-Ant API server is ready",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "CB-Ant API server is not ready",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "app.AntResponse-array_load_LoadGeneratorQueueResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "result": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/load.LoadGeneratorQueueResult"
+                    }
+                },
+                "successMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.AntResponse-array_load_LoadTestStatistics": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "result": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/load.LoadTestStatistics"
+                    }
+                },
+                "successMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.AntResponse-array_load_MetricsSummary": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "result": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/load.MetricsSummary"
+                    }
+                },
+                "successMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.AntResponse-array_load_RegionLoadTestStatistics": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "result": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/load.RegionLoadTestStatistics"
+                    }
+                },
+                "successMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.AntResponse-array_load_ResultSummary": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "result": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/load.ResultSummary"
+                    }
+                },
+                "successMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.AntResponse-array_load_ResultTimeSeries": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "result": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/load.ResultTimeSeries"
+                    }
+                },
+                "successMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.AntResponse-cost_EstimateCostInfoResults": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "result": {
+                    "$ref": "#/definitions/cost.EstimateCostInfoResults"
+                },
+                "successMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.AntResponse-cost_EstimateCostResults": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "result": {
+                    "$ref": "#/definitions/cost.EstimateCostResults"
+                },
+                "successMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.AntResponse-cost_GetEstimateForecastCostInfoResults": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "result": {
+                    "$ref": "#/definitions/cost.GetEstimateForecastCostInfoResults"
+                },
+                "successMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.AntResponse-cost_UpdateEstimateForecastCostInfoResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "result": {
+                    "$ref": "#/definitions/cost.UpdateEstimateForecastCostInfoResult"
+                },
+                "successMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.AntResponse-int64": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "result": {
+                    "type": "integer"
+                },
+                "successMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.AntResponse-load_CapacitySearchResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "result": {
+                    "$ref": "#/definitions/load.CapacitySearchResult"
+                },
+                "successMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.AntResponse-load_CompareLoadTestResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "result": {
+                    "$ref": "#/definitions/load.CompareLoadTestResult"
+                },
+                "successMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.AntResponse-load_GetAllCapacitySearchesResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "result": {
+                    "$ref": "#/definitions/load.GetAllCapacitySearchesResult"
+                },
+                "successMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.AntResponse-load_GetAllLoadGeneratorInstallInfoResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "result": {
+                    "$ref": "#/definitions/load.GetAllLoadGeneratorInstallInfoResult"
+                },
+                "successMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.AntResponse-load_GetAllLoadTestExecutionInfosResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "result": {
+                    "$ref": "#/definitions/load.GetAllLoadTestExecutionInfosResult"
+                },
+                "successMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.AntResponse-load_GetAllLoadTestExecutionStateResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "result": {
+                    "$ref": "#/definitions/load.GetAllLoadTestExecutionStateResult"
+                },
+                "successMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.AntResponse-load_GetAllLoadTestPlansResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "result": {
+                    "$ref": "#/definitions/load.GetAllLoadTestPlansResult"
+                },
+                "successMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.AntResponse-load_GetAllLoadTestSchedulesResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "result": {
+                    "$ref": "#/definitions/load.GetAllLoadTestSchedulesResult"
+                },
+                "successMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.AntResponse-load_GetAllMonitoringAgentInfoResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "result": {
+                    "$ref": "#/definitions/load.GetAllMonitoringAgentInfoResult"
+                },
+                "successMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.AntResponse-load_GetAllMultiRegionLoadTestsResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "result": {
+                    "$ref": "#/definitions/load.GetAllMultiRegionLoadTestsResult"
+                },
+                "successMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.AntResponse-load_LoadGeneratorHealthResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "result": {
+                    "$ref": "#/definitions/load.LoadGeneratorHealthResult"
+                },
+                "successMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.AntResponse-load_LoadGeneratorInstallInfoResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "result": {
+                    "$ref": "#/definitions/load.LoadGeneratorInstallInfoResult"
+                },
+                "successMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.AntResponse-load_LoadGeneratorKeyResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "result": {
+                    "$ref": "#/definitions/load.LoadGeneratorKeyResult"
+                },
+                "successMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.AntResponse-load_LoadTestExecutionInfoResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "result": {
+                    "$ref": "#/definitions/load.LoadTestExecutionInfoResult"
+                },
+                "successMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.AntResponse-load_LoadTestExecutionStateResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "result": {
+                    "$ref": "#/definitions/load.LoadTestExecutionStateResult"
+                },
+                "successMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.AntResponse-load_LoadTestPlanResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "result": {
+                    "$ref": "#/definitions/load.LoadTestPlanResult"
+                },
+                "successMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.AntResponse-load_LoadTestScheduleResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "result": {
+                    "$ref": "#/definitions/load.LoadTestScheduleResult"
+                },
+                "successMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.AntResponse-load_MonitoringAgentInstallationResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "result": {
+                    "$ref": "#/definitions/load.MonitoringAgentInstallationResult"
+                },
+                "successMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.AntResponse-load_MultiRegionLoadTestResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "result": {
+                    "$ref": "#/definitions/load.MultiRegionLoadTestResult"
+                },
+                "successMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.AntResponse-string": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "result": {
+                    "type": "string"
+                },
+                "successMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.AwsAdditionalInfoReq": {
+            "type": "object",
+            "properties": {
+                "ownerId": {
+                    "type": "string"
+                },
+                "regions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "app.CostResourceReq": {
+            "type": "object",
+            "properties": {
+                "resourceIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "resourceType": {
+                    "$ref": "#/definitions/constant.ResourceType"
+                }
+            }
+        },
+        "app.InstallLoadGeneratorReq": {
+            "type": "object",
+            "properties": {
+                "coordinates": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "image": {
+                    "type": "string"
+                },
+                "installLocation": {
+                    "$ref": "#/definitions/constant.InstallLocation"
+                },
+                "maxMemoryGiB": {
+                    "type": "integer"
+                },
+                "maxVCpu": {
+                    "type": "integer"
+                },
+                "minMemoryGiB": {
+                    "type": "integer"
+                },
+                "minVCpu": {
+                    "type": "integer"
+                },
+                "priority": {
+                    "type": "string"
+                },
+                "providers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "regionName": {
+                    "type": "string"
+                },
+                "rootDiskSize": {
+                    "type": "integer"
+                },
+                "spec": {
+                    "type": "string"
+                },
+                "workerCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "app.JsonResult": {
+            "type": "object"
+        },
+        "app.LoadProfileReq": {
+            "type": "object",
+            "properties": {
+                "maxVirtualUsers": {
+                    "type": "integer"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "stages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.LoadStageReq"
+                    }
+                }
+            }
+        },
+        "app.LoadStageReq": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "type": "integer"
+                },
+                "target": {
+                    "type": "integer"
+                }
+            }
+        },
+        "app.LoadTestRegionReq": {
+            "type": "object",
+            "properties": {
+                "coordinates": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "regionName": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "integer"
+                }
+            }
+        },
+        "app.LoadTestScheduleReq": {
+            "type": "object",
+            "properties": {
+                "cronExpression": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "runLoadTest": {
+                    "$ref": "#/definitions/app.RunLoadTestReq"
+                }
+            }
+        },
+        "app.MonitoringAgentInstallationReq": {
+            "type": "object",
+            "properties": {
+                "mciId": {
+                    "type": "string"
+                },
+                "nsId": {
+                    "type": "string"
+                },
+                "vmIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "app.MoveQueuedLoadTestReq": {
+            "type": "object",
+            "properties": {
+                "queuePosition": {
+                    "type": "integer"
+                }
+            }
+        },
+        "app.RegisterLoadGeneratorReq": {
+            "type": "object",
+            "properties": {
+                "label": {
+                    "type": "string"
+                },
+                "privateIp": {
+                    "type": "string"
+                },
+                "privateKeyName": {
+                    "type": "string"
+                },
+                "publicIp": {
+                    "type": "string"
+                },
+                "sshPort": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.RunCapacitySearchReq": {
+            "type": "object",
+            "properties": {
+                "loadModel": {
+                    "type": "string"
+                },
+                "maxErrorPercent": {
+                    "type": "number"
+                },
+                "maxLoad": {
+                    "type": "integer"
+                },
+                "maxNinetyFive": {
+                    "type": "number"
+                },
+                "maxVirtualUsers": {
+                    "type": "integer"
+                },
+                "runLoadTest": {
+                    "$ref": "#/definitions/app.RunLoadTestReq"
+                },
+                "startLoad": {
+                    "type": "integer"
+                },
+                "stepDuration": {
+                    "type": "integer"
+                },
+                "stepLoad": {
+                    "type": "integer"
+                }
+            }
+        },
+        "app.RunLoadGeneratorExtractorReq": {
+            "type": "object",
+            "properties": {
+                "defaultValue": {
+                    "type": "string"
+                },
+                "expression": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "matchNumber": {
+                    "type": "integer"
+                },
+                "template": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "variable": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.RunLoadGeneratorHttpAuthReq": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.RunLoadGeneratorHttpHeaderReq": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.RunLoadGeneratorHttpReq": {
+            "type": "object",
+            "properties": {
+                "auth": {
+                    "$ref": "#/definitions/app.RunLoadGeneratorHttpAuthReq"
+                },
+                "bodyData": {
+                    "type": "string"
+                },
+                "connectTimeout": {
+                    "type": "integer"
+                },
+                "contentType": {
+                    "type": "string"
+                },
+                "cookies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.RunLoadGeneratorHttpHeaderReq"
+                    }
+                },
+                "extractors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.RunLoadGeneratorExtractorReq"
+                    }
+                },
+                "headers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.RunLoadGeneratorHttpHeaderReq"
+                    }
+                },
+                "hostname": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "port": {
+                    "type": "string"
+                },
+                "protocol": {
+                    "type": "string"
+                },
+                "redirectPolicy": {
+                    "type": "string"
+                },
+                "responseTimeout": {
+                    "type": "integer"
+                },
+                "thinkTime": {
+                    "type": "integer"
+                },
+                "transaction": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.RunLoadTestReq": {
+            "type": "object",
+            "properties": {
+                "agentHostname": {
+                    "type": "string"
+                },
+                "agentInstalled": {
+                    "type": "boolean"
+                },
+                "duration": {
+                    "type": "string"
+                },
+                "engine": {
+                    "type": "string"
+                },
+                "hostname": {
+                    "type": "string"
+                },
+                "httpReqs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.RunLoadGeneratorHttpReq"
+                    }
+                },
+                "installLoadGenerator": {
+                    "$ref": "#/definitions/app.InstallLoadGeneratorReq"
+                },
+                "loadGeneratorInstallInfoId": {
+                    "type": "integer"
+                },
+                "loadProfile": {
+                    "$ref": "#/definitions/app.LoadProfileReq"
+                },
+                "port": {
+                    "type": "string"
+                },
+                "rampUpSteps": {
+                    "type": "string"
+                },
+                "rampUpTime": {
+                    "type": "string"
+                },
+                "sloRules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.SloRuleReq"
+                    }
+                },
+                "testName": {
+                    "type": "string"
+                },
+                "testPlanId": {
+                    "type": "integer"
+                },
+                "virtualUsers": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.RunMultiRegionLoadTestReq": {
+            "type": "object",
+            "properties": {
+                "regions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.LoadTestRegionReq"
+                    }
+                },
+                "runLoadTest": {
+                    "$ref": "#/definitions/app.RunLoadTestReq"
+                }
+            }
+        },
+        "app.SloRuleReq": {
+            "type": "object",
+            "properties": {
+                "label": {
+                    "type": "string"
+                },
+                "metric": {
+                    "type": "string"
+                },
+                "operator": {
+                    "type": "string"
+                },
+                "threshold": {
+                    "type": "number"
+                }
+            }
+        },
+        "app.StopLoadTestReq": {
+            "type": "object",
+            "properties": {
+                "loadTestKey": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.UpdateAndGetEstimateCostReq": {
+            "type": "object",
+            "properties": {
+                "specs": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "required": [
+                            "instanceType",
+                            "providerName",
+                            "regionName"
+                        ],
+                        "properties": {
+                            "image": {
+                                "type": "string"
+                            },
+                            "instanceType": {
+                                "type": "string"
+                            },
+                            "providerName": {
+                                "type": "string"
+                            },
+                            "regionName": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "specsWithFormat": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "required": [
+                            "commonSpec"
+                        ],
+                        "properties": {
+                            "commonImage": {
+                                "type": "string"
+                            },
+                            "commonSpec": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "app.UpdateEstimateForecastCostRawReq": {
+            "type": "object",
+            "required": [
+                "costResources"
+            ],
+            "properties": {
+                "awsAdditionalInfo": {
+                    "$ref": "#/definitions/app.AwsAdditionalInfoReq"
+                },
+                "costResources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/app.CostResourceReq"
+                    }
+                }
+            }
+        },
+        "app.UpdateEstimateForecastCostReq": {
+            "type": "object",
+            "properties": {
+                "mciId": {
+                    "type": "string"
+                },
+                "nsId": {
+                    "type": "string"
+                }
+            }
+        },
+        "app.VerifyLoadGeneratorReq": {
+            "type": "object",
+            "properties": {
+                "reinstall": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "constant.ExecutionStatus": {
+            "type": "string",
+            "enum": [
+                "queued",
+                "on_preparing",
+                "on_running",
+                "on_fetching",
+                "successed",
+                "test_failed",
+                "update_failed",
+                "result_failed",
+                "stopped",
+                "failed",
+                "processing",
+                "fetching",
+                "success"
+            ],
+            "x-enum-varnames": [
+                "Queued",
+                "OnPreparing",
+                "OnRunning",
+                "OnFetching",
+                "Successed",
+                "TestFailed",
+                "UpdateFailed",
+                "ResultFailed",
+                "Stopped",
+                "Failed",
+                "Processing",
+                "Fetching",
+                "Success"
+            ]
+        },
+        "constant.InstallLocation": {
+            "type": "string",
+            "enum": [
+                "local",
+                "remote",
+                "ssh"
+            ],
+            "x-enum-varnames": [
+                "Local",
+                "Remote",
+                "Ssh"
+            ]
+        },
+        "constant.LoadGeneratorType": {
+            "type": "string",
+            "enum": [
+                "jmeter",
+                "k6",
+                "native"
+            ],
+            "x-enum-varnames": [
+                "Jmeter",
+                "K6",
+                "Native"
+            ]
+        },
+        "constant.PriceCurrency": {
+            "type": "string",
+            "enum": [
+                "USD",
+                "KRW"
+            ],
+            "x-enum-varnames": [
+                "USD",
+                "KRW"
+            ]
+        },
+        "constant.PricePolicy": {
+            "type": "string",
+            "enum": [
+                "OnDemand"
+            ],
+            "x-enum-varnames": [
+                "OnDemand"
+            ]
+        },
+        "constant.PriceUnit": {
+            "type": "string",
+            "enum": [
+                "PerHour",
+                "PerYear"
+            ],
+            "x-enum-varnames": [
+                "PerHour",
+                "PerYear"
+            ]
+        },
+        "constant.ResourceType": {
+            "type": "string",
+            "enum": [
+                "VM",
+                "VNet",
+                "DataDisk",
+                "Etc"
+            ],
+            "x-enum-varnames": [
+                "VM",
+                "VNet",
+                "DataDisk",
+                "Etc"
+            ]
+        },
+        "constant.Verdict": {
+            "type": "string",
+            "enum": [
+                "passed",
+                "failed",
+                "error"
+            ],
+            "x-enum-varnames": [
+                "VerdictPassed",
+                "VerdictFailed",
+                "VerdictError"
+            ]
+        },
+        "constant.VmPriority": {
+            "type": "string",
+            "enum": [
+                "location",
+                "cost",
+                "performance"
+            ],
+            "x-enum-varnames": [
+                "LocationPriority",
+                "CostPriority",
+                "PerformancePriority"
+            ]
+        },
+        "cost.EsimateCostSpecResults": {
+            "type": "object",
+            "properties": {
+                "estimateForecastCostSpecDetailResults": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/cost.EstimateCostSpecDetailResult"
+                    }
+                },
+                "imageName": {
+                    "type": "string"
+                },
+                "instanceType": {
+                    "type": "string"
+                },
+                "providerName": {
+                    "type": "string"
+                },
+                "regionName": {
+                    "type": "string"
+                },
+                "totalMaxMonthlyPrice": {
+                    "type": "number"
+                },
+                "totalMinMonthlyPrice": {
+                    "type": "number"
+                }
+            }
+        },
+        "cost.EstimateCostInfoResult": {
+            "type": "object",
+            "properties": {
+                "calculatedMonthlyPrice": {
+                    "type": "number"
+                },
+                "currency": {
+                    "$ref": "#/definitions/constant.PriceCurrency"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "instanceType": {
+                    "type": "string"
+                },
+                "lastUpdatedAt": {
+                    "type": "string"
+                },
+                "memory": {
+                    "type": "string"
+                },
+                "originalPricePolicy": {
+                    "type": "string"
+                },
+                "osType": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "string"
+                },
+                "priceDescription": {
+                    "type": "string"
+                },
+                "pricePolicy": {
+                    "$ref": "#/definitions/constant.PricePolicy"
+                },
+                "productDescription": {
+                    "type": "string"
+                },
+                "providerName": {
+                    "type": "string"
+                },
+                "regionName": {
+                    "type": "string"
+                },
+                "storage": {
+                    "type": "string"
+                },
+                "unit": {
+                    "$ref": "#/definitions/constant.PriceUnit"
+                },
+                "vCpu": {
+                    "type": "string"
+                }
+            }
+        },
+        "cost.EstimateCostInfoResults": {
+            "type": "object",
+            "properties": {
+                "estimateCostInfoResult": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/cost.EstimateCostInfoResult"
+                    }
+                },
+                "resultCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "cost.EstimateCostResults": {
+            "type": "object",
+            "properties": {
+                "esimateCostSpecResults": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/cost.EsimateCostSpecResults"
+                    }
+                }
+            }
+        },
+        "cost.EstimateCostSpecDetailResult": {
+            "type": "object",
+            "properties": {
+                "calculatedMonthlyPrice": {
+                    "type": "number"
+                },
+                "currency": {
+                    "$ref": "#/definitions/constant.PriceCurrency"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lastUpdatedAt": {
+                    "type": "string"
+                },
+                "memory": {
+                    "type": "string"
+                },
+                "originalPricePolicy": {
+                    "type": "string"
+                },
+                "osType": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "string"
+                },
+                "priceDescription": {
+                    "type": "string"
+                },
+                "pricePolicy": {
+                    "$ref": "#/definitions/constant.PricePolicy"
+                },
+                "productDescription": {
+                    "type": "string"
+                },
+                "storage": {
+                    "type": "string"
+                },
+                "unit": {
+                    "$ref": "#/definitions/constant.PriceUnit"
+                },
+                "vCpu": {
+                    "type": "string"
+                }
+            }
+        },
+        "cost.GetEstimateForecastCostInfoResult": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "resourceId": {
+                    "type": "string"
+                },
+                "resourceType": {
+                    "type": "string"
+                },
+                "totalCost": {
+                    "type": "number"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "cost.GetEstimateForecastCostInfoResults": {
+            "type": "object",
+            "properties": {
+                "getEstimateForecastCostInfoResults": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/cost.GetEstimateForecastCostInfoResult"
+                    }
+                },
+                "resultCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "cost.UpdateEstimateForecastCostInfoResult": {
+            "type": "object",
+            "properties": {
+                "fetchedDataCount": {
+                    "type": "integer"
+                },
+                "insertedDataCount": {
+                    "type": "integer"
+                },
+                "updatedDataCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "load.CapacitySearchResult": {
+            "type": "object",
+            "properties": {
+                "breakingLoad": {
+                    "type": "integer"
+                },
+                "breakingReason": {
+                    "type": "string"
+                },
+                "breakingStep": {
+                    "type": "integer"
+                },
+                "failureMessage": {
+                    "type": "string"
+                },
+                "finishAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "maxSustainableLoad": {
+                    "type": "integer"
+                },
+                "maxSustainableThroughput": {
+                    "type": "number"
+                },
+                "runCapacitySearchParam": {
+                    "$ref": "#/definitions/load.RunCapacitySearchParam"
+                },
+                "startAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/constant.ExecutionStatus"
+                },
+                "steps": {
+                    "type": "array",
                     "items": {
-                        "$ref": "#/definitions/load.LoadTestStatistics"
+                        "$ref": "#/definitions/load.CapacitySearchStepResult"
                     }
                 },
-                "successMessage": {
+                "testName": {
                     "type": "string"
                 }
             }
         },
-        "app.AntResponse-array_load_MetricsSummary": {
+        "load.CapacitySearchStepResult": {
             "type": "object",
             "properties": {
-                "code": {
+                "errorPercent": {
+                    "type": "number"
+                },
+                "load": {
                     "type": "integer"
                 },
-                "errorMessage": {
+                "loadTestKey": {
                     "type": "string"
                 },
-                "result": {
+                "message": {
+                    "type": "string"
+                },
+                "ninetyFive": {
+                    "type": "number"
+                },
+                "passed": {
+                    "type": "boolean"
+                },
+                "requestCount": {
+                    "type": "integer"
+                },
+                "step": {
+                    "type": "integer"
+                },
+                "throughput": {
+                    "type": "number"
+                }
+            }
+        },
+        "load.CompareLoadTestResult": {
+            "type": "object",
+            "properties": {
+                "baseKey": {
+                    "type": "string"
+                },
+                "comparisons": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/load.MetricsSummary"
+                        "$ref": "#/definitions/load.LabelComparison"
                     }
                 },
-                "successMessage": {
+                "regressed": {
+                    "type": "boolean"
+                },
+                "targetKey": {
                     "type": "string"
+                },
+                "thresholds": {
+                    "$ref": "#/definitions/load.CompareThresholds"
                 }
             }
         },
-        "app.AntResponse-array_load_ResultSummary": {
+        "load.CompareThresholds": {
             "type": "object",
             "properties": {
-                "code": {
+                "errorPercentThreshold": {
+                    "type": "number"
+                },
+                "latencyThreshold": {
+                    "type": "number"
+                },
+                "throughputThreshold": {
+                    "type": "number"
+                }
+            }
+        },
+        "load.GetAllCapacitySearchesResult": {
+            "type": "object",
+            "properties": {
+                "capacitySearches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/load.CapacitySearchResult"
+                    }
+                },
+                "totalRow": {
                     "type": "integer"
+                }
+            }
+        },
+        "load.GetAllLoadGeneratorInstallInfoResult": {
+            "type": "object",
+            "properties": {
+                "loadGeneratorInstallInfoResults": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/load.LoadGeneratorInstallInfoResult"
+                    }
                 },
-                "errorMessage": {
+                "totalRows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "load.GetAllLoadTestExecutionInfosResult": {
+            "type": "object",
+            "properties": {
+                "loadTestExecutionInfos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/load.LoadTestExecutionInfoResult"
+                    }
+                },
+                "totalRow": {
+                    "type": "integer"
+                }
+            }
+        },
+        "load.GetAllLoadTestExecutionStateResult": {
+            "type": "object",
+            "properties": {
+                "loadTestExecutionStates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/load.LoadTestExecutionStateResult"
+                    }
+                },
+                "totalRow": {
+                    "type": "integer"
+                }
+            }
+        },
+        "load.GetAllLoadTestPlansResult": {
+            "type": "object",
+            "properties": {
+                "loadTestPlans": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/load.LoadTestPlanResult"
+                    }
+                },
+                "totalRow": {
+                    "type": "integer"
+                }
+            }
+        },
+        "load.GetAllLoadTestSchedulesResult": {
+            "type": "object",
+            "properties": {
+                "loadTestSchedules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/load.LoadTestScheduleResult"
+                    }
+                },
+                "totalRow": {
+                    "type": "integer"
+                }
+            }
+        },
+        "load.GetAllMonitoringAgentInfoResult": {
+            "type": "object",
+            "properties": {
+                "monitoringAgentInfos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/load.MonitoringAgentInstallationResult"
+                    }
+                },
+                "totalRow": {
+                    "type": "integer"
+                }
+            }
+        },
+        "load.GetAllMultiRegionLoadTestsResult": {
+            "type": "object",
+            "properties": {
+                "multiRegionLoadTests": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/load.MultiRegionLoadTestResult"
+                    }
+                },
+                "totalRow": {
+                    "type": "integer"
+                }
+            }
+        },
+        "load.InstallLoadGeneratorParam": {
+            "type": "object",
+            "properties": {
+                "coordinate": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "image": {
+                    "description": "Image is the common image such as aws+ap-northeast-2+ubuntu22.04, or the os such as ubuntu24.04 in the region of the spec.",
                     "type": "string"
                 },
-                "result": {
+                "installLocation": {
+                    "$ref": "#/definitions/constant.InstallLocation"
+                },
+                "maxMemoryGiB": {
+                    "type": "integer"
+                },
+                "maxVCpu": {
+                    "type": "integer"
+                },
+                "minMemoryGiB": {
+                    "type": "integer"
+                },
+                "minVCpu": {
+                    "type": "integer"
+                },
+                "priority": {
+                    "$ref": "#/definitions/constant.VmPriority"
+                },
+                "providers": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/load.ResultSummary"
+                        "type": "string"
                     }
                 },
-                "successMessage": {
+                "regionName": {
+                    "description": "RegionName installs the regional load generator on its own mci near the coordinates.\nThe default load generator is installed when it is empty.",
+                    "type": "string"
+                },
+                "rootDiskSize": {
+                    "description": "RootDiskSize is the root disk size in GB, the default of the csp when it is 0.",
+                    "type": "integer"
+                },
+                "spec": {
+                    "description": "Spec is the common spec such as aws+ap-northeast-2+t3.xlarge which is used without the recommendation.",
                     "type": "string"
+                },
+                "workerCount": {
+                    "type": "integer"
                 }
             }
         },
-        "app.AntResponse-cost_EstimateCostInfoResults": {
+        "load.LabelComparison": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer"
+                "base": {
+                    "$ref": "#/definitions/load.LoadTestStatistics"
                 },
-                "errorMessage": {
+                "change": {
+                    "description": "added or removed when the label is only in one of the results",
                     "type": "string"
                 },
-                "result": {
-                    "$ref": "#/definitions/cost.EstimateCostInfoResults"
+                "deltas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/load.MetricDelta"
+                    }
                 },
-                "successMessage": {
+                "label": {
                     "type": "string"
+                },
+                "regressed": {
+                    "type": "boolean"
+                },
+                "target": {
+                    "$ref": "#/definitions/load.LoadTestStatistics"
                 }
             }
         },
-        "app.AntResponse-cost_EstimateCostResults": {
+        "load.LoadGeneratorHealthResult": {
             "type": "object",
             "properties": {
-                "code": {
+                "checkedAt": {
+                    "type": "string"
+                },
+                "healthy": {
+                    "type": "boolean"
+                },
+                "loadGeneratorInstallInfoId": {
                     "type": "integer"
                 },
-                "errorMessage": {
+                "problems": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reinstalled": {
+                    "type": "boolean"
+                },
+                "servers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/load.LoadGeneratorServerHealthResult"
+                    }
+                }
+            }
+        },
+        "load.LoadGeneratorInstallInfoResult": {
+            "type": "object",
+            "properties": {
+                "clusterSize": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "healthCheckedAt": {
+                    "type": "string"
+                },
+                "healthMessage": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "installLocation": {
+                    "$ref": "#/definitions/constant.InstallLocation"
+                },
+                "installPath": {
+                    "type": "string"
+                },
+                "installType": {
+                    "description": "engines installed on the load generator such as jmeter,k6",
+                    "type": "string"
+                },
+                "installVersion": {
                     "type": "string"
                 },
-                "result": {
-                    "$ref": "#/definitions/cost.EstimateCostResults"
+                "isCluster": {
+                    "type": "boolean"
                 },
-                "successMessage": {
+                "lastUsedAt": {
                     "type": "string"
-                }
-            }
-        },
-        "app.AntResponse-cost_GetEstimateForecastCostInfoResults": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer"
                 },
-                "errorMessage": {
+                "lifecycleStatus": {
                     "type": "string"
                 },
-                "result": {
-                    "$ref": "#/definitions/cost.GetEstimateForecastCostInfoResults"
+                "loadGeneratorServers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/load.LoadGeneratorServerResult"
+                    }
                 },
-                "successMessage": {
-                    "type": "string"
-                }
-            }
-        },
-        "app.AntResponse-cost_UpdateEstimateForecastCostInfoResult": {
-            "type": "object",
-            "properties": {
-                "code": {
+                "masterId": {
                     "type": "integer"
                 },
-                "errorMessage": {
+                "privateKeyName": {
                     "type": "string"
                 },
-                "result": {
-                    "$ref": "#/definitions/cost.UpdateEstimateForecastCostInfoResult"
+                "publicKeyName": {
+                    "type": "string"
                 },
-                "successMessage": {
+                "regionName": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
                     "type": "string"
                 }
             }
         },
-        "app.AntResponse-int64": {
+        "load.LoadGeneratorKeyResult": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer"
+                "createdAt": {
+                    "type": "string"
                 },
-                "errorMessage": {
+                "fingerprint": {
                     "type": "string"
                 },
-                "result": {
+                "keyType": {
+                    "type": "string"
+                },
+                "loadGeneratorInstallInfoId": {
                     "type": "integer"
                 },
-                "successMessage": {
+                "publicKey": {
                     "type": "string"
+                },
+                "revokedFingerprints": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "app.AntResponse-load_GetAllLoadGeneratorInstallInfoResult": {
+        "load.LoadGeneratorQueueResult": {
             "type": "object",
             "properties": {
-                "code": {
+                "loadGeneratorInstallInfoId": {
                     "type": "integer"
                 },
-                "errorMessage": {
-                    "type": "string"
-                },
-                "result": {
-                    "$ref": "#/definitions/load.GetAllLoadGeneratorInstallInfoResult"
+                "queuedLoadTests": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/load.QueuedLoadTestResult"
+                    }
                 },
-                "successMessage": {
-                    "type": "string"
+                "runningCount": {
+                    "type": "integer"
                 }
             }
         },
-        "app.AntResponse-load_GetAllLoadTestExecutionInfosResult": {
+        "load.LoadGeneratorServerHealthResult": {
             "type": "object",
             "properties": {
-                "code": {
+                "freeDiskMb": {
                     "type": "integer"
                 },
-                "errorMessage": {
-                    "type": "string"
+                "id": {
+                    "type": "integer"
                 },
-                "result": {
-                    "$ref": "#/definitions/load.GetAllLoadTestExecutionInfosResult"
+                "javaVersion": {
+                    "type": "string"
                 },
-                "successMessage": {
+                "jmeterVersion": {
                     "type": "string"
-                }
-            }
-        },
-        "app.AntResponse-load_GetAllLoadTestExecutionStateResult": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer"
                 },
-                "errorMessage": {
+                "missingPlugins": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "problems": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "publicIp": {
                     "type": "string"
                 },
-                "result": {
-                    "$ref": "#/definitions/load.GetAllLoadTestExecutionStateResult"
+                "reachable": {
+                    "type": "boolean"
                 },
-                "successMessage": {
+                "status": {
+                    "type": "string"
+                },
+                "vmId": {
                     "type": "string"
                 }
             }
         },
-        "app.AntResponse-load_GetAllMonitoringAgentInfoResult": {
+        "load.LoadGeneratorServerResult": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer"
-                },
-                "errorMessage": {
+                "additionalVmKey": {
                     "type": "string"
                 },
-                "result": {
-                    "$ref": "#/definitions/load.GetAllMonitoringAgentInfoResult"
+                "createdAt": {
+                    "type": "string"
                 },
-                "successMessage": {
+                "csp": {
                     "type": "string"
-                }
-            }
-        },
-        "app.AntResponse-load_LoadGeneratorInstallInfoResult": {
-            "type": "object",
-            "properties": {
-                "code": {
+                },
+                "id": {
                     "type": "integer"
                 },
-                "errorMessage": {
+                "isCluster": {
+                    "type": "boolean"
+                },
+                "isMaster": {
+                    "type": "boolean"
+                },
+                "label": {
                     "type": "string"
                 },
-                "result": {
-                    "$ref": "#/definitions/load.LoadGeneratorInstallInfoResult"
+                "lat": {
+                    "type": "string"
                 },
-                "successMessage": {
+                "lon": {
                     "type": "string"
-                }
-            }
-        },
-        "app.AntResponse-load_LoadTestExecutionInfoResult": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer"
                 },
-                "errorMessage": {
+                "machineType": {
                     "type": "string"
                 },
-                "result": {
-                    "$ref": "#/definitions/load.LoadTestExecutionInfoResult"
+                "privateIp": {
+                    "type": "string"
                 },
-                "successMessage": {
+                "publicDns": {
                     "type": "string"
-                }
-            }
-        },
-        "app.AntResponse-load_LoadTestExecutionStateResult": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer"
                 },
-                "errorMessage": {
+                "publicIp": {
                     "type": "string"
                 },
-                "result": {
-                    "$ref": "#/definitions/load.LoadTestExecutionStateResult"
+                "region": {
+                    "type": "string"
                 },
-                "successMessage": {
+                "sshPort": {
                     "type": "string"
-                }
-            }
-        },
-        "app.AntResponse-load_MonitoringAgentInstallationResult": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer"
                 },
-                "errorMessage": {
+                "startTime": {
                     "type": "string"
                 },
-                "result": {
-                    "$ref": "#/definitions/load.MonitoringAgentInstallationResult"
+                "status": {
+                    "type": "string"
                 },
-                "successMessage": {
+                "updatedAt": {
                     "type": "string"
-                }
-            }
-        },
-        "app.AntResponse-string": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer"
                 },
-                "errorMessage": {
+                "username": {
                     "type": "string"
                 },
-                "result": {
+                "vmId": {
                     "type": "string"
                 },
-                "successMessage": {
+                "zone": {
                     "type": "string"
                 }
             }
         },
-        "app.AwsAdditionalInfoReq": {
+        "load.LoadProfileParam": {
             "type": "object",
             "properties": {
-                "ownerId": {
+                "maxVirtualUsers": {
+                    "description": "MaxVirtualUsers limits the concurrent virtual users of the open model. (0 means unlimited)",
+                    "type": "integer"
+                },
+                "model": {
                     "type": "string"
                 },
-                "regions": {
+                "stages": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/load.LoadStageParam"
                     }
                 }
             }
         },
-        "app.CostResourceReq": {
+        "load.LoadStageParam": {
             "type": "object",
             "properties": {
-                "resourceIds": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "duration": {
+                    "type": "integer"
                 },
-                "resourceType": {
-                    "$ref": "#/definitions/constant.ResourceType"
-                }
-            }
-        },
-        "app.InstallLoadGeneratorReq": {
-            "type": "object",
-            "properties": {
-                "installLocation": {
-                    "$ref": "#/definitions/constant.InstallLocation"
+                "target": {
+                    "type": "integer"
                 }
             }
         },
-        "app.JsonResult": {
-            "type": "object"
-        },
-        "app.MonitoringAgentInstallationReq": {
+        "load.LoadTestExecutionHttpInfoResult": {
             "type": "object",
             "properties": {
-                "mciId": {
+                "authType": {
                     "type": "string"
                 },
-                "nsId": {
+                "bodyData": {
                     "type": "string"
                 },
-                "vmIds": {
+                "connectTimeout": {
+                    "type": "integer"
+                },
+                "contentType": {
+                    "type": "string"
+                },
+                "cookies": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/load.RunLoadTestHttpCookieParam"
+                    }
+                },
+                "extractors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/load.RunLoadTestExtractorParam"
+                    }
+                },
+                "headers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/load.RunLoadTestHttpHeaderParam"
                     }
-                }
-            }
-        },
-        "app.RunLoadGeneratorHttpReq": {
-            "type": "object",
-            "properties": {
-                "bodyData": {
-                    "type": "string"
                 },
                 "hostname": {
                     "type": "string"
                 },
+                "id": {
+                    "type": "integer"
+                },
                 "method": {
                     "type": "string"
                 },
+                "name": {
+                    "type": "string"
+                },
                 "path": {
                     "type": "string"
                 },
@@ -1498,10 +4423,22 @@ const docTemplate = `{
                 },
                 "protocol": {
                     "type": "string"
+                },
+                "redirectPolicy": {
+                    "type": "string"
+                },
+                "responseTimeout": {
+                    "type": "integer"
+                },
+                "thinkTime": {
+                    "type": "integer"
+                },
+                "transaction": {
+                    "type": "string"
                 }
             }
         },
-        "app.RunLoadTestReq": {
+        "load.LoadTestExecutionInfoResult": {
             "type": "object",
             "properties": {
                 "agentHostname": {
@@ -1510,23 +4447,41 @@ const docTemplate = `{
                 "agentInstalled": {
                     "type": "boolean"
                 },
+                "compileDuration": {
+                    "type": "string"
+                },
                 "duration": {
                     "type": "string"
                 },
+                "engine": {
+                    "$ref": "#/definitions/constant.LoadGeneratorType"
+                },
+                "executionDuration": {
+                    "type": "string"
+                },
                 "hostname": {
                     "type": "string"
                 },
-                "httpReqs": {
+                "id": {
+                    "type": "integer"
+                },
+                "loadGeneratorInstallInfo": {
+                    "$ref": "#/definitions/load.LoadGeneratorInstallInfoResult"
+                },
+                "loadProfile": {
+                    "$ref": "#/definitions/load.LoadProfileParam"
+                },
+                "loadTestExecutionHttpInfos": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/app.RunLoadGeneratorHttpReq"
+                        "$ref": "#/definitions/load.LoadTestExecutionHttpInfoResult"
                     }
                 },
-                "installLoadGenerator": {
-                    "$ref": "#/definitions/app.InstallLoadGeneratorReq"
+                "loadTestExecutionState": {
+                    "$ref": "#/definitions/load.LoadTestExecutionStateResult"
                 },
-                "loadGeneratorInstallInfoId": {
-                    "type": "integer"
+                "loadTestKey": {
+                    "type": "string"
                 },
                 "port": {
                     "type": "string"
@@ -1537,839 +4492,978 @@ const docTemplate = `{
                 "rampUpTime": {
                     "type": "string"
                 },
+                "sloRules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/load.SloRuleParam"
+                    }
+                },
                 "testName": {
                     "type": "string"
                 },
+                "testPlanId": {
+                    "type": "integer"
+                },
                 "virtualUsers": {
                     "type": "string"
                 }
             }
         },
-        "app.StopLoadTestReq": {
+        "load.LoadTestExecutionStateResult": {
             "type": "object",
             "properties": {
-                "loadTestKey": {
+                "compileDuration": {
                     "type": "string"
-                }
-            }
-        },
-        "app.UpdateAndGetEstimateCostReq": {
-            "type": "object",
-            "properties": {
-                "specs": {
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "executionDuration": {
+                    "type": "string"
+                },
+                "executionStatus": {
+                    "$ref": "#/definitions/constant.ExecutionStatus"
+                },
+                "failureMessage": {
+                    "type": "string"
+                },
+                "fileTransfers": {
                     "type": "array",
                     "items": {
-                        "type": "object",
-                        "required": [
-                            "instanceType",
-                            "providerName",
-                            "regionName"
-                        ],
-                        "properties": {
-                            "image": {
-                                "type": "string"
-                            },
-                            "instanceType": {
-                                "type": "string"
-                            },
-                            "providerName": {
-                                "type": "string"
-                            },
-                            "regionName": {
-                                "type": "string"
-                            }
-                        }
+                        "$ref": "#/definitions/load.LoadTestFileTransferResult"
                     }
                 },
-                "specsWithFormat": {
+                "finishAt": {
+                    "type": "string"
+                },
+                "generatorSaturated": {
+                    "type": "boolean"
+                },
+                "generatorSaturationMessage": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "loadGeneratorInstallInfo": {
+                    "$ref": "#/definitions/load.LoadGeneratorInstallInfoResult"
+                },
+                "loadGeneratorInstallInfoId": {
+                    "type": "integer"
+                },
+                "loadTestKey": {
+                    "type": "string"
+                },
+                "queuePosition": {
+                    "type": "integer"
+                },
+                "sloResults": {
                     "type": "array",
                     "items": {
-                        "type": "object",
-                        "required": [
-                            "commonSpec"
-                        ],
-                        "properties": {
-                            "commonImage": {
-                                "type": "string"
-                            },
-                            "commonSpec": {
-                                "type": "string"
-                            }
-                        }
+                        "$ref": "#/definitions/load.LoadTestSloResultResult"
                     }
+                },
+                "startAt": {
+                    "type": "string"
+                },
+                "totalExpectedExecutionSecond": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "verdict": {
+                    "$ref": "#/definitions/constant.Verdict"
                 }
             }
         },
-        "app.UpdateEstimateForecastCostRawReq": {
+        "load.LoadTestFileTransferResult": {
             "type": "object",
-            "required": [
-                "costResources"
-            ],
             "properties": {
-                "awsAdditionalInfo": {
-                    "$ref": "#/definitions/app.AwsAdditionalInfoReq"
+                "bytes": {
+                    "type": "integer"
                 },
-                "costResources": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/app.CostResourceReq"
-                    }
+                "fileName": {
+                    "type": "string"
+                },
+                "host": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "transferredAt": {
+                    "type": "string"
                 }
             }
         },
-        "app.UpdateEstimateForecastCostReq": {
+        "load.LoadTestPlanDataFileResult": {
             "type": "object",
             "properties": {
-                "mciId": {
+                "fileName": {
                     "type": "string"
                 },
-                "nsId": {
-                    "type": "string"
-                }
-            }
-        },
-        "constant.ExecutionStatus": {
-            "type": "string",
-            "enum": [
-                "on_preparing",
-                "on_running",
-                "on_fetching",
-                "successed",
-                "test_failed",
-                "update_failed",
-                "result_failed",
-                "failed",
-                "processing",
-                "fetching",
-                "success"
-            ],
-            "x-enum-varnames": [
-                "OnPreparing",
-                "OnRunning",
-                "OnFetching",
-                "Successed",
-                "TestFailed",
-                "UpdateFailed",
-                "ResultFailed",
-                "Failed",
-                "Processing",
-                "Fetching",
-                "Success"
-            ]
-        },
-        "constant.InstallLocation": {
-            "type": "string",
-            "enum": [
-                "local",
-                "remote"
-            ],
-            "x-enum-varnames": [
-                "Local",
-                "Remote"
-            ]
-        },
-        "constant.PriceCurrency": {
-            "type": "string",
-            "enum": [
-                "USD",
-                "KRW"
-            ],
-            "x-enum-varnames": [
-                "USD",
-                "KRW"
-            ]
-        },
-        "constant.PricePolicy": {
-            "type": "string",
-            "enum": [
-                "OnDemand"
-            ],
-            "x-enum-varnames": [
-                "OnDemand"
-            ]
-        },
-        "constant.PriceUnit": {
-            "type": "string",
-            "enum": [
-                "PerHour",
-                "PerYear"
-            ],
-            "x-enum-varnames": [
-                "PerHour",
-                "PerYear"
-            ]
-        },
-        "constant.ResourceType": {
-            "type": "string",
-            "enum": [
-                "VM",
-                "VNet",
-                "DataDisk",
-                "Etc"
-            ],
-            "x-enum-varnames": [
-                "VM",
-                "VNet",
-                "DataDisk",
-                "Etc"
-            ]
+                "id": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
         },
-        "cost.EsimateCostSpecResults": {
+        "load.LoadTestPlanResult": {
             "type": "object",
             "properties": {
-                "estimateForecastCostSpecDetailResults": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "dataFiles": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/cost.EstimateCostSpecDetailResult"
+                        "$ref": "#/definitions/load.LoadTestPlanDataFileResult"
                     }
                 },
-                "imageName": {
+                "description": {
                     "type": "string"
                 },
-                "instanceType": {
+                "fileName": {
                     "type": "string"
                 },
-                "providerName": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 },
-                "regionName": {
+                "updatedAt": {
                     "type": "string"
+                }
+            }
+        },
+        "load.LoadTestProgress": {
+            "type": "object",
+            "properties": {
+                "executionStatus": {
+                    "$ref": "#/definitions/constant.ExecutionStatus"
                 },
-                "totalMaxMonthlyPrice": {
-                    "type": "number"
+                "finished": {
+                    "type": "boolean"
                 },
-                "totalMinMonthlyPrice": {
-                    "type": "number"
+                "loadTestKey": {
+                    "type": "string"
+                },
+                "metrics": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/load.LoadTestProgressMetric"
+                    }
+                },
+                "statistics": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/load.LoadTestProgressStatistics"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "window": {
+                    "type": "string"
                 }
             }
         },
-        "cost.EstimateCostInfoResult": {
+        "load.LoadTestProgressMetric": {
             "type": "object",
             "properties": {
-                "calculatedMonthlyPrice": {
+                "label": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "load.LoadTestProgressStatistics": {
+            "type": "object",
+            "properties": {
+                "average": {
                     "type": "number"
                 },
-                "currency": {
-                    "$ref": "#/definitions/constant.PriceCurrency"
+                "errorPercent": {
+                    "type": "number"
                 },
-                "id": {
-                    "type": "integer"
+                "label": {
+                    "type": "string"
                 },
-                "instanceType": {
+                "lastSampleAt": {
                     "type": "string"
                 },
-                "lastUpdatedAt": {
+                "median": {
+                    "type": "number"
+                },
+                "ninetyFive": {
+                    "type": "number"
+                },
+                "ninetyNine": {
+                    "type": "number"
+                },
+                "ninetyPercent": {
+                    "type": "number"
+                },
+                "requestCount": {
+                    "type": "integer"
+                },
+                "rps": {
+                    "type": "number"
+                },
+                "totalErrorCount": {
+                    "type": "integer"
+                },
+                "totalRequestCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "load.LoadTestRegionParam": {
+            "type": "object",
+            "properties": {
+                "coordinates": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "regionName": {
                     "type": "string"
                 },
-                "memory": {
+                "weight": {
+                    "type": "integer"
+                }
+            }
+        },
+        "load.LoadTestScheduleResult": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
                     "type": "string"
                 },
-                "originalPricePolicy": {
+                "cronExpression": {
                     "type": "string"
                 },
-                "osType": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lastLoadTestKey": {
                     "type": "string"
                 },
-                "price": {
+                "lastRunAt": {
                     "type": "string"
                 },
-                "priceDescription": {
+                "lastRunMessage": {
                     "type": "string"
                 },
-                "pricePolicy": {
-                    "$ref": "#/definitions/constant.PricePolicy"
+                "loadGeneratorInstallInfoId": {
+                    "type": "integer"
                 },
-                "productDescription": {
+                "name": {
                     "type": "string"
                 },
-                "providerName": {
+                "nextRunAt": {
                     "type": "string"
                 },
-                "regionName": {
+                "runLoadTestParam": {
+                    "$ref": "#/definitions/load.RunLoadTestParam"
+                },
+                "updatedAt": {
                     "type": "string"
+                }
+            }
+        },
+        "load.LoadTestSloResultResult": {
+            "type": "object",
+            "properties": {
+                "actual": {
+                    "type": "number"
                 },
-                "storage": {
+                "label": {
                     "type": "string"
                 },
-                "unit": {
-                    "$ref": "#/definitions/constant.PriceUnit"
+                "message": {
+                    "type": "string"
                 },
-                "vCpu": {
+                "metric": {
                     "type": "string"
+                },
+                "operator": {
+                    "type": "string"
+                },
+                "passed": {
+                    "type": "boolean"
+                },
+                "threshold": {
+                    "type": "number"
                 }
             }
         },
-        "cost.EstimateCostInfoResults": {
+        "load.LoadTestStatistics": {
             "type": "object",
             "properties": {
-                "estimateCostInfoResult": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/cost.EstimateCostInfoResult"
-                    }
+                "average": {
+                    "type": "number"
                 },
-                "resultCount": {
+                "errorPercent": {
+                    "type": "number"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "maxTime": {
+                    "type": "number"
+                },
+                "median": {
+                    "type": "number"
+                },
+                "minTime": {
+                    "type": "number"
+                },
+                "ninetyFive": {
+                    "type": "number"
+                },
+                "ninetyNine": {
+                    "type": "number"
+                },
+                "ninetyPercent": {
+                    "type": "number"
+                },
+                "receivedKB": {
+                    "type": "number"
+                },
+                "requestCount": {
                     "type": "integer"
+                },
+                "sentKB": {
+                    "type": "number"
+                },
+                "throughput": {
+                    "type": "number"
                 }
             }
         },
-        "cost.EstimateCostResults": {
+        "load.MetricDelta": {
             "type": "object",
             "properties": {
-                "esimateCostSpecResults": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/cost.EsimateCostSpecResults"
-                    }
+                "base": {
+                    "type": "number"
+                },
+                "delta": {
+                    "type": "number"
+                },
+                "deltaPercent": {
+                    "type": "number"
+                },
+                "fromZero": {
+                    "description": "the base is 0, so the delta percent is infinite and left 0",
+                    "type": "boolean"
+                },
+                "metric": {
+                    "type": "string"
+                },
+                "regressed": {
+                    "type": "boolean"
+                },
+                "target": {
+                    "type": "number"
                 }
             }
         },
-        "cost.EstimateCostSpecDetailResult": {
+        "load.MetricsRawData": {
             "type": "object",
             "properties": {
-                "calculatedMonthlyPrice": {
-                    "type": "number"
-                },
-                "currency": {
-                    "$ref": "#/definitions/constant.PriceCurrency"
+                "isError": {
+                    "type": "boolean"
                 },
-                "id": {
-                    "type": "integer"
+                "timestamp": {
+                    "type": "string"
                 },
-                "lastUpdatedAt": {
+                "unit": {
                     "type": "string"
                 },
-                "memory": {
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "load.MetricsSummary": {
+            "type": "object",
+            "properties": {
+                "label": {
                     "type": "string"
                 },
-                "originalPricePolicy": {
+                "metrics": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/load.MetricsRawData"
+                    }
+                }
+            }
+        },
+        "load.MonitoringAgentInstallationResult": {
+            "type": "object",
+            "properties": {
+                "agentType": {
                     "type": "string"
                 },
-                "osType": {
+                "createdAt": {
                     "type": "string"
                 },
-                "price": {
+                "id": {
+                    "type": "integer"
+                },
+                "mciId": {
                     "type": "string"
                 },
-                "priceDescription": {
+                "nsId": {
                     "type": "string"
                 },
-                "pricePolicy": {
-                    "$ref": "#/definitions/constant.PricePolicy"
+                "status": {
+                    "type": "string"
                 },
-                "productDescription": {
+                "updatedAt": {
                     "type": "string"
                 },
-                "storage": {
+                "username": {
                     "type": "string"
                 },
-                "unit": {
-                    "$ref": "#/definitions/constant.PriceUnit"
+                "vmCount": {
+                    "type": "integer"
                 },
-                "vCpu": {
+                "vmId": {
                     "type": "string"
                 }
             }
         },
-        "cost.GetEstimateForecastCostInfoResult": {
+        "load.MultiRegionLoadTestRegionResult": {
             "type": "object",
             "properties": {
-                "category": {
+                "coordinates": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "csp": {
+                    "description": "the location of the load generator which is provisioned for the region.",
                     "type": "string"
                 },
-                "date": {
+                "executionStatus": {
+                    "$ref": "#/definitions/constant.ExecutionStatus"
+                },
+                "failureMessage": {
                     "type": "string"
                 },
-                "provider": {
+                "lat": {
                     "type": "string"
                 },
-                "resourceId": {
+                "loadGeneratorInstallInfoId": {
+                    "type": "integer"
+                },
+                "loadTestKey": {
                     "type": "string"
                 },
-                "resourceType": {
+                "lon": {
                     "type": "string"
                 },
-                "totalCost": {
-                    "type": "number"
+                "region": {
+                    "type": "string"
                 },
-                "unit": {
+                "regionName": {
                     "type": "string"
+                },
+                "weight": {
+                    "type": "integer"
                 }
             }
         },
-        "cost.GetEstimateForecastCostInfoResults": {
+        "load.MultiRegionLoadTestResult": {
             "type": "object",
             "properties": {
-                "getEstimateForecastCostInfoResults": {
+                "failureMessage": {
+                    "type": "string"
+                },
+                "finishAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "regions": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/cost.GetEstimateForecastCostInfoResult"
+                        "$ref": "#/definitions/load.MultiRegionLoadTestRegionResult"
                     }
                 },
-                "resultCount": {
-                    "type": "integer"
+                "runMultiRegionLoadTestParam": {
+                    "$ref": "#/definitions/load.RunMultiRegionLoadTestParam"
+                },
+                "startAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/constant.ExecutionStatus"
+                },
+                "testName": {
+                    "type": "string"
                 }
             }
         },
-        "cost.UpdateEstimateForecastCostInfoResult": {
+        "load.QueuedLoadTestResult": {
             "type": "object",
             "properties": {
-                "fetchedDataCount": {
-                    "type": "integer"
+                "loadTestKey": {
+                    "type": "string"
                 },
-                "insertedDataCount": {
+                "queuePosition": {
                     "type": "integer"
                 },
-                "updatedDataCount": {
-                    "type": "integer"
+                "queuedAt": {
+                    "type": "string"
+                },
+                "testName": {
+                    "type": "string"
                 }
             }
         },
-        "load.GetAllLoadGeneratorInstallInfoResult": {
+        "load.RegionLoadTestStatistics": {
             "type": "object",
             "properties": {
-                "loadGeneratorInstallInfoResults": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/load.LoadGeneratorInstallInfoResult"
-                    }
+                "average": {
+                    "type": "number"
                 },
-                "totalRows": {
+                "errorPercent": {
+                    "type": "number"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "loadTestKey": {
+                    "type": "string"
+                },
+                "maxTime": {
+                    "type": "number"
+                },
+                "median": {
+                    "type": "number"
+                },
+                "minTime": {
+                    "type": "number"
+                },
+                "ninetyFive": {
+                    "type": "number"
+                },
+                "ninetyNine": {
+                    "type": "number"
+                },
+                "ninetyPercent": {
+                    "type": "number"
+                },
+                "receivedKB": {
+                    "type": "number"
+                },
+                "regionName": {
+                    "type": "string"
+                },
+                "requestCount": {
                     "type": "integer"
+                },
+                "sentKB": {
+                    "type": "number"
+                },
+                "throughput": {
+                    "type": "number"
                 }
             }
         },
-        "load.GetAllLoadTestExecutionInfosResult": {
+        "load.ResultRawData": {
             "type": "object",
             "properties": {
-                "loadTestExecutionInfos": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/load.LoadTestExecutionInfoResult"
-                    }
+                "bytes": {
+                    "type": "integer"
                 },
-                "totalRow": {
+                "connection": {
+                    "description": "time to establish connection",
                     "type": "integer"
-                }
-            }
-        },
-        "load.GetAllLoadTestExecutionStateResult": {
-            "type": "object",
-            "properties": {
-                "loadTestExecutionStates": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/load.LoadTestExecutionStateResult"
-                    }
                 },
-                "totalRow": {
+                "elapsed": {
+                    "description": "time to last byte",
+                    "type": "integer"
+                },
+                "idleTime": {
+                    "description": "time not spent sampling in jmeter (milliseconds) (generally 0)",
+                    "type": "integer"
+                },
+                "isError": {
+                    "type": "boolean"
+                },
+                "latency": {
+                    "description": "time to first byte",
+                    "type": "integer"
+                },
+                "no": {
+                    "type": "integer"
+                },
+                "sentBytes": {
                     "type": "integer"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
                 }
             }
         },
-        "load.GetAllMonitoringAgentInfoResult": {
+        "load.ResultSummary": {
             "type": "object",
             "properties": {
-                "monitoringAgentInfos": {
+                "label": {
+                    "type": "string"
+                },
+                "results": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/load.MonitoringAgentInstallationResult"
+                        "$ref": "#/definitions/load.ResultRawData"
                     }
-                },
-                "totalRow": {
-                    "type": "integer"
                 }
             }
         },
-        "load.LoadGeneratorInstallInfoResult": {
+        "load.ResultTimeSeries": {
             "type": "object",
             "properties": {
-                "createdAt": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "installLocation": {
-                    "$ref": "#/definitions/constant.InstallLocation"
-                },
-                "installPath": {
-                    "type": "string"
-                },
-                "installType": {
-                    "type": "string"
-                },
-                "installVersion": {
-                    "type": "string"
-                },
-                "loadGeneratorServers": {
+                "buckets": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/load.LoadGeneratorServerResult"
+                        "$ref": "#/definitions/load.TimeSeriesBucket"
                     }
                 },
-                "privateKeyName": {
-                    "type": "string"
-                },
-                "publicKeyName": {
-                    "type": "string"
-                },
-                "status": {
+                "interval": {
                     "type": "string"
                 },
-                "updatedAt": {
+                "label": {
                     "type": "string"
                 }
             }
         },
-        "load.LoadGeneratorServerResult": {
+        "load.RunCapacitySearchParam": {
             "type": "object",
             "properties": {
-                "additionalVmKey": {
-                    "type": "string"
-                },
-                "createdAt": {
+                "loadModel": {
+                    "description": "the load of each step is the virtual users of the closed model or the requests per second of the open model.",
                     "type": "string"
                 },
-                "csp": {
-                    "type": "string"
+                "maxErrorPercent": {
+                    "type": "number"
                 },
-                "id": {
+                "maxLoad": {
                     "type": "integer"
                 },
-                "label": {
-                    "type": "string"
-                },
-                "lat": {
-                    "type": "string"
-                },
-                "lon": {
-                    "type": "string"
-                },
-                "machineType": {
-                    "type": "string"
+                "maxNinetyFive": {
+                    "type": "number"
                 },
-                "privateIp": {
-                    "type": "string"
+                "maxVirtualUsers": {
+                    "type": "integer"
                 },
-                "publicDns": {
-                    "type": "string"
+                "runLoadTestParam": {
+                    "$ref": "#/definitions/load.RunLoadTestParam"
                 },
-                "publicIp": {
-                    "type": "string"
+                "startLoad": {
+                    "type": "integer"
                 },
-                "region": {
-                    "type": "string"
+                "stepDuration": {
+                    "type": "integer"
                 },
-                "sshPort": {
+                "stepLoad": {
+                    "type": "integer"
+                }
+            }
+        },
+        "load.RunLoadTestExtractorParam": {
+            "type": "object",
+            "properties": {
+                "defaultValue": {
                     "type": "string"
                 },
-                "startTime": {
+                "expression": {
                     "type": "string"
                 },
-                "status": {
+                "field": {
+                    "description": "body or headers, default body",
                     "type": "string"
                 },
-                "updatedAt": {
-                    "type": "string"
+                "matchNumber": {
+                    "description": "nth match from 1, 0 is taken as the first match",
+                    "type": "integer"
                 },
-                "username": {
+                "template": {
+                    "description": "default $1$",
                     "type": "string"
                 },
-                "vmId": {
+                "type": {
                     "type": "string"
                 },
-                "zone": {
+                "variable": {
                     "type": "string"
                 }
             }
         },
-        "load.LoadTestExecutionHttpInfoResult": {
+        "load.RunLoadTestHttpAuthParam": {
             "type": "object",
             "properties": {
-                "bodyData": {
+                "password": {
                     "type": "string"
                 },
-                "hostname": {
+                "token": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
-                },
-                "method": {
+                "type": {
                     "type": "string"
                 },
-                "path": {
+                "username": {
                     "type": "string"
-                },
-                "port": {
+                }
+            }
+        },
+        "load.RunLoadTestHttpCookieParam": {
+            "type": "object",
+            "properties": {
+                "name": {
                     "type": "string"
                 },
-                "protocol": {
+                "value": {
                     "type": "string"
                 }
             }
         },
-        "load.LoadTestExecutionInfoResult": {
+        "load.RunLoadTestHttpHeaderParam": {
             "type": "object",
             "properties": {
-                "agentHostname": {
+                "name": {
                     "type": "string"
                 },
-                "agentInstalled": {
-                    "type": "boolean"
-                },
-                "compileDuration": {
+                "value": {
                     "type": "string"
+                }
+            }
+        },
+        "load.RunLoadTestHttpParam": {
+            "type": "object",
+            "properties": {
+                "auth": {
+                    "$ref": "#/definitions/load.RunLoadTestHttpAuthParam"
                 },
-                "duration": {
+                "bodyData": {
                     "type": "string"
                 },
-                "executionDuration": {
-                    "type": "string"
+                "connectTimeout": {
+                    "description": "milliseconds",
+                    "type": "integer"
                 },
-                "hostname": {
+                "contentType": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "cookies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/load.RunLoadTestHttpCookieParam"
+                    }
                 },
-                "loadGeneratorInstallInfo": {
-                    "$ref": "#/definitions/load.LoadGeneratorInstallInfoResult"
+                "extractors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/load.RunLoadTestExtractorParam"
+                    }
                 },
-                "loadTestExecutionHttpInfos": {
+                "headers": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/load.LoadTestExecutionHttpInfoResult"
+                        "$ref": "#/definitions/load.RunLoadTestHttpHeaderParam"
                     }
                 },
-                "loadTestExecutionState": {
-                    "$ref": "#/definitions/load.LoadTestExecutionStateResult"
+                "hostname": {
+                    "type": "string"
                 },
-                "loadTestKey": {
+                "method": {
                     "type": "string"
                 },
-                "port": {
+                "name": {
+                    "description": "http requests are run in order by every virtual user, so they are the steps of a scenario.\nvariables extracted from a step can be referenced as ${name} in the following steps.",
                     "type": "string"
                 },
-                "rampUpSteps": {
+                "path": {
                     "type": "string"
                 },
-                "rampUpTime": {
+                "port": {
                     "type": "string"
                 },
-                "testName": {
+                "protocol": {
                     "type": "string"
                 },
-                "virtualUsers": {
+                "redirectPolicy": {
+                    "description": "follow, auto or none",
+                    "type": "string"
+                },
+                "responseTimeout": {
+                    "description": "milliseconds",
+                    "type": "integer"
+                },
+                "thinkTime": {
+                    "description": "milliseconds to pause after the step",
+                    "type": "integer"
+                },
+                "transaction": {
+                    "description": "consecutive steps with the same transaction are grouped under one label",
                     "type": "string"
                 }
             }
         },
-        "load.LoadTestExecutionStateResult": {
+        "load.RunLoadTestParam": {
             "type": "object",
             "properties": {
-                "compileDuration": {
+                "agentHostname": {
                     "type": "string"
                 },
-                "createdAt": {
-                    "type": "string"
+                "agentInstalled": {
+                    "type": "boolean"
                 },
-                "executionDuration": {
+                "duration": {
                     "type": "string"
                 },
-                "executionStatus": {
-                    "$ref": "#/definitions/constant.ExecutionStatus"
-                },
-                "failureMessage": {
-                    "type": "string"
+                "engine": {
+                    "description": "Engine is the load generator engine which runs the load test. (jmeter is used by default)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/constant.LoadGeneratorType"
+                        }
+                    ]
                 },
-                "finishAt": {
+                "hostname": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "httpReqs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/load.RunLoadTestHttpParam"
+                    }
                 },
-                "loadGeneratorInstallInfo": {
-                    "$ref": "#/definitions/load.LoadGeneratorInstallInfoResult"
+                "installLoadGenerator": {
+                    "$ref": "#/definitions/load.InstallLoadGeneratorParam"
                 },
                 "loadGeneratorInstallInfoId": {
                     "type": "integer"
                 },
+                "loadProfile": {
+                    "description": "LoadProfile shapes the load with the stages instead of the plateau of virtual users, ramp up and duration.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/load.LoadProfileParam"
+                        }
+                    ]
+                },
                 "loadTestKey": {
                     "type": "string"
                 },
-                "startAt": {
+                "port": {
                     "type": "string"
                 },
-                "totalExpectedExecutionSecond": {
-                    "type": "integer"
-                },
-                "updatedAt": {
+                "rampUpSteps": {
                     "type": "string"
-                }
-            }
-        },
-        "load.LoadTestStatistics": {
-            "type": "object",
-            "properties": {
-                "average": {
-                    "type": "number"
-                },
-                "errorPercent": {
-                    "type": "number"
                 },
-                "label": {
+                "rampUpTime": {
                     "type": "string"
                 },
-                "maxTime": {
-                    "type": "number"
-                },
-                "median": {
-                    "type": "number"
-                },
-                "minTime": {
-                    "type": "number"
-                },
-                "ninetyFive": {
-                    "type": "number"
-                },
-                "ninetyNine": {
-                    "type": "number"
-                },
-                "ninetyPercent": {
-                    "type": "number"
-                },
-                "receivedKB": {
-                    "type": "number"
-                },
-                "requestCount": {
-                    "type": "integer"
-                },
-                "sentKB": {
-                    "type": "number"
-                },
-                "throughput": {
-                    "type": "number"
-                }
-            }
-        },
-        "load.MetricsRawData": {
-            "type": "object",
-            "properties": {
-                "isError": {
-                    "type": "boolean"
+                "sloRules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/load.SloRuleParam"
+                    }
                 },
-                "timestamp": {
+                "testName": {
                     "type": "string"
                 },
-                "unit": {
-                    "type": "string"
+                "testPlanId": {
+                    "description": "TestPlanId runs the stored test plan of the library instead of the plan generated from the http requests.",
+                    "type": "integer"
                 },
-                "value": {
+                "virtualUsers": {
                     "type": "string"
                 }
             }
         },
-        "load.MetricsSummary": {
+        "load.RunMultiRegionLoadTestParam": {
             "type": "object",
             "properties": {
-                "label": {
-                    "type": "string"
-                },
-                "metrics": {
+                "regions": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/load.MetricsRawData"
+                        "$ref": "#/definitions/load.LoadTestRegionParam"
                     }
+                },
+                "runLoadTestParam": {
+                    "$ref": "#/definitions/load.RunLoadTestParam"
                 }
             }
         },
-        "load.MonitoringAgentInstallationResult": {
+        "load.SloRuleParam": {
             "type": "object",
             "properties": {
-                "agentType": {
-                    "type": "string"
-                },
-                "createdAt": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "mciId": {
-                    "type": "string"
-                },
-                "nsId": {
-                    "type": "string"
-                },
-                "status": {
+                "label": {
                     "type": "string"
                 },
-                "updatedAt": {
+                "metric": {
                     "type": "string"
                 },
-                "username": {
+                "operator": {
                     "type": "string"
                 },
-                "vmCount": {
-                    "type": "integer"
-                },
-                "vmId": {
-                    "type": "string"
+                "threshold": {
+                    "type": "number"
                 }
             }
         },
-        "load.ResultRawData": {
+        "load.TimeSeriesBucket": {
             "type": "object",
             "properties": {
-                "bytes": {
-                    "type": "integer"
-                },
-                "connection": {
-                    "description": "time to establish connection",
+                "activeTime": {
                     "type": "integer"
                 },
-                "elapsed": {
-                    "description": "time to last byte",
-                    "type": "integer"
+                "average": {
+                    "type": "number"
                 },
-                "idleTime": {
-                    "description": "time not spent sampling in jmeter (milliseconds) (generally 0)",
+                "errorCount": {
                     "type": "integer"
                 },
-                "isError": {
-                    "type": "boolean"
-                },
-                "latency": {
-                    "description": "time to first byte",
-                    "type": "integer"
+                "ninetyFive": {
+                    "type": "number"
                 },
-                "no": {
+                "requestCount": {
                     "type": "integer"
                 },
-                "sentBytes": {
-                    "type": "integer"
+                "throughput": {
+                    "type": "number"
                 },
                 "timestamp": {
                     "type": "string"
-                },
-                "url": {
-                    "type": "string"
-                }
-            }
-        },
-        "load.ResultSummary": {
-            "type": "object",
-            "properties": {
-                "label": {
-                    "type": "string"
-                },
-                "results": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/load.ResultRawData"
-                    }
                 }
             }
         }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 
 	"os"
@@ -95,6 +96,10 @@ func main() {
 
 	log.Info().Msgf("CM-Ant server initialization completed successfully.")
 
+	// Start the background jobs such as load test scheduler
+	jobCtx, cancelJobs := context.WithCancel(context.Background())
+	s.StartBackgroundJobs(jobCtx)
+
 	// Create a channel to listen for OS signals
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
@@ -112,6 +117,7 @@ func main() {
 	<-stop
 
 	log.Info().Msgf("Shutting down CM-Ant server...")
+	cancelJobs()
 
 	// Perform any necessary cleanup actions here, such as closing connections or saving state.
 	// Optionally wait for pending operations to complete gracefully.
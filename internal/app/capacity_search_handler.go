@@ -0,0 +1,121 @@
+package app
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cloud-barista/cm-ant/internal/core/load"
+	"github.com/labstack/echo/v4"
+)
+
+// runCapacitySearch handler function that starts the search of the breaking point.
+// @Id RunCapacitySearch
+// @Summary Run Capacity Search
+// @Description Run load tests step by step from the start load, increasing the load by the step load up to the max load against the same target.
+// @Description The load is the virtual users of the closed load model or the requests per second of the open load model, which is held for the step duration (seconds).
+// @Description After each step the error percent and the p95 (ms) of all requests are checked, and the search stops at the first step which exceeds the max error percent or the max p95.
+// @Description The result reports the max sustainable load and throughput with the step where it broke. The load generator of the first step is reused by the following steps.
+// @Tags [Capacity Search Management]
+// @Accept json
+// @Produce json
+// @Param body body app.RunCapacitySearchReq true "Capacity Search Request"
+// @Success 200 {object} app.AntResponse[load.CapacitySearchResult] "Successfully started capacity search"
+// @Failure 400 {object} app.AntResponse[string] "capacity search info is not correct."
+// @Router /api/v1/load/capacity-searches [post]
+func (s *AntServer) runCapacitySearch(c echo.Context) error {
+	var req RunCapacitySearchReq
+
+	if err := c.Bind(&req); err != nil {
+		return errorResponseJson(http.StatusBadRequest, "capacity search info is not correct.")
+	}
+
+	if msg := validateRunLoadTestReq(&req.RunLoadTest); msg != "" {
+		return errorResponseJson(http.StatusBadRequest, msg)
+	}
+
+	arg := load.RunCapacitySearchParam{
+		RunLoadTestParam: toRunLoadTestParam(req.RunLoadTest),
+		LoadModel:        req.LoadModel,
+		StartLoad:        req.StartLoad,
+		StepLoad:         req.StepLoad,
+		MaxLoad:          req.MaxLoad,
+		StepDuration:     req.StepDuration,
+		MaxVirtualUsers:  req.MaxVirtualUsers,
+		MaxErrorPercent:  req.MaxErrorPercent,
+		MaxNinetyFive:    req.MaxNinetyFive,
+	}
+
+	result, err := s.services.loadService.RunCapacitySearch(arg)
+
+	if err != nil {
+		return errorResponseJson(http.StatusBadRequest, err.Error())
+	}
+
+	return successResponseJson(c, "Successfully started capacity search", result)
+}
+
+// getAllCapacitySearches handler function that retrieves all capacity searches.
+// @Id GetAllCapacitySearches
+// @Summary Get All Capacity Searches
+// @Description Retrieve a list of all capacity searches with their steps with pagination support.
+// @Tags [Capacity Search Management]
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number for pagination (default 1)"
+// @Param size query int false "Number of items per page (default 10, max 10)"
+// @Success 200 {object} app.AntResponse[load.GetAllCapacitySearchesResult] "Successfully retrieved capacity searches"
+// @Failure 400 {object} app.AntResponse[string] "Invalid request parameters"
+// @Failure 500 {object} app.AntResponse[string] "Failed to retrieve capacity searches"
+// @Router /api/v1/load/capacity-searches [get]
+func (s *AntServer) getAllCapacitySearches(c echo.Context) error {
+	var req GetAllCapacitySearchesReq
+	if err := c.Bind(&req); err != nil {
+		return errorResponseJson(http.StatusBadRequest, "Invalid request parameters")
+	}
+	if req.Size < 1 || req.Size > 10 {
+		req.Size = 10
+	}
+	if req.Page < 1 {
+		req.Page = 1
+	}
+
+	arg := load.GetAllCapacitySearchesParam{
+		Page: req.Page,
+		Size: req.Size,
+	}
+
+	result, err := s.services.loadService.GetAllCapacitySearches(arg)
+
+	if err != nil {
+		return errorResponseJson(http.StatusInternalServerError, "Failed to retrieve capacity searches")
+	}
+
+	return successResponseJson(c, "Successfully retrieved capacity searches", result)
+}
+
+// getCapacitySearch handler function that retrieves a capacity search by id.
+// @Id GetCapacitySearch
+// @Summary Get Capacity Search
+// @Description Retrieve the status, the steps and the breaking point of the capacity search.
+// @Tags [Capacity Search Management]
+// @Accept json
+// @Produce json
+// @Param capacitySearchId path string true "Capacity search id"
+// @Success 200 {object} app.AntResponse[load.CapacitySearchResult] "Successfully retrieved capacity search"
+// @Failure 400 {object} app.AntResponse[string] "Capacity search id must be number."
+// @Failure 500 {object} app.AntResponse[string] "Failed to retrieve capacity search"
+// @Router /api/v1/load/capacity-searches/{capacitySearchId} [get]
+func (s *AntServer) getCapacitySearch(c echo.Context) error {
+	capacitySearchId, err := strconv.Atoi(c.Param("capacitySearchId"))
+	if err != nil {
+		return errorResponseJson(http.StatusBadRequest, "Capacity search id must be number.")
+	}
+
+	result, err := s.services.loadService.GetCapacitySearch(uint(capacitySearchId))
+
+	if err != nil {
+		return errorResponseJson(http.StatusInternalServerError, "Failed to retrieve capacity search")
+	}
+
+	return successResponseJson(c, "Successfully retrieved capacity search", result)
+}
@@ -0,0 +1,180 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+
+	"github.com/cloud-barista/cm-ant/internal/core/load"
+	"github.com/labstack/echo/v4"
+)
+
+// uploadLoadTestPlan handler function that stores a user supplied jmeter test plan into the plan library.
+// @Id UploadLoadTestPlan
+// @Summary Upload Load Test Plan
+// @Description Upload a jmx test plan and the csv data files referenced by its csv data set configs into the plan library.
+// @Description The plan is validated as a jmeter test plan with at least one thread group. When the plan is run, the csv data files and result listeners are bound to the load test key and the perfmon collectors are injected if the monitoring agent is used.
+// @Description The stored plan is run by setting testPlanId of the run load test request. The thread groups of the plan are used as they are.
+// @Tags [Load Test Plan Management]
+// @Accept multipart/form-data
+// @Produce json
+// @Param name formData string false "Name of the test plan (default file name)"
+// @Param description formData string false "Description of the test plan"
+// @Param file formData file true "Jmx test plan file"
+// @Param dataFiles formData file false "Csv data files referenced by the test plan"
+// @Success 200 {object} app.AntResponse[load.LoadTestPlanResult] "Successfully uploaded load test plan"
+// @Failure 400 {object} app.AntResponse[string] "test plan file is required."
+// @Failure 400 {object} app.AntResponse[string] "test plan is not valid."
+// @Router /api/v1/load/plans [post]
+func (s *AntServer) uploadLoadTestPlan(c echo.Context) error {
+	file, err := c.FormFile("file")
+	if err != nil {
+		return errorResponseJson(http.StatusBadRequest, "test plan file is required.")
+	}
+
+	content, err := readFormFile(file)
+	if err != nil {
+		return errorResponseJson(http.StatusBadRequest, "test plan file is not readable.")
+	}
+
+	arg := load.UploadLoadTestPlanParam{
+		Name:        c.FormValue("name"),
+		Description: c.FormValue("description"),
+		FileName:    file.Filename,
+		Content:     content,
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		return errorResponseJson(http.StatusBadRequest, "test plan form is not correct.")
+	}
+
+	for _, f := range form.File["dataFiles"] {
+		dataContent, err := readFormFile(f)
+		if err != nil {
+			return errorResponseJson(http.StatusBadRequest, fmt.Sprintf("data file %s is not readable.", f.Filename))
+		}
+
+		arg.DataFiles = append(arg.DataFiles, load.LoadTestPlanDataFileParam{
+			FileName: f.Filename,
+			Content:  dataContent,
+		})
+	}
+
+	result, err := s.services.loadService.UploadLoadTestPlan(arg)
+
+	if err != nil {
+		return errorResponseJson(http.StatusBadRequest, err.Error())
+	}
+
+	return successResponseJson(c, "Successfully uploaded load test plan", result)
+}
+
+func readFormFile(f *multipart.FileHeader) ([]byte, error) {
+	src, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	return io.ReadAll(src)
+}
+
+// getAllLoadTestPlans handler function that retrieves the stored test plans.
+// @Id GetAllLoadTestPlans
+// @Summary Get All Load Test Plans
+// @Description Retrieve a list of the stored test plans of the plan library with pagination support.
+// @Tags [Load Test Plan Management]
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number for pagination (default 1)"
+// @Param size query int false "Number of items per page (default 10, max 10)"
+// @Success 200 {object} app.AntResponse[load.GetAllLoadTestPlansResult] "Successfully retrieved load test plans"
+// @Failure 400 {object} app.AntResponse[string] "Invalid request parameters"
+// @Failure 500 {object} app.AntResponse[string] "Failed to retrieve load test plans"
+// @Router /api/v1/load/plans [get]
+func (s *AntServer) getAllLoadTestPlans(c echo.Context) error {
+	var req GetAllLoadTestPlansReq
+	if err := c.Bind(&req); err != nil {
+		return errorResponseJson(http.StatusBadRequest, "Invalid request parameters")
+	}
+	if req.Size < 1 || req.Size > 10 {
+		req.Size = 10
+	}
+	if req.Page < 1 {
+		req.Page = 1
+	}
+
+	arg := load.GetAllLoadTestPlansParam{
+		Page: req.Page,
+		Size: req.Size,
+	}
+
+	result, err := s.services.loadService.GetAllLoadTestPlans(arg)
+
+	if err != nil {
+		return errorResponseJson(http.StatusInternalServerError, "Failed to retrieve load test plans")
+	}
+
+	return successResponseJson(c, "Successfully retrieved load test plans", result)
+}
+
+// getLoadTestPlan handler function that retrieves a stored test plan by id.
+// @Id GetLoadTestPlan
+// @Summary Get Load Test Plan
+// @Description Retrieve a stored test plan and its data files by plan id.
+// @Tags [Load Test Plan Management]
+// @Accept json
+// @Produce json
+// @Param planId path string true "Load test plan id"
+// @Success 200 {object} app.AntResponse[load.LoadTestPlanResult] "Successfully retrieved load test plan"
+// @Failure 400 {object} app.AntResponse[string] "Load test plan id must be number."
+// @Failure 500 {object} app.AntResponse[string] "Failed to retrieve load test plan"
+// @Router /api/v1/load/plans/{planId} [get]
+func (s *AntServer) getLoadTestPlan(c echo.Context) error {
+	planId, err := strconv.Atoi(c.Param("planId"))
+	if err != nil {
+		return errorResponseJson(http.StatusBadRequest, "Load test plan id must be number.")
+	}
+
+	result, err := s.services.loadService.GetLoadTestPlan(uint(planId))
+
+	if err != nil {
+		return errorResponseJson(http.StatusInternalServerError, "Failed to retrieve load test plan")
+	}
+
+	return successResponseJson(c, "Successfully retrieved load test plan", result)
+}
+
+// deleteLoadTestPlan handler function that deletes a stored test plan.
+// @Id DeleteLoadTestPlan
+// @Summary Delete Load Test Plan
+// @Description Delete a stored test plan and its data files. Load tests already run with the plan are not affected.
+// @Tags [Load Test Plan Management]
+// @Accept json
+// @Produce json
+// @Param planId path string true "Load test plan id"
+// @Success 200 {object} app.AntResponse[string] "Successfully deleted load test plan"
+// @Failure 400 {object} app.AntResponse[string] "Load test plan id must be number."
+// @Failure 500 {object} app.AntResponse[string] "Failed to delete load test plan"
+// @Router /api/v1/load/plans/{planId} [delete]
+func (s *AntServer) deleteLoadTestPlan(c echo.Context) error {
+	planId, err := strconv.Atoi(c.Param("planId"))
+	if err != nil {
+		return errorResponseJson(http.StatusBadRequest, "Load test plan id must be number.")
+	}
+
+	err = s.services.loadService.DeleteLoadTestPlan(uint(planId))
+
+	if err != nil {
+		return errorResponseJson(http.StatusInternalServerError, "Failed to delete load test plan")
+	}
+
+	return successResponseJson(
+		c,
+		fmt.Sprintf("Successfully deleted load test plan: %d", planId),
+		"done",
+	)
+}
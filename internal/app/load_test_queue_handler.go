@@ -0,0 +1,92 @@
+package app
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cloud-barista/cm-ant/internal/core/load"
+	"github.com/labstack/echo/v4"
+)
+
+// getLoadTestQueue handler function that retrieves the run queues of the load generators.
+// @Id GetLoadTestQueue
+// @Summary Get Load Test Queue
+// @Description Retrieve the running count and the queued load tests of each load generator in the order they will start.
+// @Description The load tests on the same load generator run up to the configured max concurrent tests, and the others wait in the queue.
+// @Tags [Load Test Queue Management]
+// @Accept json
+// @Produce json
+// @Success 200 {object} app.AntResponse[[]load.LoadGeneratorQueueResult] "Successfully retrieved load test queue"
+// @Router /api/v1/load/queue [get]
+func (s *AntServer) getLoadTestQueue(c echo.Context) error {
+	result := s.services.loadService.GetLoadTestQueue()
+	return successResponseJson(c, "Successfully retrieved load test queue", result)
+}
+
+// moveQueuedLoadTest handler function that changes the position of the queued load test.
+// @Id MoveQueuedLoadTest
+// @Summary Move Queued Load Test
+// @Description Move the queued load test to the position in the queue of its load generator. The position starts from 1, and the position over the queue length moves it to the end.
+// @Tags [Load Test Queue Management]
+// @Accept json
+// @Produce json
+// @Param loadTestKey path string true "Load test key"
+// @Param body body app.MoveQueuedLoadTestReq true "Move Queued Load Test Request"
+// @Success 200 {object} app.AntResponse[string] "Successfully moved queued load test"
+// @Failure 400 {object} app.AntResponse[string] "queue position is not correct."
+// @Router /api/v1/load/queue/{loadTestKey} [put]
+func (s *AntServer) moveQueuedLoadTest(c echo.Context) error {
+	loadTestKey := c.Param("loadTestKey")
+	if strings.TrimSpace(loadTestKey) == "" {
+		return errorResponseJson(http.StatusBadRequest, "pass correct load test key")
+	}
+
+	var req MoveQueuedLoadTestReq
+	if err := c.Bind(&req); err != nil || req.QueuePosition < 1 {
+		return errorResponseJson(http.StatusBadRequest, "queue position is not correct.")
+	}
+
+	arg := load.MoveQueuedLoadTestParam{
+		LoadTestKey:   loadTestKey,
+		QueuePosition: req.QueuePosition,
+	}
+
+	if err := s.services.loadService.MoveQueuedLoadTest(arg); err != nil {
+		return errorResponseJson(http.StatusBadRequest, err.Error())
+	}
+
+	return successResponseJson(
+		c,
+		fmt.Sprintf("Successfully moved queued load test: %s", loadTestKey),
+		"done",
+	)
+}
+
+// cancelQueuedLoadTest handler function that removes the queued load test from the queue.
+// @Id CancelQueuedLoadTest
+// @Summary Cancel Queued Load Test
+// @Description Remove the queued load test from the queue before it starts. The canceled load test is recorded as stopped.
+// @Tags [Load Test Queue Management]
+// @Accept json
+// @Produce json
+// @Param loadTestKey path string true "Load test key"
+// @Success 200 {object} app.AntResponse[string] "Successfully canceled queued load test"
+// @Failure 400 {object} app.AntResponse[string] "load test is not queued"
+// @Router /api/v1/load/queue/{loadTestKey} [delete]
+func (s *AntServer) cancelQueuedLoadTest(c echo.Context) error {
+	loadTestKey := c.Param("loadTestKey")
+	if strings.TrimSpace(loadTestKey) == "" {
+		return errorResponseJson(http.StatusBadRequest, "pass correct load test key")
+	}
+
+	if err := s.services.loadService.CancelQueuedLoadTest(loadTestKey); err != nil {
+		return errorResponseJson(http.StatusBadRequest, err.Error())
+	}
+
+	return successResponseJson(
+		c,
+		fmt.Sprintf("Successfully canceled queued load test: %s", loadTestKey),
+		"done",
+	)
+}
@@ -0,0 +1,221 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cloud-barista/cm-ant/internal/core/load"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// createLoadTestSchedule handler function that registers a recurring load test.
+// @Id CreateLoadTestSchedule
+// @Summary Create Load Test Schedule
+// @Description Register a load test which runs repeatedly according to the cron expression. (minute hour day-of-month month day-of-week or macros such as @daily)
+// @Description The password and token of the auth, the values of the sensitive headers and the cookies are encrypted with load.keystore.masterKey, which must be set to schedule them, and are returned as ******.
+// @Tags [Load Test Schedule Management]
+// @Accept json
+// @Produce json
+// @Param body body app.LoadTestScheduleReq true "Load Test Schedule Request"
+// @Success 200 {object} app.AntResponse[load.LoadTestScheduleResult] "Successfully created load test schedule"
+// @Failure 400 {object} app.AntResponse[string] "load test schedule info is not correct."
+// @Failure 500 {object} app.AntResponse[string] "Failed to create load test schedule"
+// @Router /api/v1/load/schedules [post]
+func (s *AntServer) createLoadTestSchedule(c echo.Context) error {
+	var req LoadTestScheduleReq
+
+	if err := c.Bind(&req); err != nil {
+		return errorResponseJson(http.StatusBadRequest, "load test schedule info is not correct.")
+	}
+
+	if strings.TrimSpace(req.CronExpression) == "" {
+		return errorResponseJson(http.StatusBadRequest, "cron expression must be set.")
+	}
+
+	if msg := validateRunLoadTestReq(&req.RunLoadTest); msg != "" {
+		return errorResponseJson(http.StatusBadRequest, msg)
+	}
+
+	arg := load.CreateLoadTestScheduleParam{
+		Name:             req.Name,
+		CronExpression:   req.CronExpression,
+		Enabled:          req.Enabled,
+		RunLoadTestParam: toRunLoadTestParam(req.RunLoadTest),
+	}
+
+	result, err := s.services.loadService.CreateLoadTestSchedule(arg)
+
+	if err != nil {
+		return loadTestScheduleErrorResponse(err, "Failed to create load test schedule")
+	}
+
+	return successResponseJson(c, "Successfully created load test schedule", result)
+}
+
+// getAllLoadTestSchedules handler function that retrieves all load test schedules.
+// @Id GetAllLoadTestSchedules
+// @Summary Get All Load Test Schedules
+// @Description Retrieve a list of all load test schedules with pagination support.
+// @Tags [Load Test Schedule Management]
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number for pagination (default 1)"
+// @Param size query int false "Number of items per page (default 10, max 10)"
+// @Success 200 {object} app.AntResponse[load.GetAllLoadTestSchedulesResult] "Successfully retrieved load test schedules"
+// @Failure 400 {object} app.AntResponse[string] "Invalid request parameters"
+// @Failure 500 {object} app.AntResponse[string] "Failed to retrieve load test schedules"
+// @Router /api/v1/load/schedules [get]
+func (s *AntServer) getAllLoadTestSchedules(c echo.Context) error {
+	var req GetAllLoadTestSchedulesReq
+	if err := c.Bind(&req); err != nil {
+		return errorResponseJson(http.StatusBadRequest, "Invalid request parameters")
+	}
+	if req.Size < 1 || req.Size > 10 {
+		req.Size = 10
+	}
+	if req.Page < 1 {
+		req.Page = 1
+	}
+
+	arg := load.GetAllLoadTestSchedulesParam{
+		Page: req.Page,
+		Size: req.Size,
+	}
+
+	result, err := s.services.loadService.GetAllLoadTestSchedules(arg)
+
+	if err != nil {
+		return errorResponseJson(http.StatusInternalServerError, "Failed to retrieve load test schedules")
+	}
+
+	return successResponseJson(c, "Successfully retrieved load test schedules", result)
+}
+
+// getLoadTestSchedule handler function that retrieves a load test schedule by id.
+// @Id GetLoadTestSchedule
+// @Summary Get Load Test Schedule
+// @Description Retrieve a load test schedule by schedule id.
+// @Tags [Load Test Schedule Management]
+// @Accept json
+// @Produce json
+// @Param scheduleId path string true "Load test schedule id"
+// @Success 200 {object} app.AntResponse[load.LoadTestScheduleResult] "Successfully retrieved load test schedule"
+// @Failure 400 {object} app.AntResponse[string] "Load test schedule id must be number."
+// @Failure 404 {object} app.AntResponse[string] "Load test schedule is not found"
+// @Failure 500 {object} app.AntResponse[string] "Failed to retrieve load test schedule"
+// @Router /api/v1/load/schedules/{scheduleId} [get]
+func (s *AntServer) getLoadTestSchedule(c echo.Context) error {
+	scheduleId, err := strconv.Atoi(c.Param("scheduleId"))
+	if err != nil {
+		return errorResponseJson(http.StatusBadRequest, "Load test schedule id must be number.")
+	}
+
+	result, err := s.services.loadService.GetLoadTestSchedule(uint(scheduleId))
+
+	if err != nil {
+		return loadTestScheduleErrorResponse(err, "Failed to retrieve load test schedule")
+	}
+
+	return successResponseJson(c, "Successfully retrieved load test schedule", result)
+}
+
+// updateLoadTestSchedule handler function that updates a load test schedule.
+// @Id UpdateLoadTestSchedule
+// @Summary Update Load Test Schedule
+// @Description Update the cron expression, enabled flag and load test configuration of the schedule.
+// @Description A credential given as ****** keeps the stored one of the http request with the same method, url and username.
+// @Tags [Load Test Schedule Management]
+// @Accept json
+// @Produce json
+// @Param scheduleId path string true "Load test schedule id"
+// @Param body body app.LoadTestScheduleReq true "Load Test Schedule Request"
+// @Success 200 {object} app.AntResponse[load.LoadTestScheduleResult] "Successfully updated load test schedule"
+// @Failure 400 {object} app.AntResponse[string] "load test schedule info is not correct."
+// @Failure 404 {object} app.AntResponse[string] "Load test schedule is not found"
+// @Failure 500 {object} app.AntResponse[string] "Failed to update load test schedule"
+// @Router /api/v1/load/schedules/{scheduleId} [put]
+func (s *AntServer) updateLoadTestSchedule(c echo.Context) error {
+	scheduleId, err := strconv.Atoi(c.Param("scheduleId"))
+	if err != nil {
+		return errorResponseJson(http.StatusBadRequest, "Load test schedule id must be number.")
+	}
+
+	var req LoadTestScheduleReq
+
+	if err := c.Bind(&req); err != nil {
+		return errorResponseJson(http.StatusBadRequest, "load test schedule info is not correct.")
+	}
+
+	if strings.TrimSpace(req.CronExpression) == "" {
+		return errorResponseJson(http.StatusBadRequest, "cron expression must be set.")
+	}
+
+	if msg := validateRunLoadTestReq(&req.RunLoadTest); msg != "" {
+		return errorResponseJson(http.StatusBadRequest, msg)
+	}
+
+	arg := load.UpdateLoadTestScheduleParam{
+		ID:               uint(scheduleId),
+		Name:             req.Name,
+		CronExpression:   req.CronExpression,
+		Enabled:          req.Enabled,
+		RunLoadTestParam: toRunLoadTestParam(req.RunLoadTest),
+	}
+
+	result, err := s.services.loadService.UpdateLoadTestSchedule(arg)
+
+	if err != nil {
+		return loadTestScheduleErrorResponse(err, "Failed to update load test schedule")
+	}
+
+	return successResponseJson(c, "Successfully updated load test schedule", result)
+}
+
+// deleteLoadTestSchedule handler function that deletes a load test schedule.
+// @Id DeleteLoadTestSchedule
+// @Summary Delete Load Test Schedule
+// @Description Delete a load test schedule. Load tests already started by the schedule are not affected.
+// @Tags [Load Test Schedule Management]
+// @Accept json
+// @Produce json
+// @Param scheduleId path string true "Load test schedule id"
+// @Success 200 {object} app.AntResponse[string] "Successfully deleted load test schedule"
+// @Failure 400 {object} app.AntResponse[string] "Load test schedule id must be number."
+// @Failure 404 {object} app.AntResponse[string] "Load test schedule is not found"
+// @Failure 500 {object} app.AntResponse[string] "Failed to delete load test schedule"
+// @Router /api/v1/load/schedules/{scheduleId} [delete]
+func (s *AntServer) deleteLoadTestSchedule(c echo.Context) error {
+	scheduleId, err := strconv.Atoi(c.Param("scheduleId"))
+	if err != nil {
+		return errorResponseJson(http.StatusBadRequest, "Load test schedule id must be number.")
+	}
+
+	err = s.services.loadService.DeleteLoadTestSchedule(uint(scheduleId))
+
+	if err != nil {
+		return loadTestScheduleErrorResponse(err, "Failed to delete load test schedule")
+	}
+
+	return successResponseJson(
+		c,
+		fmt.Sprintf("Successfully deleted load test schedule: %d", scheduleId),
+		"done",
+	)
+}
+
+// loadTestScheduleErrorResponse maps the invalid schedule to 400 and the missing schedule to 404.
+func loadTestScheduleErrorResponse(err error, message string) error {
+	if errors.Is(err, load.ErrInvalidLoadTestSchedule) {
+		return errorResponseJson(http.StatusBadRequest, err.Error())
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errorResponseJson(http.StatusNotFound, "Load test schedule is not found")
+	}
+
+	return errorResponseJson(http.StatusInternalServerError, message)
+}
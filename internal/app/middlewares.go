@@ -26,12 +26,14 @@ func setMiddleware(e *echo.Echo) {
 		middleware.Secure(),
 		middleware.RequestID(),
 		middleware.Recover(),
-		middleware.Gzip(),
+		middleware.GzipWithConfig(middleware.GzipConfig{
+			Skipper: streamSkipper,
+		}),
 		middleware.CORS(),
 		Zerologger(logSkipPattern),
 		middleware.TimeoutWithConfig(
 			middleware.TimeoutConfig{
-				Skipper:      middleware.DefaultSkipper,
+				Skipper:      streamSkipper,
 				ErrorMessage: "request timeout",
 				OnTimeoutRouteErrorHandler: func(err error, c echo.Context) {
 					utils.LogInfo(c.Path())
@@ -44,6 +46,11 @@ func setMiddleware(e *echo.Echo) {
 	)
 }
 
+// streamSkipper skips the middlewares which buffer the response for the server-sent events.
+func streamSkipper(c echo.Context) bool {
+	return strings.HasSuffix(c.Request().URL.Path, "/stream")
+}
+
 func Zerologger(skipPatterns [][]string) echo.MiddlewareFunc {
 	return middleware.RequestLoggerWithConfig(middleware.RequestLoggerConfig{
 		Skipper: func(c echo.Context) bool {
@@ -0,0 +1,154 @@
+package app
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cloud-barista/cm-ant/internal/core/common/constant"
+	"github.com/cloud-barista/cm-ant/internal/core/load"
+	"github.com/labstack/echo/v4"
+)
+
+// runMultiRegionLoadTest handler function that starts the load test from several regions at once.
+// @Id RunMultiRegionLoadTest
+// @Summary Run Multi Region Load Test
+// @Description Run the load test from the load generators in several regions at once against the same target.
+// @Description A regional load generator is installed near the coordinates of each region (such as 37.53/127.02) on its own MCI, and reused by the following multi region load tests with the same region name.
+// @Description The virtual users, or the targets and the max virtual users of the load profile, are divided among the regions by their weights (1 by default).
+// @Description Each region runs its own load test, and the result reports the statistics of each label per region.
+// @Tags [Multi Region Load Test Management]
+// @Accept json
+// @Produce json
+// @Param body body app.RunMultiRegionLoadTestReq true "Multi Region Load Test Request"
+// @Success 200 {object} app.AntResponse[load.MultiRegionLoadTestResult] "Successfully started multi region load test"
+// @Failure 400 {object} app.AntResponse[string] "multi region load test info is not correct."
+// @Router /api/v1/load/multi-region-tests [post]
+func (s *AntServer) runMultiRegionLoadTest(c echo.Context) error {
+	var req RunMultiRegionLoadTestReq
+
+	if err := c.Bind(&req); err != nil {
+		return errorResponseJson(http.StatusBadRequest, "multi region load test info is not correct.")
+	}
+
+	// the load generator of each region is installed remotely
+	req.RunLoadTest.LoadGeneratorInstallInfoId = 0
+	req.RunLoadTest.InstallLoadGenerator.InstallLocation = constant.Remote
+
+	if msg := validateRunLoadTestReq(&req.RunLoadTest); msg != "" {
+		return errorResponseJson(http.StatusBadRequest, msg)
+	}
+
+	arg := load.RunMultiRegionLoadTestParam{
+		RunLoadTestParam: toRunLoadTestParam(req.RunLoadTest),
+	}
+
+	for _, r := range req.Regions {
+		arg.Regions = append(arg.Regions, load.LoadTestRegionParam{
+			RegionName:  r.RegionName,
+			Coordinates: r.Coordinates,
+			Weight:      r.Weight,
+		})
+	}
+
+	result, err := s.services.loadService.RunMultiRegionLoadTest(arg)
+
+	if err != nil {
+		return errorResponseJson(http.StatusBadRequest, err.Error())
+	}
+
+	return successResponseJson(c, "Successfully started multi region load test", result)
+}
+
+// getAllMultiRegionLoadTests handler function that retrieves all multi region load tests.
+// @Id GetAllMultiRegionLoadTests
+// @Summary Get All Multi Region Load Tests
+// @Description Retrieve a list of all multi region load tests with their regions with pagination support.
+// @Tags [Multi Region Load Test Management]
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number for pagination (default 1)"
+// @Param size query int false "Number of items per page (default 10, max 10)"
+// @Success 200 {object} app.AntResponse[load.GetAllMultiRegionLoadTestsResult] "Successfully retrieved multi region load tests"
+// @Failure 400 {object} app.AntResponse[string] "Invalid request parameters"
+// @Failure 500 {object} app.AntResponse[string] "Failed to retrieve multi region load tests"
+// @Router /api/v1/load/multi-region-tests [get]
+func (s *AntServer) getAllMultiRegionLoadTests(c echo.Context) error {
+	var req GetAllMultiRegionLoadTestsReq
+	if err := c.Bind(&req); err != nil {
+		return errorResponseJson(http.StatusBadRequest, "Invalid request parameters")
+	}
+	if req.Size < 1 || req.Size > 10 {
+		req.Size = 10
+	}
+	if req.Page < 1 {
+		req.Page = 1
+	}
+
+	arg := load.GetAllMultiRegionLoadTestsParam{
+		Page: req.Page,
+		Size: req.Size,
+	}
+
+	result, err := s.services.loadService.GetAllMultiRegionLoadTests(arg)
+
+	if err != nil {
+		return errorResponseJson(http.StatusInternalServerError, "Failed to retrieve multi region load tests")
+	}
+
+	return successResponseJson(c, "Successfully retrieved multi region load tests", result)
+}
+
+// getMultiRegionLoadTest handler function that retrieves a multi region load test by id.
+// @Id GetMultiRegionLoadTest
+// @Summary Get Multi Region Load Test
+// @Description Retrieve the status of the multi region load test with the load test key, the status and the location of the load generator of each region.
+// @Tags [Multi Region Load Test Management]
+// @Accept json
+// @Produce json
+// @Param multiRegionLoadTestId path string true "Multi region load test id"
+// @Success 200 {object} app.AntResponse[load.MultiRegionLoadTestResult] "Successfully retrieved multi region load test"
+// @Failure 400 {object} app.AntResponse[string] "Multi region load test id must be number."
+// @Failure 500 {object} app.AntResponse[string] "Failed to retrieve multi region load test"
+// @Router /api/v1/load/multi-region-tests/{multiRegionLoadTestId} [get]
+func (s *AntServer) getMultiRegionLoadTest(c echo.Context) error {
+	multiRegionLoadTestId, err := strconv.Atoi(c.Param("multiRegionLoadTestId"))
+	if err != nil {
+		return errorResponseJson(http.StatusBadRequest, "Multi region load test id must be number.")
+	}
+
+	result, err := s.services.loadService.GetMultiRegionLoadTest(uint(multiRegionLoadTestId))
+
+	if err != nil {
+		return errorResponseJson(http.StatusInternalServerError, "Failed to retrieve multi region load test")
+	}
+
+	return successResponseJson(c, "Successfully retrieved multi region load test", result)
+}
+
+// getMultiRegionLoadTestResult handler function that retrieves the result of a multi region load test per region.
+// @Id GetMultiRegionLoadTestResult
+// @Summary Get Multi Region Load Test Result
+// @Description Retrieve the statistics of each label measured from each region, ordered by the label, so the latency of the same request can be compared between the regions.
+// @Description The result of the region is included once its load test has fetched the result.
+// @Tags [Multi Region Load Test Management]
+// @Accept json
+// @Produce json
+// @Param multiRegionLoadTestId path string true "Multi region load test id"
+// @Success 200 {object} app.AntResponse[[]load.RegionLoadTestStatistics] "Successfully retrieved multi region load test result"
+// @Failure 400 {object} app.AntResponse[string] "Multi region load test id must be number."
+// @Failure 500 {object} app.AntResponse[string] "Failed to retrieve multi region load test result"
+// @Router /api/v1/load/multi-region-tests/{multiRegionLoadTestId}/result [get]
+func (s *AntServer) getMultiRegionLoadTestResult(c echo.Context) error {
+	multiRegionLoadTestId, err := strconv.Atoi(c.Param("multiRegionLoadTestId"))
+	if err != nil {
+		return errorResponseJson(http.StatusBadRequest, "Multi region load test id must be number.")
+	}
+
+	result, err := s.services.loadService.GetMultiRegionLoadTestResult(uint(multiRegionLoadTestId))
+
+	if err != nil {
+		return errorResponseJson(http.StatusInternalServerError, "Failed to retrieve multi region load test result")
+	}
+
+	return successResponseJson(c, "Successfully retrieved multi region load test result", result)
+}
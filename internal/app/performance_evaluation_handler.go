@@ -1,11 +1,14 @@
 package app
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/cloud-barista/cm-ant/internal/config"
 	"github.com/cloud-barista/cm-ant/internal/core/common/constant"
 	"github.com/cloud-barista/cm-ant/internal/core/load"
 	"github.com/cloud-barista/cm-ant/internal/utils"
@@ -14,8 +17,13 @@ import (
 
 const (
 	seoul = "37.53/127.02"
-)
 
+	defaultTimeSeriesInterval = 10 * time.Second
+	minTimeSeriesInterval     = time.Second
+
+	defaultProgressWindow  = 30 * time.Second
+	progressStreamInterval = 5 * time.Second
+)
 
 // getAllLoadGeneratorInstallInfo handler function that retrieves all load generator installation information.
 // @Id GetAllLoadGeneratorInstallInfo
@@ -61,7 +69,11 @@ func (s *AntServer) getAllLoadGeneratorInstallInfo(c echo.Context) error {
 // installLoadGenerator handler function that handles a load generator installation request.
 // @Id InstallLoadGenerator
 // @Summary Install Load Generator
-// @Description Install a load generator either locally or remotely.
+// @Description Install a load generator either locally or remotely. For remote installation, workerCount provisions additional worker VMs which run jmeter-server for distributed load test, and the security groups of the cluster are limited to ssh and the private ips of the cluster.
+// @Description The remote load generator is provisioned near the coordinates such as 37.53/127.02 (seoul by default).
+// @Description With regionName, a regional load generator is installed on its own MCI, which is used by the multi region load test.
+// @Description The remote VM is recommended among the providers (aws by default) within minVCpu-maxVCpu (2-8) and minMemoryGiB-maxMemoryGiB (4-8) by the priority, which is location (default), cost or performance.
+// @Description spec (such as aws+ap-northeast-2+t3.xlarge) skips the recommendation, image is the common image or the os (ubuntu22.04 by default), and rootDiskSize is in GB. The VM options apply to the VMs which are newly provisioned.
 // @Tags [Load Generator Management]
 // @Accept json
 // @Produce json
@@ -86,12 +98,31 @@ func (s *AntServer) installLoadGenerator(c echo.Context) error {
 		return errorResponseJson(http.StatusBadRequest, "available install locations are remote or local.")
 	}
 
+	if req.WorkerCount < 0 || (req.InstallLocation == constant.Local && req.WorkerCount > 0) {
+		utils.LogError("Invalid worker count:", req.WorkerCount)
+		return errorResponseJson(http.StatusBadRequest, "worker count is only available for remote install location.")
+	}
+
+	if req.InstallLocation == constant.Local && (req.RegionName != "" || len(req.Coordinates) > 0) {
+		utils.LogError("Invalid region for local install location:", req.RegionName)
+		return errorResponseJson(http.StatusBadRequest, "region is only available for remote install location.")
+	}
+
+	if req.InstallLocation == constant.Local && req.LoadGeneratorVmReq.specified() {
+		utils.LogError("Invalid vm for local install location:", req.LoadGeneratorVmReq)
+		return errorResponseJson(http.StatusBadRequest, "vm is only available for remote install location.")
+	}
+
 	utils.LogInfo("Calling service layer to install load generator")
 
 	// call service layer install load generator
 	param := load.InstallLoadGeneratorParam{
 		InstallLocation: req.InstallLocation,
-		Coordinates:     []string{seoul},
+		Coordinates:     coordinatesOrDefault(req.Coordinates),
+		WorkerCount:     req.WorkerCount,
+		RegionName:      req.RegionName,
+
+		LoadGeneratorVmParam: toLoadGeneratorVmParam(req.LoadGeneratorVmReq),
 	}
 	result, err := s.services.loadService.InstallLoadGenerator(param)
 
@@ -109,10 +140,55 @@ func (s *AntServer) installLoadGenerator(c echo.Context) error {
 	)
 }
 
+// registerLoadGenerator handler function that registers an existing host as a load generator.
+// @Id RegisterLoadGenerator
+// @Summary Register Load Generator
+// @Description Register an existing host, such as a bastion or an on-premise server which Tumblebug does not manage, as a load generator reached by SSH directly.
+// @Description privateKeyName is the name of the private key file in ~/.ssh of the CM-ANT server, which the host accepts for the username. sshPort is 22 by default.
+// @Description The host must be Ubuntu and allow sudo without a password, because install-jmeter.sh installs JMeter on it over SSH.
+// @Description The load tests on the registered load generator run and fetch their results over SSH. It is not suspended, resumed or terminated, and uninstall only deregisters it after removing JMeter.
+// @Tags [Load Generator Management]
+// @Accept json
+// @Produce json
+// @Param body body app.RegisterLoadGeneratorReq true "Load Generator Registration Request"
+// @Success 200 {object} app.AntResponse[load.LoadGeneratorInstallInfoResult] "Successfully registered load generator"
+// @Failure 400 {object} app.AntResponse[string] "Load generator registration info is not correct."
+// @Failure 400 {object} app.AntResponse[string] "Host is already registered."
+// @Router /api/v1/load/generators/ssh [post]
+func (s *AntServer) registerLoadGenerator(c echo.Context) error {
+	var req RegisterLoadGeneratorReq
+
+	if err := c.Bind(&req); err != nil {
+		return errorResponseJson(http.StatusBadRequest, "load generator registration info is not correct.")
+	}
+
+	if req.PublicIp == "" || req.Username == "" || req.PrivateKeyName == "" {
+		return errorResponseJson(http.StatusBadRequest, "publicIp, username and privateKeyName are required.")
+	}
+
+	param := load.RegisterLoadGeneratorParam{
+		PublicIp:       req.PublicIp,
+		PrivateIp:      req.PrivateIp,
+		SshPort:        req.SshPort,
+		Username:       req.Username,
+		PrivateKeyName: req.PrivateKeyName,
+		Label:          req.Label,
+	}
+
+	result, err := s.services.loadService.RegisterLoadGenerator(param)
+	if err != nil {
+		utils.LogErrorf("Error registering load generator %s: %v", req.PublicIp, err)
+		return errorResponseJson(http.StatusBadRequest, err.Error())
+	}
+
+	return successResponseJson(c, "load generator is successfully registered", result)
+}
+
 // uninstallLoadGenerator handler function that handles a load generator uninstallation request.
 // @Id UninstallLoadGenerator
 // @Summary Uninstall Load Generator
 // @Description Uninstall a previously installed load generator.
+// @Description The VMs of a remote load generator are suspended after the uninstallation, and resumed by the next installation.
 // @Tags [Load Generator Management]
 // @Accept json
 // @Produce json
@@ -151,10 +227,158 @@ func (s *AntServer) uninstallLoadGenerator(c echo.Context) error {
 	)
 }
 
+// suspendLoadGenerator handler function that suspends the vms of a remote load generator.
+// @Id SuspendLoadGenerator
+// @Summary Suspend Load Generator
+// @Description Suspend the VMs of a remote load generator which has no unfinished load test, so they are not billed while they are not used.
+// @Description The suspended load generator is resumed automatically when a load test runs on it.
+// @Description Remote load generators are also suspended (or terminated) by the idle reaper after load.generator.idleTimeout (1h by default) since the last load test.
+// @Tags [Load Generator Management]
+// @Accept json
+// @Produce json
+// @Param loadGeneratorInstallInfoId path string true "load generator install info id"
+// @Success 200 {object} app.AntResponse[string] "Successfully suspend load generator"
+// @Failure 400 {object} app.AntResponse[string] "Load generator install info id must be number."
+// @Failure 400 {object} app.AntResponse[string] "Load generator has unfinished load tests."
+// @Router /api/v1/load/generators/{loadGeneratorInstallInfoId}/suspend [post]
+func (s *AntServer) suspendLoadGenerator(c echo.Context) error {
+	return s.controlLoadGenerator(c, "suspend", s.services.loadService.SuspendLoadGenerator)
+}
+
+// resumeLoadGenerator handler function that resumes the suspended vms of a remote load generator.
+// @Id ResumeLoadGenerator
+// @Summary Resume Load Generator
+// @Description Resume the suspended VMs of a remote load generator and wait until they are running. The public IPs of the VMs are refreshed.
+// @Tags [Load Generator Management]
+// @Accept json
+// @Produce json
+// @Param loadGeneratorInstallInfoId path string true "load generator install info id"
+// @Success 200 {object} app.AntResponse[string] "Successfully resume load generator"
+// @Failure 400 {object} app.AntResponse[string] "Load generator install info id must be number."
+// @Router /api/v1/load/generators/{loadGeneratorInstallInfoId}/resume [post]
+func (s *AntServer) resumeLoadGenerator(c echo.Context) error {
+	return s.controlLoadGenerator(c, "resume", s.services.loadService.ResumeLoadGenerator)
+}
+
+// terminateLoadGenerator handler function that terminates the vms of a remote load generator.
+// @Id TerminateLoadGenerator
+// @Summary Terminate Load Generator
+// @Description Terminate the VMs of a remote load generator which has no unfinished load test and delete its MCI.
+// @Description The terminated load generator can not run load tests anymore, and the next installation provisions new VMs.
+// @Tags [Load Generator Management]
+// @Accept json
+// @Produce json
+// @Param loadGeneratorInstallInfoId path string true "load generator install info id"
+// @Success 200 {object} app.AntResponse[string] "Successfully terminate load generator"
+// @Failure 400 {object} app.AntResponse[string] "Load generator install info id must be number."
+// @Failure 400 {object} app.AntResponse[string] "Load generator has unfinished load tests."
+// @Router /api/v1/load/generators/{loadGeneratorInstallInfoId}/terminate [post]
+func (s *AntServer) terminateLoadGenerator(c echo.Context) error {
+	return s.controlLoadGenerator(c, "terminate", s.services.loadService.TerminateLoadGenerator)
+}
+
+// verifyLoadGenerator handler function that checks the health of a load generator.
+// @Id VerifyLoadGenerator
+// @Summary Verify Load Generator
+// @Description Check that the VMs of a load generator are running and reachable by SSH, Java and JMeter of the installed version are present, the install path has free disk (load.health.minFreeDiskMb) and the required JMeter plugins are installed.
+// @Description The status of each load generator server is updated with the result, such as unreachable or unhealthy, and the problems are kept in the healthMessage of the load generator.
+// @Description With reinstall, install-jmeter.sh runs again on the drifted load generator and the health is checked again.
+// @Description Running remote load generators are also checked every load.health.checkInterval, and a JMeter load test fails at once when its load generator is unhealthy.
+// @Tags [Load Generator Management]
+// @Accept json
+// @Produce json
+// @Param loadGeneratorInstallInfoId path string true "load generator install info id"
+// @Param body body app.VerifyLoadGeneratorReq false "Load Generator Verification Request"
+// @Success 200 {object} app.AntResponse[load.LoadGeneratorHealthResult] "Successfully verified load generator"
+// @Failure 400 {object} app.AntResponse[string] "Load generator install info id must be number."
+// @Failure 500 {object} app.AntResponse[string] "Failed to verify load generator."
+// @Router /api/v1/load/generators/{loadGeneratorInstallInfoId}/verify [post]
+func (s *AntServer) verifyLoadGenerator(c echo.Context) error {
+	var req VerifyLoadGeneratorReq
+
+	cvt, err := strconv.Atoi(c.Param("loadGeneratorInstallInfoId"))
+	if err != nil || cvt <= 0 {
+		return errorResponseJson(http.StatusBadRequest, "Load generator install info id must be number.")
+	}
+
+	if err := c.Bind(&req); err != nil {
+		return errorResponseJson(http.StatusBadRequest, "load generator verification info is not correct.")
+	}
+
+	result, err := s.services.loadService.VerifyLoadGenerator(uint(cvt), req.Reinstall)
+	if err != nil {
+		utils.LogErrorf("Error verifying load generator %d: %v", cvt, err)
+		return errorResponseJson(http.StatusInternalServerError, err.Error())
+	}
+
+	return successResponseJson(c, "Successfully verified load generator", result)
+}
+
+// rotateLoadGeneratorKey handler function that replaces the ssh key of a load generator.
+// @Id RotateLoadGeneratorKey
+// @Summary Rotate Load Generator Key
+// @Description Generate a new key pair for a remote or SSH load generator and authorize its public key on every load generator server.
+// @Description The new key is checked by SSH on each server before it is activated, then the former key of the load generator and the key shared by the load generators installed before the keystore are removed from the authorized keys.
+// @Description The key of a host registered by SSH is kept, because it was authorized by the user. The private keys are encrypted with load.keystore.masterKey, which must be set.
+// @Description The load generator must not be suspended or have unfinished load tests.
+// @Tags [Load Generator Management]
+// @Accept json
+// @Produce json
+// @Param loadGeneratorInstallInfoId path string true "load generator install info id"
+// @Success 200 {object} app.AntResponse[load.LoadGeneratorKeyResult] "Successfully rotated load generator key"
+// @Failure 400 {object} app.AntResponse[string] "Load generator install info id must be number."
+// @Failure 500 {object} app.AntResponse[string] "Failed to rotate load generator key."
+// @Router /api/v1/load/generators/{loadGeneratorInstallInfoId}/keys/rotate [post]
+func (s *AntServer) rotateLoadGeneratorKey(c echo.Context) error {
+	cvt, err := strconv.Atoi(c.Param("loadGeneratorInstallInfoId"))
+	if err != nil || cvt <= 0 {
+		return errorResponseJson(http.StatusBadRequest, "Load generator install info id must be number.")
+	}
+
+	result, err := s.services.loadService.RotateLoadGeneratorKey(uint(cvt))
+	if err != nil {
+		utils.LogErrorf("Error rotating key of load generator %d: %v", cvt, err)
+		return errorResponseJson(http.StatusInternalServerError, err.Error())
+	}
+
+	return successResponseJson(c, "Successfully rotated load generator key", result)
+}
+
+func (s *AntServer) controlLoadGenerator(c echo.Context, action string, control func(uint) error) error {
+	loadGeneratorInstallInfoId := c.Param("loadGeneratorInstallInfoId")
+
+	cvt, err := strconv.Atoi(loadGeneratorInstallInfoId)
+	if err != nil || cvt <= 0 {
+		return errorResponseJson(http.StatusBadRequest, "Load generator install info id must be number.")
+	}
+
+	if err := control(uint(cvt)); err != nil {
+		utils.LogErrorf("Error trying to %s load generator %d: %v", action, cvt, err)
+		return errorResponseJson(http.StatusBadRequest, err.Error())
+	}
+
+	return successResponseJson(
+		c,
+		fmt.Sprintf("Successfully %s load generator: %s", action, loadGeneratorInstallInfoId),
+		"done",
+	)
+}
+
 // runLoadTest handler function that initiates a load test.
 // @Id RunLoadTest
 // @Summary Run Load Test
 // @Description Start a load test using the provided load test configuration.
+// @Description SLO rules such as `{"label": "home", "metric": "p95", "operator": "<", "threshold": 300}` are evaluated after the result is fetched and the verdict is stored in the load test execution state.
+// @Description Supported metrics are average, median, p90, p95, p99, minTime, maxTime, errorPercent, throughput, receivedKB and sentKB. Supported operators are <, <=, > and >=.
+// @Description Http requests support GET, HEAD, OPTIONS, POST, PUT, PATCH and DELETE methods with headers, cookies, content type, basic or bearer auth, timeouts in milliseconds and redirect policy (follow, auto or none).
+// @Description Http requests are executed in order as the steps of a scenario. A step can extract variables from the response with jsonpath or regex extractors, and later steps refer to them as `${variable}`.
+// @Description Consecutive steps with the same transaction name are grouped and reported as a transaction, and thinkTime pauses the virtual user for the given milliseconds after the step.
+// @Description Set testPlanId to run a test plan uploaded to the plan library instead of the plan generated from the http requests.
+// @Description Set engine to jmeter (default), k6 or native to choose the load generator engine. The k6 engine is installed on demand and runs on the master load generator only; uploaded test plans and worker VMs require jmeter.
+// @Description The native engine sends the http requests from ant itself without any installation, so it only runs on the local load generator. Its results are written in the jmeter csv format.
+// @Description Set loadProfile to shape the load with stages instead of virtualUsers, rampUpTime, rampUpSteps and duration. Each stage changes the load linearly from the previous target to its target for the duration in seconds, starting from 0, and a stage of 0 duration changes the load at once.
+// @Description The closed model shapes the concurrent virtual users, and the open model shapes the arrival rate, the iterations of the http requests started per second, limited by maxVirtualUsers.
+// @Description For example, a spike is `{"model": "closed", "stages": [{"duration": 60, "target": 10}, {"duration": 0, "target": 100}, {"duration": 120, "target": 100}, {"duration": 0, "target": 10}, {"duration": 60, "target": 10}]}` and a soak is `{"model": "open", "stages": [{"duration": 300, "target": 50}, {"duration": 14400, "target": 50}]}`.
 // @Tags [Load Test Execution Management]
 // @Accept json
 // @Produce json
@@ -171,32 +395,156 @@ func (s *AntServer) runLoadTest(c echo.Context) error {
 		return errorResponseJson(http.StatusBadRequest, "load test running info is not correct.")
 	}
 
+	if msg := validateRunLoadTestReq(&req); msg != "" {
+		return errorResponseJson(http.StatusBadRequest, msg)
+	}
+
+	arg := toRunLoadTestParam(req)
+
+	loadTestKey, err := s.services.loadService.RunLoadTest(arg)
+
+	if err != nil {
+		return errorResponseJson(http.StatusBadRequest, err.Error())
+	}
+
+	return successResponseJson(
+		c,
+		fmt.Sprintf("Successfully run load test. Load test key: %s", loadTestKey),
+		loadTestKey,
+	)
+}
+
+// validateRunLoadTestReq checks the run load test request and returns the error message if it is invalid.
+// Install information is cleared when an already installed load generator is used.
+func validateRunLoadTestReq(req *RunLoadTestReq) string {
 	if req.LoadGeneratorInstallInfoId != uint(0) {
 		req.InstallLoadGenerator = InstallLoadGeneratorReq{}
 	} else if req.InstallLoadGenerator.InstallLocation != constant.Local &&
 		req.InstallLoadGenerator.InstallLocation != constant.Remote {
-		return errorResponseJson(http.StatusBadRequest, "load test install location is invalid.")
+		return "load test install location is invalid."
+	} else if req.InstallLoadGenerator.WorkerCount < 0 ||
+		(req.InstallLoadGenerator.InstallLocation == constant.Local && req.InstallLoadGenerator.WorkerCount > 0) {
+		return "worker count is only available for remote install location."
+	} else if req.InstallLoadGenerator.InstallLocation == constant.Local &&
+		(req.InstallLoadGenerator.RegionName != "" || len(req.InstallLoadGenerator.Coordinates) > 0) {
+		return "region is only available for remote install location."
+	} else if req.InstallLoadGenerator.InstallLocation == constant.Local && req.InstallLoadGenerator.LoadGeneratorVmReq.specified() {
+		return "vm is only available for remote install location."
 	}
 
+	return ""
+}
+
+// coordinatesOrDefault returns the coordinates to install the load generator near, which is seoul by default.
+func coordinatesOrDefault(coordinates []string) []string {
+	if len(coordinates) == 0 {
+		return []string{seoul}
+	}
+	return coordinates
+}
+
+// specified reports whether any vm option of the load generator is given.
+func (r LoadGeneratorVmReq) specified() bool {
+	return len(r.Providers) > 0 || r.MinVCpu != 0 || r.MaxVCpu != 0 || r.MinMemoryGiB != 0 || r.MaxMemoryGiB != 0 ||
+		r.Priority != "" || r.Spec != "" || r.Image != "" || r.RootDiskSize != 0
+}
+
+func toLoadGeneratorVmParam(req LoadGeneratorVmReq) load.LoadGeneratorVmParam {
+	return load.LoadGeneratorVmParam{
+		Providers:    req.Providers,
+		MinVCpu:      req.MinVCpu,
+		MaxVCpu:      req.MaxVCpu,
+		MinMemoryGiB: req.MinMemoryGiB,
+		MaxMemoryGiB: req.MaxMemoryGiB,
+		Priority:     constant.VmPriority(req.Priority),
+		Spec:         req.Spec,
+		Image:        req.Image,
+		RootDiskSize: req.RootDiskSize,
+	}
+}
+
+func toRunLoadTestParam(req RunLoadTestReq) load.RunLoadTestParam {
 	var https []load.RunLoadTestHttpParam
 	for _, h := range req.HttpReqs {
 		hh := load.RunLoadTestHttpParam{
-			Method:   h.Method,
-			Protocol: h.Protocol,
-			Hostname: h.Hostname,
-			Port:     h.Port,
-			Path:     h.Path,
-			BodyData: h.BodyData,
+			Method:          h.Method,
+			Protocol:        h.Protocol,
+			Hostname:        h.Hostname,
+			Port:            h.Port,
+			Path:            h.Path,
+			BodyData:        h.BodyData,
+			ContentType:     h.ContentType,
+			ConnectTimeout:  h.ConnectTimeout,
+			ResponseTimeout: h.ResponseTimeout,
+			RedirectPolicy:  h.RedirectPolicy,
+			Name:            h.Name,
+			Transaction:     h.Transaction,
+			ThinkTime:       h.ThinkTime,
+		}
+
+		for _, header := range h.Headers {
+			hh.Headers = append(hh.Headers, load.RunLoadTestHttpHeaderParam{Name: header.Name, Value: header.Value})
+		}
+
+		for _, cookie := range h.Cookies {
+			hh.Cookies = append(hh.Cookies, load.RunLoadTestHttpCookieParam{Name: cookie.Name, Value: cookie.Value})
+		}
+
+		for _, e := range h.Extractors {
+			hh.Extractors = append(hh.Extractors, load.RunLoadTestExtractorParam{
+				Type:         e.Type,
+				Variable:     e.Variable,
+				Expression:   e.Expression,
+				MatchNumber:  e.MatchNumber,
+				DefaultValue: e.DefaultValue,
+				Template:     e.Template,
+				Field:        e.Field,
+			})
+		}
+
+		if h.Auth != nil {
+			hh.Auth = &load.RunLoadTestHttpAuthParam{
+				Type:     h.Auth.Type,
+				Username: h.Auth.Username,
+				Password: h.Auth.Password,
+				Token:    h.Auth.Token,
+			}
 		}
 
 		https = append(https, hh)
 	}
 
-	arg := load.RunLoadTestParam{
+	var sloRules []load.SloRuleParam
+	for _, r := range req.SloRules {
+		sloRules = append(sloRules, load.SloRuleParam{
+			Label:     r.Label,
+			Metric:    r.Metric,
+			Operator:  r.Operator,
+			Threshold: r.Threshold,
+		})
+	}
+
+	var loadProfile *load.LoadProfileParam
+	if p := req.LoadProfile; p != nil {
+		loadProfile = &load.LoadProfileParam{
+			Model:           p.Model,
+			MaxVirtualUsers: p.MaxVirtualUsers,
+		}
+
+		for _, s := range p.Stages {
+			loadProfile.Stages = append(loadProfile.Stages, load.LoadStageParam{Duration: s.Duration, Target: s.Target})
+		}
+	}
+
+	return load.RunLoadTestParam{
 
 		InstallLoadGenerator: load.InstallLoadGeneratorParam{
 			InstallLocation: req.InstallLoadGenerator.InstallLocation,
-			Coordinates:     []string{seoul},
+			Coordinates:     coordinatesOrDefault(req.InstallLoadGenerator.Coordinates),
+			WorkerCount:     req.InstallLoadGenerator.WorkerCount,
+			RegionName:      req.InstallLoadGenerator.RegionName,
+
+			LoadGeneratorVmParam: toLoadGeneratorVmParam(req.InstallLoadGenerator.LoadGeneratorVmReq),
 		},
 		LoadGeneratorInstallInfoId: req.LoadGeneratorInstallInfoId,
 		TestName:                   req.TestName,
@@ -208,26 +556,20 @@ func (s *AntServer) runLoadTest(c echo.Context) error {
 		Port:                       req.Port,
 		AgentInstalled:             req.AgentInstalled,
 		AgentHostname:              req.AgentHostname,
+		TestPlanId:                 req.TestPlanId,
+		Engine:                     constant.LoadGeneratorType(req.Engine),
+		LoadProfile:                loadProfile,
 		HttpReqs:                   https,
+		SloRules:                   sloRules,
 	}
-
-	loadTestKey, err := s.services.loadService.RunLoadTest(arg)
-
-	if err != nil {
-		return errorResponseJson(http.StatusBadRequest, err.Error())
-	}
-
-	return successResponseJson(
-		c,
-		fmt.Sprintf("Successfully run load test. Load test key: %s", loadTestKey),
-		loadTestKey,
-	)
 }
 
 // stopLoadTest handler function that stops a running load test.
 // @Id StopLoadTest
 // @Summary Stop Load Test
 // @Description Stop a running load test using the provided load test key.
+// @Description A load test in preparation is canceled before the load generator engine starts, and a running engine is killed.
+// @Description The partial result is still fetched and the load test ends with the stopped status. Stopping a finished load test does nothing.
 // @Tags [Load Test Execution Management]
 // @Accept json
 // @Produce json
@@ -272,8 +614,9 @@ func (s *AntServer) stopLoadTest(c echo.Context) error {
 // @Accept json
 // @Produce json
 // @Param loadTestKey query string true "Load test key"
-// @Param format query string false "Result format (normal or aggregate)"
-// @Success 200 {object} app.JsonResult{[normal]=app.AntResponse[[]load.ResultSummary],[aggregate]=app.AntResponse[[]load.LoadTestStatistics]} "Successfully retrieved load test metrics"
+// @Param format query string false "Result format (normal, aggregate or timeseries)"
+// @Param interval query string false "Bucket interval of timeseries format such as 1s, 10s or 1m (default 10s, min 1s). widened to its multiple when the result spans more than 10000 buckets"
+// @Success 200 {object} app.JsonResult{[normal]=app.AntResponse[[]load.ResultSummary],[aggregate]=app.AntResponse[[]load.LoadTestStatistics],[timeseries]=app.AntResponse[[]load.ResultTimeSeries]} "Successfully retrieved load test metrics"
 // @Failure 400 {object} app.AntResponse[string] "Invalid request parameters"
 // @Failure 500 {object} app.AntResponse[string] "Failed to retrieve load test result"
 // @Router /api/v1/load/test/result [get]
@@ -289,13 +632,23 @@ func (s *AntServer) getLoadTestResult(c echo.Context) error {
 
 	if req.Format == "" {
 		req.Format = constant.Normal
-	} else if req.Format != constant.Normal && req.Format != constant.Aggregate {
+	} else if req.Format != constant.Normal && req.Format != constant.Aggregate && req.Format != constant.TimeSeries {
 		req.Format = constant.Normal
 	}
 
+	interval := defaultTimeSeriesInterval
+	if req.Format == constant.TimeSeries && req.Interval != "" {
+		d, err := time.ParseDuration(req.Interval)
+		if err != nil || d < minTimeSeriesInterval {
+			return errorResponseJson(http.StatusBadRequest, "interval must be a duration of at least 1s such as 10s or 1m")
+		}
+		interval = d
+	}
+
 	arg := load.GetLoadTestResultParam{
 		LoadTestKey: req.LoadTestKey,
 		Format:      req.Format,
+		Interval:    interval,
 	}
 
 	result, err := s.services.loadService.GetLoadTestResult(arg)
@@ -307,10 +660,164 @@ func (s *AntServer) getLoadTestResult(c echo.Context) error {
 	return successResponseJson(c, "Successfully retrieved load test result", result)
 }
 
+// streamLoadTestProgress handler function that streams the progress of a running load test.
+// @Id StreamLoadTestProgress
+// @Summary Stream load test progress
+// @Description Push the progress of the load test as server-sent events until the load test is finished or the client disconnects.
+// @Description Each `progress` event carries the rolling rps, latency percentiles and error percent of each label and the latest perfmon metrics, which are computed from the partially fetched results.
+// @Description A `done` event is sent with the last progress when the load test is finished.
+// @Tags [Load Test Result]
+// @Produce text/event-stream
+// @Param loadTestKey path string true "Load test key"
+// @Param window query string false "Rolling window of the statistics from 1s to 10m such as 10s or 1m (default 30s)"
+// @Success 200 {object} load.LoadTestProgress "Stream of load test progress events"
+// @Failure 400 {object} app.AntResponse[string] "Invalid request parameters"
+// @Failure 404 {object} app.AntResponse[string] "Load test is not found"
+// @Router /api/v1/load/tests/{loadTestKey}/stream [get]
+func (s *AntServer) streamLoadTestProgress(c echo.Context) error {
+	loadTestKey := c.Param("loadTestKey")
+	if strings.TrimSpace(loadTestKey) == "" {
+		return errorResponseJson(http.StatusBadRequest, "pass correct load test key")
+	}
+
+	window := defaultProgressWindow
+	if w := c.QueryParam("window"); w != "" {
+		d, err := time.ParseDuration(w)
+		if err != nil || d < minTimeSeriesInterval || d > load.MaxProgressWindow {
+			return errorResponseJson(http.StatusBadRequest, "window must be a duration from 1s to 10m such as 10s or 1m")
+		}
+		window = d
+	}
+
+	progress, err := s.services.loadService.GetLoadTestProgress(loadTestKey, window)
+	if err != nil {
+		return errorResponseJson(http.StatusNotFound, "Load test is not found")
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.WriteHeader(http.StatusOK)
+
+	ticker := time.NewTicker(progressStreamInterval)
+	defer ticker.Stop()
+
+	for {
+		event := "progress"
+		if progress.Finished {
+			event = "done"
+		}
+
+		if err := writeServerSentEvent(res, event, progress); err != nil {
+			utils.LogWarnf("Failed to stream progress of load test %s: %v", loadTestKey, err)
+			return nil
+		}
+
+		if progress.Finished {
+			return nil
+		}
+
+		select {
+		case <-c.Request().Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		p, err := s.services.loadService.GetLoadTestProgress(loadTestKey, window)
+		if err != nil {
+			utils.LogErrorf("Failed to get progress of load test %s: %v", loadTestKey, err)
+			continue
+		}
+		progress = p
+	}
+}
+
+func writeServerSentEvent(res *echo.Response, event string, data any) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if _, err = fmt.Fprintf(res, "event: %s\ndata: %s\n\n", event, b); err != nil {
+		return err
+	}
+
+	res.Flush()
+	return nil
+}
+
+// compareLoadTestResult handler function that compares the results of two load tests.
+// @Id CompareLoadTestResult
+// @Summary Compare load test results
+// @Description Compare the aggregated results of the base load test (before migration) and the target load test (after migration) label by label.
+// @Description Deltas of average, p90, p95, p99, error percent and throughput are flagged as regressed when they exceed the thresholds.
+// @Description A delta from the base of 0 is marked fromZero, and any increase of the response time from 0 is regressed.
+// @Description Labels only in one of the results are marked added or removed, and a removed label is regressed.
+// @Description Thresholds which are not passed use the values of the configuration.
+// @Tags [Load Test Result]
+// @Accept json
+// @Produce json
+// @Param baseKey query string true "Load test key of the base (before migration) result"
+// @Param targetKey query string true "Load test key of the target (after migration) result"
+// @Param latencyThreshold query number false "Allowed increase of response time in percent"
+// @Param errorPercentThreshold query number false "Allowed increase of error percent in percentage points"
+// @Param throughputThreshold query number false "Allowed decrease of throughput in percent"
+// @Success 200 {object} app.AntResponse[load.CompareLoadTestResult] "Successfully compared load test results"
+// @Failure 400 {object} app.AntResponse[string] "Invalid request parameters"
+// @Failure 500 {object} app.AntResponse[string] "Failed to compare load test results"
+// @Router /api/v1/load/tests/result/compare [get]
+func (s *AntServer) compareLoadTestResult(c echo.Context) error {
+	var req CompareLoadTestResultReq
+	if err := c.Bind(&req); err != nil {
+		return errorResponseJson(http.StatusBadRequest, "Invalid request parameters")
+	}
+
+	if strings.TrimSpace(req.BaseKey) == "" || strings.TrimSpace(req.TargetKey) == "" {
+		return errorResponseJson(http.StatusBadRequest, "pass correct base key and target key")
+	}
+
+	thresholds := load.CompareThresholds{
+		LatencyThreshold:      config.AppConfig.Load.Compare.LatencyThreshold,
+		ErrorPercentThreshold: config.AppConfig.Load.Compare.ErrorPercentThreshold,
+		ThroughputThreshold:   config.AppConfig.Load.Compare.ThroughputThreshold,
+	}
+
+	if req.LatencyThreshold != nil {
+		thresholds.LatencyThreshold = *req.LatencyThreshold
+	}
+	if req.ErrorPercentThreshold != nil {
+		thresholds.ErrorPercentThreshold = *req.ErrorPercentThreshold
+	}
+	if req.ThroughputThreshold != nil {
+		thresholds.ThroughputThreshold = *req.ThroughputThreshold
+	}
+
+	if thresholds.LatencyThreshold < 0 || thresholds.ErrorPercentThreshold < 0 || thresholds.ThroughputThreshold < 0 {
+		return errorResponseJson(http.StatusBadRequest, "thresholds must not be negative")
+	}
+
+	arg := load.CompareLoadTestResultParam{
+		BaseKey:    req.BaseKey,
+		TargetKey:  req.TargetKey,
+		Thresholds: thresholds,
+	}
+
+	result, err := s.services.loadService.CompareLoadTestResult(arg)
+
+	if err != nil {
+		return errorResponseJson(http.StatusInternalServerError, "Failed to compare load test results")
+	}
+
+	return successResponseJson(c, "Successfully compared load test results", result)
+}
+
 // getLoadTestMetrics handler function that retrieves metrics for a specific load test.
 // @Id GetLoadTestMetrics
 // @Summary Get load test metrics
 // @Description Retrieve load test metrics based on provided parameters.
+// @Description The metrics of the target servers collected by the monitoring agent are followed by the resource usage of the load generator
+// @Description sampled while the load test runs, labeled load_generator_cpu, load_generator_memory (%), load_generator_network_rx and load_generator_network_tx (Mbps).
 // @Tags [Load Test Result]
 // @Accept json
 // @Produce json
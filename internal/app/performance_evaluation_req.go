@@ -18,6 +18,38 @@ type GetAllMonitoringAgentInfosReq struct {
 
 type InstallLoadGeneratorReq struct {
 	InstallLocation constant.InstallLocation `json:"installLocation"`
+	WorkerCount     int                      `json:"workerCount,omitempty"`
+	RegionName      string                   `json:"regionName,omitempty"`
+	Coordinates     []string                 `json:"coordinates,omitempty"`
+
+	LoadGeneratorVmReq
+}
+
+// LoadGeneratorVmReq selects the vm of the remote load generator instead of the default aws vm with 2-8 vcpu and 4-8 GiB memory.
+type LoadGeneratorVmReq struct {
+	Providers    []string `json:"providers,omitempty"`
+	MinVCpu      int      `json:"minVCpu,omitempty"`
+	MaxVCpu      int      `json:"maxVCpu,omitempty"`
+	MinMemoryGiB int      `json:"minMemoryGiB,omitempty"`
+	MaxMemoryGiB int      `json:"maxMemoryGiB,omitempty"`
+	Priority     string   `json:"priority,omitempty"`
+	Spec         string   `json:"spec,omitempty"`
+	Image        string   `json:"image,omitempty"`
+	RootDiskSize int      `json:"rootDiskSize,omitempty"`
+}
+
+// RegisterLoadGeneratorReq is the existing host which is registered as the load generator over ssh.
+type RegisterLoadGeneratorReq struct {
+	PublicIp       string `json:"publicIp"`
+	PrivateIp      string `json:"privateIp,omitempty"`
+	SshPort        string `json:"sshPort,omitempty"`
+	Username       string `json:"username"`
+	PrivateKeyName string `json:"privateKeyName"`
+	Label          string `json:"label,omitempty"`
+}
+
+type VerifyLoadGeneratorReq struct {
+	Reinstall bool `json:"reinstall,omitempty"`
 }
 
 type GetAllLoadGeneratorInstallInfoReq struct {
@@ -38,8 +70,30 @@ type RunLoadTestReq struct {
 	Port                       string                  `json:"port"`
 	AgentInstalled             bool                    `json:"agentInstalled"`
 	AgentHostname              string                  `json:"agentHostname"`
+	TestPlanId                 uint                    `json:"testPlanId,omitempty"`
+	Engine                     string                  `json:"engine,omitempty"`
+	LoadProfile                *LoadProfileReq         `json:"loadProfile,omitempty"`
 
 	HttpReqs []RunLoadGeneratorHttpReq `json:"httpReqs,omitempty"`
+	SloRules []SloRuleReq              `json:"sloRules,omitempty"`
+}
+
+type LoadProfileReq struct {
+	Model           string         `json:"model"`
+	MaxVirtualUsers int            `json:"maxVirtualUsers,omitempty"`
+	Stages          []LoadStageReq `json:"stages"`
+}
+
+type LoadStageReq struct {
+	Duration int `json:"duration"`
+	Target   int `json:"target"`
+}
+
+type SloRuleReq struct {
+	Label     string  `json:"label,omitempty"`
+	Metric    string  `json:"metric"`
+	Operator  string  `json:"operator"`
+	Threshold float64 `json:"threshold"`
 }
 
 type RunLoadGeneratorHttpReq struct {
@@ -49,6 +103,41 @@ type RunLoadGeneratorHttpReq struct {
 	Port     string `json:"port,omitempty"`
 	Path     string `json:"path,omitempty"`
 	BodyData string `json:"bodyData,omitempty"`
+
+	Headers         []RunLoadGeneratorHttpHeaderReq `json:"headers,omitempty"`
+	Cookies         []RunLoadGeneratorHttpHeaderReq `json:"cookies,omitempty"`
+	ContentType     string                          `json:"contentType,omitempty"`
+	Auth            *RunLoadGeneratorHttpAuthReq    `json:"auth,omitempty"`
+	ConnectTimeout  int                             `json:"connectTimeout,omitempty"`
+	ResponseTimeout int                             `json:"responseTimeout,omitempty"`
+	RedirectPolicy  string                          `json:"redirectPolicy,omitempty"`
+
+	Name        string                         `json:"name,omitempty"`
+	Transaction string                         `json:"transaction,omitempty"`
+	ThinkTime   int                            `json:"thinkTime,omitempty"`
+	Extractors  []RunLoadGeneratorExtractorReq `json:"extractors,omitempty"`
+}
+
+type RunLoadGeneratorExtractorReq struct {
+	Type         string `json:"type"`
+	Variable     string `json:"variable"`
+	Expression   string `json:"expression"`
+	MatchNumber  int    `json:"matchNumber,omitempty"`
+	DefaultValue string `json:"defaultValue,omitempty"`
+	Template     string `json:"template,omitempty"`
+	Field        string `json:"field,omitempty"`
+}
+
+type RunLoadGeneratorHttpHeaderReq struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type RunLoadGeneratorHttpAuthReq struct {
+	Type     string `json:"type"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Token    string `json:"token,omitempty"`
 }
 
 type GetAllLoadTestExecutionStateReq struct {
@@ -70,4 +159,67 @@ type StopLoadTestReq struct {
 type GetLoadTestResultReq struct {
 	LoadTestKey string                `query:"loadTestKey"`
 	Format      constant.ResultFormat `query:"format"`
+	Interval    string                `query:"interval"`
+}
+
+type CompareLoadTestResultReq struct {
+	BaseKey               string   `query:"baseKey"`
+	TargetKey             string   `query:"targetKey"`
+	LatencyThreshold      *float64 `query:"latencyThreshold"`
+	ErrorPercentThreshold *float64 `query:"errorPercentThreshold"`
+	ThroughputThreshold   *float64 `query:"throughputThreshold"`
+}
+
+type LoadTestScheduleReq struct {
+	Name           string         `json:"name"`
+	CronExpression string         `json:"cronExpression"`
+	Enabled        bool           `json:"enabled"`
+	RunLoadTest    RunLoadTestReq `json:"runLoadTest"`
+}
+
+type GetAllLoadTestSchedulesReq struct {
+	Page int `query:"page"`
+	Size int `query:"size"`
+}
+
+type RunCapacitySearchReq struct {
+	RunLoadTest     RunLoadTestReq `json:"runLoadTest"`
+	LoadModel       string         `json:"loadModel"`
+	StartLoad       int            `json:"startLoad"`
+	StepLoad        int            `json:"stepLoad"`
+	MaxLoad         int            `json:"maxLoad"`
+	StepDuration    int            `json:"stepDuration"`
+	MaxVirtualUsers int            `json:"maxVirtualUsers,omitempty"`
+	MaxErrorPercent float64        `json:"maxErrorPercent"`
+	MaxNinetyFive   float64        `json:"maxNinetyFive,omitempty"`
+}
+
+type RunMultiRegionLoadTestReq struct {
+	RunLoadTest RunLoadTestReq      `json:"runLoadTest"`
+	Regions     []LoadTestRegionReq `json:"regions"`
+}
+
+type LoadTestRegionReq struct {
+	RegionName  string   `json:"regionName"`
+	Coordinates []string `json:"coordinates"`
+	Weight      int      `json:"weight,omitempty"`
+}
+
+type GetAllMultiRegionLoadTestsReq struct {
+	Page int `query:"page"`
+	Size int `query:"size"`
+}
+
+type GetAllCapacitySearchesReq struct {
+	Page int `query:"page"`
+	Size int `query:"size"`
+}
+
+type MoveQueuedLoadTestReq struct {
+	QueuePosition int `json:"queuePosition"`
+}
+
+type GetAllLoadTestPlansReq struct {
+	Page int `query:"page"`
+	Size int `query:"size"`
 }
@@ -28,7 +28,13 @@ func (server *AntServer) InitRouter() error {
 		{
 			loadRouter.GET("/generators", server.getAllLoadGeneratorInstallInfo)
 			loadRouter.POST("/generators", server.installLoadGenerator)
+			loadRouter.POST("/generators/ssh", server.registerLoadGenerator)
 			loadRouter.DELETE("/generators/:loadGeneratorInstallInfoId", server.uninstallLoadGenerator)
+			loadRouter.POST("/generators/:loadGeneratorInstallInfoId/suspend", server.suspendLoadGenerator)
+			loadRouter.POST("/generators/:loadGeneratorInstallInfoId/resume", server.resumeLoadGenerator)
+			loadRouter.POST("/generators/:loadGeneratorInstallInfoId/terminate", server.terminateLoadGenerator)
+			loadRouter.POST("/generators/:loadGeneratorInstallInfoId/verify", server.verifyLoadGenerator)
+			loadRouter.POST("/generators/:loadGeneratorInstallInfoId/keys/rotate", server.rotateLoadGeneratorKey)
 
 			// load test metrics agent
 			loadRouter.POST("/monitoring/agent/install", server.installMonitoringAgent, middleware.RateLimiter(middleware.NewRateLimiterMemoryStore(1)))
@@ -53,6 +59,54 @@ func (server *AntServer) InitRouter() error {
 				// load test result
 				loadTestRouter.GET("/result", server.getLoadTestResult)
 				loadTestRouter.GET("/result/metrics", server.getLoadTestMetrics)
+				loadTestRouter.GET("/result/compare", server.compareLoadTestResult)
+
+				// load test progress
+				loadTestRouter.GET("/:loadTestKey/stream", server.streamLoadTestProgress)
+			}
+
+			loadScheduleRouter := loadRouter.Group("/schedules")
+
+			{
+				loadScheduleRouter.POST("", server.createLoadTestSchedule)
+				loadScheduleRouter.GET("", server.getAllLoadTestSchedules)
+				loadScheduleRouter.GET("/:scheduleId", server.getLoadTestSchedule)
+				loadScheduleRouter.PUT("/:scheduleId", server.updateLoadTestSchedule)
+				loadScheduleRouter.DELETE("/:scheduleId", server.deleteLoadTestSchedule)
+			}
+
+			loadCapacitySearchRouter := loadRouter.Group("/capacity-searches")
+
+			{
+				loadCapacitySearchRouter.POST("", server.runCapacitySearch)
+				loadCapacitySearchRouter.GET("", server.getAllCapacitySearches)
+				loadCapacitySearchRouter.GET("/:capacitySearchId", server.getCapacitySearch)
+			}
+
+			loadMultiRegionRouter := loadRouter.Group("/multi-region-tests")
+
+			{
+				loadMultiRegionRouter.POST("", server.runMultiRegionLoadTest)
+				loadMultiRegionRouter.GET("", server.getAllMultiRegionLoadTests)
+				loadMultiRegionRouter.GET("/:multiRegionLoadTestId", server.getMultiRegionLoadTest)
+				loadMultiRegionRouter.GET("/:multiRegionLoadTestId/result", server.getMultiRegionLoadTestResult)
+			}
+
+			loadQueueRouter := loadRouter.Group("/queue")
+
+			{
+				loadQueueRouter.GET("", server.getLoadTestQueue)
+				loadQueueRouter.PUT("/:loadTestKey", server.moveQueuedLoadTest)
+				loadQueueRouter.DELETE("/:loadTestKey", server.cancelQueuedLoadTest)
+			}
+
+			loadPlanRouter := loadRouter.Group("/plans")
+
+			{
+				loadPlanRouter.POST("", server.uploadLoadTestPlan)
+				loadPlanRouter.GET("", server.getAllLoadTestPlans)
+				loadPlanRouter.GET("/:planId", server.getLoadTestPlan)
+				loadPlanRouter.DELETE("/:planId", server.deleteLoadTestPlan)
 			}
 		}
 	}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -105,3 +106,12 @@ func initializeServices(repos *antRepositories, tbClient *tumblebug.TumblebugCli
 func (a *AntServer) Start() error {
 	return a.e.Start(fmt.Sprintf(":%s", config.AppConfig.Server.Port))
 }
+
+// StartBackgroundJobs launches the periodic jobs of the services.
+// The jobs are stopped when the given context is canceled.
+func (a *AntServer) StartBackgroundJobs(ctx context.Context) {
+	go a.services.loadService.RecoverLoadTests(ctx)
+	go a.services.loadService.RunLoadTestScheduler(ctx)
+	go a.services.loadService.RunLoadGeneratorReaper(ctx)
+	go a.services.loadService.RunLoadGeneratorHealthChecker(ctx)
+}
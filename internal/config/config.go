@@ -45,6 +45,42 @@ type AntConfig struct {
 			Dir     string `yaml:"dir"`
 			Version string `yaml:"version"`
 		} `yaml:"jmeter"`
+		K6 struct {
+			Version string `yaml:"version"`
+		} `yaml:"k6"`
+		Schedule struct {
+			CheckInterval time.Duration `yaml:"checkInterval"`
+		} `yaml:"schedule"`
+		Queue struct {
+			MaxConcurrentTests int `yaml:"maxConcurrentTests"`
+		} `yaml:"queue"`
+		Generator struct {
+			IdleTimeout       time.Duration `yaml:"idleTimeout"`
+			IdleAction        string        `yaml:"idleAction"`
+			IdleCheckInterval time.Duration `yaml:"idleCheckInterval"`
+		} `yaml:"generator"`
+		Health struct {
+			CheckInterval time.Duration `yaml:"checkInterval"`
+			MinFreeDiskMb int           `yaml:"minFreeDiskMb"`
+			AutoReinstall bool          `yaml:"autoReinstall"`
+		} `yaml:"health"`
+		Saturation struct {
+			CpuPercent    float64 `yaml:"cpuPercent"`
+			MemoryPercent float64 `yaml:"memoryPercent"`
+			NetworkMbps   float64 `yaml:"networkMbps"`
+		} `yaml:"saturation"`
+		Keystore struct {
+			MasterKey Secret `yaml:"masterKey"`
+			KeyType   string `yaml:"keyType"`
+		} `yaml:"keystore"`
+		Ssh struct {
+			TrustUnknownHosts bool `yaml:"trustUnknownHosts"`
+		} `yaml:"ssh"`
+		Compare struct {
+			LatencyThreshold      float64 `yaml:"latencyThreshold"`
+			ErrorPercentThreshold float64 `yaml:"errorPercentThreshold"`
+			ThroughputThreshold   float64 `yaml:"throughputThreshold"`
+		} `yaml:"compare"`
 	} `yaml:"load"`
 	Log struct {
 		Level string `yaml:"level"`
@@ -59,6 +95,16 @@ type AntConfig struct {
 	} `yaml:"database"`
 }
 
+// Secret is the config value which is not printed in the log.
+type Secret string
+
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return "******"
+}
+
 func InitConfig() error {
 	log.Info().Msg("Initializing configuration...")
 
@@ -5,17 +5,30 @@ type InstallLocation string
 const (
 	Local  InstallLocation = "local"
 	Remote InstallLocation = "remote"
+	// Ssh is the existing host which is registered as the load generator and reached by ssh directly.
+	Ssh InstallLocation = "ssh"
 )
 
 type LoadGeneratorType string
 
 const (
 	Jmeter LoadGeneratorType = "jmeter"
+	K6     LoadGeneratorType = "k6"
+	Native LoadGeneratorType = "native"
+)
+
+type VmPriority string
+
+const (
+	LocationPriority    VmPriority = "location"
+	CostPriority        VmPriority = "cost"
+	PerformancePriority VmPriority = "performance"
 )
 
 type ExecutionStatus string
 
 const (
+	Queued       ExecutionStatus = "queued"
 	OnPreparing  ExecutionStatus = "on_preparing"
 	OnRunning    ExecutionStatus = "on_running"
 	OnFetching   ExecutionStatus = "on_fetching"
@@ -23,6 +36,7 @@ const (
 	TestFailed   ExecutionStatus = "test_failed"
 	UpdateFailed ExecutionStatus = "update_failed"
 	ResultFailed ExecutionStatus = "result_failed"
+	Stopped      ExecutionStatus = "stopped"
 
 	Failed ExecutionStatus = "failed"
 
@@ -31,11 +45,20 @@ const (
 	Success    ExecutionStatus = "success"
 )
 
+type Verdict string
+
+const (
+	VerdictPassed Verdict = "passed"
+	VerdictFailed Verdict = "failed"
+	VerdictError  Verdict = "error"
+)
+
 type ResultFormat string
 
 const (
-	Normal    ResultFormat = "normal"
-	Aggregate ResultFormat = "aggregate"
+	Normal     ResultFormat = "normal"
+	Aggregate  ResultFormat = "aggregate"
+	TimeSeries ResultFormat = "timeseries"
 )
 
 type PricePolicy string
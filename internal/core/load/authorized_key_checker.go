@@ -20,6 +20,11 @@ func getAddAuthorizedKeyCommand(pkName, pubkName string) (string, error) {
 		return "", err
 	}
 
+	return addAuthorizedKeyCommandOf(pub)
+}
+
+// addAuthorizedKeyCommandOf returns a command to add the public key to the authorized keys.
+func addAuthorizedKeyCommandOf(pub string) (string, error) {
 	addAuthorizedKeyScript := utils.JoinRootPathWith("/script/add-authorized-key.sh")
 
 	addAuthorizedKeyCommand, err := utils.ReadToString(addAuthorizedKeyScript)
@@ -27,7 +32,7 @@ func getAddAuthorizedKeyCommand(pkName, pubkName string) (string, error) {
 		return "", err
 	}
 
-	addAuthorizedKeyCommand = strings.Replace(addAuthorizedKeyCommand, `PUBLIC_KEY=""`, fmt.Sprintf(`PUBLIC_KEY="%s"`, pub), 1)
+	addAuthorizedKeyCommand = strings.Replace(addAuthorizedKeyCommand, `PUBLIC_KEY=""`, fmt.Sprintf(`PUBLIC_KEY="%s"`, strings.TrimSpace(pub)), 1)
 	return addAuthorizedKeyCommand, nil
 }
 
@@ -48,10 +53,21 @@ func validateKeyPair(pkName, pubkName string) (string, string, error) {
 
 	exist := utils.ExistCheck(privKeyPath)
 	if !exist {
-		err := utils.GenerateSSHKeyPair(4096, privKeyPath, pubKeyPath)
+		err := utils.GenerateSSHKeyPair(utils.RSAKey, 4096, privKeyPath, pubKeyPath)
 		if err != nil {
 			return pubKeyPath, privKeyPath, err
 		}
 	}
 	return pubKeyPath, privKeyPath, nil
 }
+
+// revokeAuthorizedKeyCommandOf returns a command to remove the public key from the authorized keys.
+// The key is matched by its base64 body, so the comment of the key does not matter.
+func revokeAuthorizedKeyCommandOf(pub string) (string, error) {
+	fields := strings.Fields(pub)
+	if len(fields) < 2 {
+		return "", fmt.Errorf("public key %q is not in authorized_keys format", pub)
+	}
+
+	return fmt.Sprintf(`f="$HOME/.ssh/authorized_keys"; if [ -f "$f" ]; then grep -vF '%s' "$f" > "$f.ant"; cat "$f.ant" > "$f"; rm -f "$f.ant"; fi`, fields[1]), nil
+}
@@ -0,0 +1,402 @@
+package load
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cloud-barista/cm-ant/internal/core/common/constant"
+	"github.com/cloud-barista/cm-ant/internal/utils"
+)
+
+const (
+	maxCapacitySearchSteps = 50
+
+	capacitySearchPollInterval = 10 * time.Second
+	// the step is given up when it is not finished in this time after its expected execution time.
+	capacitySearchStepGracePeriod = 30 * time.Minute
+)
+
+// RunCapacitySearch validates the param, stores a new capacity search and runs its steps asynchronously.
+func (l *LoadService) RunCapacitySearch(param RunCapacitySearchParam) (CapacitySearchResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var res CapacitySearchResult
+
+	if err := validateCapacitySearchParam(param); err != nil {
+		return res, err
+	}
+
+	// the parameter is stored only to be returned, so the credentials of the http requests are not kept
+	stored := param
+	stored.RunLoadTestParam, _ = withoutHttpSecrets(param.RunLoadTestParam)
+	p, err := json.Marshal(stored)
+	if err != nil {
+		return res, err
+	}
+
+	search := LoadTestCapacitySearch{
+		TestName:               param.RunLoadTestParam.TestName,
+		Status:                 constant.OnRunning,
+		RunCapacitySearchParam: string(p),
+		StartAt:                time.Now(),
+	}
+
+	err = l.loadRepo.InsertLoadTestCapacitySearchTx(ctx, &search)
+	if err != nil {
+		utils.LogErrorf("Error inserting capacity search: %v", err)
+		return res, err
+	}
+
+	go l.runCapacitySearch(&search, param)
+
+	utils.LogInfof("Capacity search %d started with loads %v", search.ID, capacitySearchLoads(param))
+	return mapCapacitySearchResult(search), nil
+}
+
+func (l *LoadService) GetCapacitySearch(id uint) (CapacitySearchResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	search, err := l.loadRepo.GetLoadTestCapacitySearchTx(ctx, id)
+	if err != nil {
+		utils.LogErrorf("Error fetching capacity search: %v", err)
+		return CapacitySearchResult{}, err
+	}
+
+	return mapCapacitySearchResult(search), nil
+}
+
+func (l *LoadService) GetAllCapacitySearches(param GetAllCapacitySearchesParam) (GetAllCapacitySearchesResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var res GetAllCapacitySearchesResult
+
+	searches, totalRows, err := l.loadRepo.GetPagingLoadTestCapacitySearchesTx(ctx, param)
+	if err != nil {
+		utils.LogErrorf("Error fetching capacity searches: %v", err)
+		return res, err
+	}
+
+	for _, s := range searches {
+		res.CapacitySearches = append(res.CapacitySearches, mapCapacitySearchResult(s))
+	}
+	res.TotalRow = totalRows
+
+	return res, nil
+}
+
+// failInterruptedCapacitySearches fails the capacity searches which were running when the server stopped.
+// The steps are driven by the server, so the search can not continue after the restart.
+func (l *LoadService) failInterruptedCapacitySearches() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	count, err := l.loadRepo.FailRunningLoadTestCapacitySearchesTx(ctx, "the capacity search is interrupted by the restart of the server", time.Now())
+	if err != nil {
+		utils.LogErrorf("Error failing interrupted capacity searches: %v", err)
+		return
+	}
+
+	if count > 0 {
+		utils.LogWarnf("%d capacity searches are failed since they were interrupted by the restart", count)
+	}
+}
+
+// validateCapacitySearchParam checks the steps and the limits of the capacity search
+// and validates the load test of the first step.
+func validateCapacitySearchParam(param RunCapacitySearchParam) error {
+	switch strings.ToLower(param.LoadModel) {
+	case loadModelClosed, loadModelOpen:
+	default:
+		return fmt.Errorf("load model %q is not supported", param.LoadModel)
+	}
+
+	if param.StartLoad < 1 || param.StepLoad < 1 {
+		return errors.New("start load and step load must be positive")
+	}
+
+	if param.MaxLoad < param.StartLoad {
+		return errors.New("max load must not be less than the start load")
+	}
+
+	if (param.MaxLoad-param.StartLoad)/param.StepLoad+1 > maxCapacitySearchSteps {
+		return fmt.Errorf("capacity search must have at most %d steps", maxCapacitySearchSteps)
+	}
+
+	if param.StepDuration < 1 {
+		return errors.New("step duration must be positive")
+	}
+
+	if param.MaxErrorPercent < 0 || param.MaxErrorPercent > 100 {
+		return errors.New("max error percent must be between 0 and 100")
+	}
+
+	if param.MaxNinetyFive < 0 {
+		return errors.New("max p95 must not be negative")
+	}
+
+	if param.RunLoadTestParam.TestPlanId != uint(0) {
+		return errors.New("capacity search can not be run with the stored test plan")
+	}
+
+	if len(param.RunLoadTestParam.HttpReqs) == 0 {
+		return errors.New("capacity search needs at least one http request")
+	}
+
+	return validateRunLoadTestParam(capacityStepParam(param, 1, param.StartLoad))
+}
+
+// capacitySearchLoads returns the load of each step from the start load to the max load.
+func capacitySearchLoads(param RunCapacitySearchParam) []int {
+	var loads []int
+	for load := param.StartLoad; load <= param.MaxLoad && len(loads) < maxCapacitySearchSteps; load += param.StepLoad {
+		loads = append(loads, load)
+	}
+	return loads
+}
+
+// capacityStepParam returns the load test of the step, which holds the load for the step duration.
+func capacityStepParam(param RunCapacitySearchParam, step, load int) RunLoadTestParam {
+	p := param.RunLoadTestParam
+	p.TestName = fmt.Sprintf("%s (capacity step %d)", param.RunLoadTestParam.TestName, step)
+	p.LoadProfile = &LoadProfileParam{
+		Model:           strings.ToLower(param.LoadModel),
+		MaxVirtualUsers: param.MaxVirtualUsers,
+		Stages: []LoadStageParam{
+			{Duration: 0, Target: load},
+			{Duration: param.StepDuration, Target: load},
+		},
+	}
+	return p
+}
+
+// runCapacitySearch runs the steps in order and stops at the first step which exceeds the limits.
+// The load generator installed for the first step is reused by the following steps.
+func (l *LoadService) runCapacitySearch(search *LoadTestCapacitySearch, param RunCapacitySearchParam) {
+	transactions := make(map[string]bool)
+	for _, h := range param.RunLoadTestParam.HttpReqs {
+		if h.Transaction != "" {
+			transactions[h.Transaction] = true
+		}
+	}
+
+	err := func() error {
+		for i, load := range capacitySearchLoads(param) {
+			step := i + 1
+			stepParam := capacityStepParam(param, step, load)
+
+			utils.LogInfof("Capacity search %d runs step %d with load %d", search.ID, step, load)
+			loadTestKey, err := l.RunLoadTest(stepParam)
+			if err != nil {
+				return fmt.Errorf("failed to run step %d; %w", step, err)
+			}
+
+			state, err := l.waitLoadTestFinished(loadTestKey, param.StepDuration)
+			if err != nil {
+				return fmt.Errorf("step %d is not finished; %w", step, err)
+			}
+
+			if state.ExecutionStatus != constant.Successed {
+				return fmt.Errorf("load test %s of step %d is %s; %s", loadTestKey, step, state.ExecutionStatus, state.FailureMessage)
+			}
+
+			param.RunLoadTestParam.LoadGeneratorInstallInfoId = state.LoadGeneratorInstallInfoId
+
+			statistics, err := l.loadResultStatistics(loadTestKey, capacityStepLabelOf(transactions))
+			if err != nil {
+				return fmt.Errorf("failed to load the result of step %d; %w", step, err)
+			}
+
+			s := evaluateCapacityStep(param, capacityStepStatistics(statistics))
+			s.LoadTestCapacitySearchId = search.ID
+			s.Step = step
+			s.Load = load
+			s.LoadTestKey = loadTestKey
+
+			// the step on the saturated load generator may show the limit of the load generator, not of the target
+			if state.GeneratorSaturated {
+				if s.Message != "" {
+					s.Message += "; "
+				}
+				s.Message += state.GeneratorSaturationMessage
+			}
+
+			if err := l.insertCapacitySearchStep(&s); err != nil {
+				return err
+			}
+
+			if !s.Passed {
+				utils.LogInfof("Capacity search %d broke at step %d with load %d; %s", search.ID, step, load, s.Message)
+				search.BreakingStep = step
+				search.BreakingLoad = load
+				search.BreakingReason = s.Message
+				return nil
+			}
+
+			search.MaxSustainableLoad = load
+			search.MaxSustainableThroughput = math.Max(search.MaxSustainableThroughput, s.Throughput)
+		}
+
+		return nil
+	}()
+
+	search.Status = constant.Successed
+	if err != nil {
+		utils.LogErrorf("Error running capacity search %d: %v", search.ID, err)
+		search.Status = constant.Failed
+		search.FailureMessage = err.Error()
+	}
+
+	finishAt := time.Now()
+	search.FinishAt = &finishAt
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	err = l.loadRepo.UpdateLoadTestCapacitySearchTx(ctx, search)
+	if err != nil {
+		utils.LogErrorf("Error updating capacity search %d: %v", search.ID, err)
+	}
+}
+
+func (l *LoadService) insertCapacitySearchStep(step *LoadTestCapacitySearchStep) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	err := l.loadRepo.InsertLoadTestCapacitySearchStepTx(ctx, step)
+	if err != nil {
+		return fmt.Errorf("failed to save step %d; %w", step.Step, err)
+	}
+
+	return nil
+}
+
+// waitLoadTestFinished polls the execution state until the load test is not active anymore.
+// The deadline starts when the load test leaves the queue, since it may wait for the other load tests of the load generator.
+func (l *LoadService) waitLoadTestFinished(loadTestKey string, expectedSecond int) (LoadTestExecutionState, error) {
+	var deadline time.Time
+
+	ticker := time.NewTicker(capacitySearchPollInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		state, err := l.loadRepo.GetLoadTestExecutionStateTx(ctx, GetLoadTestExecutionStateParam{LoadTestKey: loadTestKey})
+		cancel()
+
+		if err != nil {
+			utils.LogWarnf("Error fetching state of load test %s: %v", loadTestKey, err)
+		} else if !slices.Contains(activeExecutionStatuses, state.ExecutionStatus) {
+			return state, nil
+		} else if deadline.IsZero() && state.ExecutionStatus != constant.Queued {
+			deadline = time.Now().Add(time.Duration(expectedSecond)*time.Second + capacitySearchStepGracePeriod)
+		}
+
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			break
+		}
+	}
+
+	return LoadTestExecutionState{}, fmt.Errorf("load test %s is not finished until %s", loadTestKey, deadline.Format(time.RFC3339))
+}
+
+// capacityStepLabelOf aggregates the samples of every request under one label of the step.
+// The samples of the transactions are left out since they duplicate the requests in them.
+func capacityStepLabelOf(transactions map[string]bool) func(string) string {
+	return func(label string) string {
+		if transactions[label] {
+			return ""
+		}
+		return "total"
+	}
+}
+
+// capacityStepStatistics returns the statistics of the step aggregated by capacityStepLabelOf.
+func capacityStepStatistics(statistics []*LoadTestStatistics) *LoadTestStatistics {
+	if len(statistics) == 0 {
+		return nil
+	}
+
+	return statistics[0]
+}
+
+// evaluateCapacityStep checks the statistics of the step against the limits of the capacity search.
+func evaluateCapacityStep(param RunCapacitySearchParam, s *LoadTestStatistics) LoadTestCapacitySearchStep {
+	if s == nil {
+		return LoadTestCapacitySearchStep{Message: "no result to evaluate"}
+	}
+
+	step := LoadTestCapacitySearchStep{
+		RequestCount: s.RequestCount,
+		ErrorPercent: s.ErrorPercent,
+		NinetyFive:   s.NinetyFive,
+		Passed:       true,
+	}
+
+	// a single sample has no running time to calculate the throughput of
+	if !math.IsInf(s.Throughput, 0) && !math.IsNaN(s.Throughput) {
+		step.Throughput = s.Throughput
+	}
+
+	var reasons []string
+	if s.ErrorPercent > param.MaxErrorPercent {
+		reasons = append(reasons, fmt.Sprintf("error percent %.2f exceeds %.2f", s.ErrorPercent, param.MaxErrorPercent))
+	}
+
+	if param.MaxNinetyFive > 0 && s.NinetyFive > param.MaxNinetyFive {
+		reasons = append(reasons, fmt.Sprintf("p95 %.2fms exceeds %.2fms", s.NinetyFive, param.MaxNinetyFive))
+	}
+
+	if len(reasons) > 0 {
+		step.Passed = false
+		step.Message = strings.Join(reasons, ", ")
+	}
+
+	return step
+}
+
+func mapCapacitySearchResult(s LoadTestCapacitySearch) CapacitySearchResult {
+	var param RunCapacitySearchParam
+	if err := json.Unmarshal([]byte(s.RunCapacitySearchParam), &param); err != nil {
+		utils.LogErrorf("Error unmarshaling param of capacity search %d: %v", s.ID, err)
+	}
+
+	var steps []CapacitySearchStepResult
+	for _, st := range s.Steps {
+		steps = append(steps, CapacitySearchStepResult{
+			Step:         st.Step,
+			Load:         st.Load,
+			LoadTestKey:  st.LoadTestKey,
+			RequestCount: st.RequestCount,
+			Throughput:   st.Throughput,
+			ErrorPercent: st.ErrorPercent,
+			NinetyFive:   st.NinetyFive,
+			Passed:       st.Passed,
+			Message:      st.Message,
+		})
+	}
+
+	return CapacitySearchResult{
+		ID:                       s.ID,
+		TestName:                 s.TestName,
+		Status:                   s.Status,
+		RunCapacitySearchParam:   param,
+		MaxSustainableLoad:       s.MaxSustainableLoad,
+		MaxSustainableThroughput: s.MaxSustainableThroughput,
+		BreakingStep:             s.BreakingStep,
+		BreakingLoad:             s.BreakingLoad,
+		BreakingReason:           s.BreakingReason,
+		FailureMessage:           s.FailureMessage,
+		Steps:                    steps,
+		StartAt:                  s.StartAt,
+		FinishAt:                 s.FinishAt,
+	}
+}
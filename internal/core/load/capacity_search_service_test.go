@@ -0,0 +1,77 @@
+package load
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapacitySearchSteps(t *testing.T) {
+	param := RunCapacitySearchParam{
+		RunLoadTestParam: RunLoadTestParam{
+			TestName: "capacity",
+			HttpReqs: []RunLoadTestHttpParam{{Method: "GET", Protocol: "http", Hostname: "localhost"}},
+		},
+		LoadModel:       "open",
+		StartLoad:       10,
+		StepLoad:        20,
+		MaxLoad:         60,
+		StepDuration:    30,
+		MaxErrorPercent: 1,
+	}
+	require.NoError(t, validateCapacitySearchParam(param))
+	require.Equal(t, []int{10, 30, 50}, capacitySearchLoads(param))
+
+	stepParam := capacityStepParam(param, 2, 30)
+	require.Equal(t, "capacity (capacity step 2)", stepParam.TestName)
+	require.True(t, stepParam.LoadProfile.isOpen())
+	require.Equal(t, 30, stepParam.LoadProfile.totalDuration())
+	require.Equal(t, []LoadStageParam{{Duration: 0, Target: 30}, {Duration: 30, Target: 30}}, stepParam.LoadProfile.Stages)
+
+	invalids := []func(p *RunCapacitySearchParam){
+		func(p *RunCapacitySearchParam) { p.LoadModel = "burst" },
+		func(p *RunCapacitySearchParam) { p.StepLoad = 0 },
+		func(p *RunCapacitySearchParam) { p.MaxLoad = 5 },
+		func(p *RunCapacitySearchParam) { p.MaxLoad = 10000 },
+		func(p *RunCapacitySearchParam) { p.StepDuration = 0 },
+		func(p *RunCapacitySearchParam) { p.MaxErrorPercent = 101 },
+		func(p *RunCapacitySearchParam) { p.RunLoadTestParam.TestPlanId = 1 },
+		func(p *RunCapacitySearchParam) { p.RunLoadTestParam.HttpReqs = nil },
+	}
+	for i, invalid := range invalids {
+		p := param
+		invalid(&p)
+		require.Error(t, validateCapacitySearchParam(p), i)
+	}
+}
+
+func TestEvaluateCapacityStep(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	sample := func(offset time.Duration, elapsed int, isError bool) *ResultRawData {
+		return &ResultRawData{Timestamp: start.Add(offset), Elapsed: elapsed, IsError: isError}
+	}
+
+	summaries := []ResultSummary{
+		{Label: "login", Results: []*ResultRawData{sample(0, 100, false), sample(time.Second, 100, false)}},
+		{Label: "items", Results: []*ResultRawData{sample(time.Second, 300, false), sample(2*time.Second, 500, true)}},
+		{Label: "flow", Results: []*ResultRawData{sample(time.Second, 400, false), sample(2*time.Second, 600, true)}},
+	}
+
+	s := capacityStepStatistics(aggregateBy(summaries, capacityStepLabelOf(map[string]bool{"flow": true})))
+	require.Equal(t, 4, s.RequestCount)
+	require.Equal(t, 25.0, s.ErrorPercent)
+	require.Equal(t, 2.0, s.Throughput)
+
+	param := RunCapacitySearchParam{MaxErrorPercent: 30, MaxNinetyFive: 1000}
+	step := evaluateCapacityStep(param, s)
+	require.True(t, step.Passed)
+	require.Equal(t, 2.0, step.Throughput)
+
+	param = RunCapacitySearchParam{MaxErrorPercent: 10, MaxNinetyFive: 200}
+	step = evaluateCapacityStep(param, s)
+	require.False(t, step.Passed)
+	require.Equal(t, "error percent 25.00 exceeds 10.00, p95 500.00ms exceeds 200.00ms", step.Message)
+
+	require.False(t, evaluateCapacityStep(param, capacityStepStatistics(nil)).Passed)
+}
@@ -43,6 +43,47 @@ type GetAllMonitoringAgentInfoResult struct {
 type InstallLoadGeneratorParam struct {
 	InstallLocation constant.InstallLocation `json:"installLocation,omitempty"`
 	Coordinates     []string                 `json:"coordinate"`
+	WorkerCount     int                      `json:"workerCount,omitempty"`
+
+	// RegionName installs the regional load generator on its own mci near the coordinates.
+	// The default load generator is installed when it is empty.
+	RegionName string `json:"regionName,omitempty"`
+
+	// Engine is the engine which the load generator is installed for.
+	// JMeter is not installed on the local load generator for the engines which run in process.
+	Engine constant.LoadGeneratorType `json:"-"`
+
+	LoadGeneratorVmParam
+}
+
+// LoadGeneratorVmParam selects the vm of the remote load generator.
+// The vm is recommended by the providers, vcpu and memory range and the priority unless the spec is given.
+// The zero values are replaced with the defaults, which are the aws vm with 2-8 vcpu and 4-8 GiB memory near the coordinates.
+type LoadGeneratorVmParam struct {
+	Providers    []string            `json:"providers,omitempty"`
+	MinVCpu      int                 `json:"minVCpu,omitempty"`
+	MaxVCpu      int                 `json:"maxVCpu,omitempty"`
+	MinMemoryGiB int                 `json:"minMemoryGiB,omitempty"`
+	MaxMemoryGiB int                 `json:"maxMemoryGiB,omitempty"`
+	Priority     constant.VmPriority `json:"priority,omitempty"`
+
+	// Spec is the common spec such as aws+ap-northeast-2+t3.xlarge which is used without the recommendation.
+	Spec string `json:"spec,omitempty"`
+	// Image is the common image such as aws+ap-northeast-2+ubuntu22.04, or the os such as ubuntu24.04 in the region of the spec.
+	Image string `json:"image,omitempty"`
+	// RootDiskSize is the root disk size in GB, the default of the csp when it is 0.
+	RootDiskSize int `json:"rootDiskSize,omitempty"`
+}
+
+// RegisterLoadGeneratorParam is the existing host which is registered as the load generator over ssh.
+// PrivateKeyName is the name of the private key file in ~/.ssh of the server, which the host accepts for the user.
+type RegisterLoadGeneratorParam struct {
+	PublicIp       string `json:"publicIp"`
+	PrivateIp      string `json:"privateIp,omitempty"`
+	SshPort        string `json:"sshPort,omitempty"`
+	Username       string `json:"username"`
+	PrivateKeyName string `json:"privateKeyName"`
+	Label          string `json:"label,omitempty"`
 }
 
 type LoadGeneratorServerResult struct {
@@ -63,6 +104,8 @@ type LoadGeneratorServerResult struct {
 	StartTime       string    `json:"startTime,omitempty"`
 	AdditionalVmKey string    `json:"additionalVmKey,omitempty"`
 	Label           string    `json:"label,omitempty"`
+	IsCluster       bool      `json:"isCluster,omitempty"`
+	IsMaster        bool      `json:"isMaster,omitempty"`
 	CreatedAt       time.Time `json:"createdAt,omitempty"`
 	UpdatedAt       time.Time `json:"updatedAt,omitempty"`
 }
@@ -70,18 +113,60 @@ type LoadGeneratorServerResult struct {
 type LoadGeneratorInstallInfoResult struct {
 	ID              uint                     `json:"id,omitempty"`
 	InstallLocation constant.InstallLocation `json:"installLocation,omitempty"`
-	InstallType     string                   `json:"installType,omitempty"`
+	InstallType     string                   `json:"installType,omitempty"` // engines installed on the load generator such as jmeter,k6
 	InstallPath     string                   `json:"installPath,omitempty"`
 	InstallVersion  string                   `json:"installVersion,omitempty"`
 	Status          string                   `json:"status,omitempty"`
+	RegionName      string                   `json:"regionName,omitempty"`
+	LifecycleStatus string                   `json:"lifecycleStatus,omitempty"`
+	LastUsedAt      *time.Time               `json:"lastUsedAt,omitempty"`
+	HealthMessage   string                   `json:"healthMessage,omitempty"`
+	HealthCheckedAt *time.Time               `json:"healthCheckedAt,omitempty"`
 	CreatedAt       time.Time                `json:"createdAt,omitempty"`
 	UpdatedAt       time.Time                `json:"updatedAt,omitempty"`
 
+	IsCluster   bool   `json:"isCluster,omitempty"`
+	MasterId    uint   `json:"masterId,omitempty"`
+	ClusterSize uint64 `json:"clusterSize,omitempty"`
+
 	PublicKeyName        string                      `json:"publicKeyName,omitempty"`
 	PrivateKeyName       string                      `json:"privateKeyName,omitempty"`
 	LoadGeneratorServers []LoadGeneratorServerResult `json:"loadGeneratorServers,omitempty"`
 }
 
+// LoadGeneratorHealthResult is the result of the health check of the load generator.
+type LoadGeneratorHealthResult struct {
+	LoadGeneratorInstallInfoId uint                              `json:"loadGeneratorInstallInfoId"`
+	Healthy                    bool                              `json:"healthy"`
+	Reinstalled                bool                              `json:"reinstalled,omitempty"`
+	Problems                   []string                          `json:"problems,omitempty"`
+	CheckedAt                  time.Time                         `json:"checkedAt"`
+	Servers                    []LoadGeneratorServerHealthResult `json:"servers,omitempty"`
+}
+
+type LoadGeneratorServerHealthResult struct {
+	ID             uint     `json:"id"`
+	VmId           string   `json:"vmId,omitempty"`
+	PublicIp       string   `json:"publicIp,omitempty"`
+	Status         string   `json:"status,omitempty"`
+	Reachable      bool     `json:"reachable"`
+	JavaVersion    string   `json:"javaVersion,omitempty"`
+	JmeterVersion  string   `json:"jmeterVersion,omitempty"`
+	FreeDiskMb     int      `json:"freeDiskMb"`
+	MissingPlugins []string `json:"missingPlugins,omitempty"`
+	Problems       []string `json:"problems,omitempty"`
+}
+
+// LoadGeneratorKeyResult is the new key of the load generator after the rotation.
+type LoadGeneratorKeyResult struct {
+	LoadGeneratorInstallInfoId uint      `json:"loadGeneratorInstallInfoId"`
+	KeyType                    string    `json:"keyType"`
+	Fingerprint                string    `json:"fingerprint"`
+	PublicKey                  string    `json:"publicKey"`
+	RevokedFingerprints        []string  `json:"revokedFingerprints,omitempty"`
+	CreatedAt                  time.Time `json:"createdAt"`
+}
+
 type UninstallLoadGeneratorParam struct {
 	LoadGeneratorInstallInfoId uint
 }
@@ -111,7 +196,45 @@ type RunLoadTestParam struct {
 	AgentInstalled             bool                      `json:"agentInstalled"`
 	AgentHostname              string                    `json:"agentHostname"`
 
+	// TestPlanId runs the stored test plan of the library instead of the plan generated from the http requests.
+	TestPlanId uint `json:"testPlanId,omitempty"`
+
+	// Engine is the load generator engine which runs the load test. (jmeter is used by default)
+	Engine constant.LoadGeneratorType `json:"engine,omitempty"`
+
+	// LoadProfile shapes the load with the stages instead of the plateau of virtual users, ramp up and duration.
+	LoadProfile *LoadProfileParam `json:"loadProfile,omitempty"`
+
 	HttpReqs []RunLoadTestHttpParam `json:"httpReqs,omitempty"`
+	SloRules []SloRuleParam         `json:"sloRules,omitempty"`
+}
+
+// LoadProfileParam is the multi stage shape of the load.
+// The closed model shapes the concurrent virtual users, and the open model shapes the arrivals,
+// the iterations of the scenario started per second regardless of the response times.
+type LoadProfileParam struct {
+	Model string `json:"model"`
+
+	// MaxVirtualUsers limits the concurrent virtual users of the open model. (0 means unlimited)
+	MaxVirtualUsers int `json:"maxVirtualUsers,omitempty"`
+
+	Stages []LoadStageParam `json:"stages"`
+}
+
+// LoadStageParam changes the load linearly from the target of the previous stage to its target for the duration in seconds.
+// The load starts from 0, and a stage of 0 duration changes the load at once.
+type LoadStageParam struct {
+	Duration int `json:"duration"`
+	Target   int `json:"target"`
+}
+
+// SloRuleParam is an assertion such as `p95 < 300` for a label of the load test result.
+// Every label is checked when the label is empty. Time based metrics are in milliseconds.
+type SloRuleParam struct {
+	Label     string  `json:"label,omitempty"`
+	Metric    string  `json:"metric"`
+	Operator  string  `json:"operator"`
+	Threshold float64 `json:"threshold"`
 }
 
 type RunLoadTestHttpParam struct {
@@ -121,6 +244,52 @@ type RunLoadTestHttpParam struct {
 	Port     string `json:"port"`
 	Path     string `json:"path,omitempty"`
 	BodyData string `json:"bodyData,omitempty"`
+
+	Headers         []RunLoadTestHttpHeaderParam `json:"headers,omitempty"`
+	Cookies         []RunLoadTestHttpCookieParam `json:"cookies,omitempty"`
+	ContentType     string                       `json:"contentType,omitempty"`
+	Auth            *RunLoadTestHttpAuthParam    `json:"auth,omitempty"`
+	ConnectTimeout  int                          `json:"connectTimeout,omitempty"`  // milliseconds
+	ResponseTimeout int                          `json:"responseTimeout,omitempty"` // milliseconds
+	RedirectPolicy  string                       `json:"redirectPolicy,omitempty"`  // follow, auto or none
+
+	// http requests are run in order by every virtual user, so they are the steps of a scenario.
+	// variables extracted from a step can be referenced as ${name} in the following steps.
+	Name        string                      `json:"name,omitempty"`        // label of the step
+	Transaction string                      `json:"transaction,omitempty"` // consecutive steps with the same transaction are grouped under one label
+	ThinkTime   int                         `json:"thinkTime,omitempty"`   // milliseconds to pause after the step
+	Extractors  []RunLoadTestExtractorParam `json:"extractors,omitempty"`
+}
+
+// RunLoadTestExtractorParam extracts a variable from the response of the step.
+// Type is jsonpath or regex. Template and Field are only used for regex.
+type RunLoadTestExtractorParam struct {
+	Type         string `json:"type"`
+	Variable     string `json:"variable"`
+	Expression   string `json:"expression"`
+	MatchNumber  int    `json:"matchNumber,omitempty"` // nth match from 1, 0 is taken as the first match
+	DefaultValue string `json:"defaultValue,omitempty"`
+	Template     string `json:"template,omitempty"` // default $1$
+	Field        string `json:"field,omitempty"`    // body or headers, default body
+}
+
+type RunLoadTestHttpHeaderParam struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type RunLoadTestHttpCookieParam struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// RunLoadTestHttpAuthParam is the authentication of the http request.
+// Username and Password are used for basic type and Token is used for bearer type.
+type RunLoadTestHttpAuthParam struct {
+	Type     string `json:"type"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Token    string `json:"token,omitempty"`
 }
 
 type GetAllLoadTestExecutionStateParam struct {
@@ -144,13 +313,38 @@ type LoadTestExecutionStateResult struct {
 	StartAt                     time.Time                      `json:"startAt,omitempty"`
 	FinishAt                    *time.Time                     `json:"finishAt,omitempty"`
 	TotalExpectedExcutionSecond uint64                         `json:"totalExpectedExecutionSecond,omitempty"`
+	QueuePosition               int                            `json:"queuePosition,omitempty"`
+	GeneratorSaturated          bool                           `json:"generatorSaturated"`
+	GeneratorSaturationMessage  string                         `json:"generatorSaturationMessage,omitempty"`
 	FailureMessage              string                         `json:"failureMessage,omitempty"`
 	CompileDuration             string                         `json:"compileDuration,omitempty"`
 	ExecutionDuration           string                         `json:"executionDuration,omitempty"`
+	Verdict                     constant.Verdict               `json:"verdict,omitempty"`
+	SloResults                  []LoadTestSloResultResult      `json:"sloResults,omitempty"`
+	FileTransfers               []LoadTestFileTransferResult   `json:"fileTransfers,omitempty"`
 	CreatedAt                   time.Time                      `json:"createdAt,omitempty"`
 	UpdatedAt                   time.Time                      `json:"updatedAt,omitempty"`
 }
 
+type LoadTestSloResultResult struct {
+	Label     string  `json:"label"`
+	Metric    string  `json:"metric"`
+	Operator  string  `json:"operator"`
+	Threshold float64 `json:"threshold"`
+	Actual    float64 `json:"actual"`
+	Passed    bool    `json:"passed"`
+	Message   string  `json:"message,omitempty"`
+}
+
+type LoadTestFileTransferResult struct {
+	Host          string     `json:"host"`
+	FileName      string     `json:"fileName"`
+	Bytes         int64      `json:"bytes"`
+	Status        string     `json:"status"`
+	Message       string     `json:"message,omitempty"`
+	TransferredAt *time.Time `json:"transferredAt,omitempty"`
+}
+
 type GetLoadTestExecutionStateParam struct {
 	LoadTestKey string `json:"loadTestKey"`
 }
@@ -179,19 +373,34 @@ type LoadTestExecutionInfoResult struct {
 	AgentInstalled             bool                              `json:"agentInstalled,omitempty"`
 	CompileDuration            string                            `json:"compileDuration,omitempty"`
 	ExecutionDuration          string                            `json:"executionDuration,omitempty"`
+	TestPlanId                 uint                              `json:"testPlanId,omitempty"`
+	Engine                     constant.LoadGeneratorType        `json:"engine,omitempty"`
+	LoadProfile                *LoadProfileParam                 `json:"loadProfile,omitempty"`
+	SloRules                   []SloRuleParam                    `json:"sloRules,omitempty"`
 	LoadTestExecutionHttpInfos []LoadTestExecutionHttpInfoResult `json:"loadTestExecutionHttpInfos,omitempty"`
 	LoadTestExecutionState     LoadTestExecutionStateResult      `json:"loadTestExecutionState,omitempty"`
 	LoadGeneratorInstallInfo   LoadGeneratorInstallInfoResult    `json:"loadGeneratorInstallInfo,omitempty"`
 }
 
 type LoadTestExecutionHttpInfoResult struct {
-	ID       uint   `json:"id"`
-	Method   string `json:"method,omitempty"`
-	Protocol string `json:"protocol,omitempty"`
-	Hostname string `json:"hostname,omitempty"`
-	Port     string `json:"port,omitempty"`
-	Path     string `json:"path,omitempty"`
-	BodyData string `json:"bodyData,omitempty"`
+	ID              uint                         `json:"id"`
+	Method          string                       `json:"method,omitempty"`
+	Protocol        string                       `json:"protocol,omitempty"`
+	Hostname        string                       `json:"hostname,omitempty"`
+	Port            string                       `json:"port,omitempty"`
+	Path            string                       `json:"path,omitempty"`
+	BodyData        string                       `json:"bodyData,omitempty"`
+	Headers         []RunLoadTestHttpHeaderParam `json:"headers,omitempty"`
+	Cookies         []RunLoadTestHttpCookieParam `json:"cookies,omitempty"`
+	ContentType     string                       `json:"contentType,omitempty"`
+	AuthType        string                       `json:"authType,omitempty"`
+	ConnectTimeout  int                          `json:"connectTimeout,omitempty"`
+	ResponseTimeout int                          `json:"responseTimeout,omitempty"`
+	RedirectPolicy  string                       `json:"redirectPolicy,omitempty"`
+	Name            string                       `json:"name,omitempty"`
+	Transaction     string                       `json:"transaction,omitempty"`
+	ThinkTime       int                          `json:"thinkTime,omitempty"`
+	Extractors      []RunLoadTestExtractorParam  `json:"extractors,omitempty"`
 }
 
 type GetLoadTestExecutionInfoParam struct {
@@ -251,4 +460,308 @@ type LoadTestStatistics struct {
 type GetLoadTestResultParam struct {
 	LoadTestKey string
 	Format      constant.ResultFormat
+	Interval    time.Duration
+}
+
+type ResultTimeSeries struct {
+	Label    string              `json:"label"`
+	Interval string              `json:"interval"`
+	Buckets  []*TimeSeriesBucket `json:"buckets"`
+}
+
+// TimeSeriesBucket is the statistics of the samples which started within the bucket.
+// Latencies are in milliseconds and ActiveTime is the milliseconds of the bucket in which any sample was running.
+type TimeSeriesBucket struct {
+	Timestamp    time.Time `json:"timestamp"`
+	RequestCount int       `json:"requestCount"`
+	ErrorCount   int       `json:"errorCount"`
+	Average      float64   `json:"average"`
+	NinetyFive   float64   `json:"ninetyFive"`
+	Throughput   float64   `json:"throughput"`
+	ActiveTime   int64     `json:"activeTime"`
+}
+
+// CompareThresholds decides when the difference between two load test results is a regression.
+// LatencyThreshold and ThroughputThreshold are percentages of the base value
+// and ErrorPercentThreshold is the allowed increase of error percent in percentage points.
+type CompareThresholds struct {
+	LatencyThreshold      float64 `json:"latencyThreshold"`
+	ErrorPercentThreshold float64 `json:"errorPercentThreshold"`
+	ThroughputThreshold   float64 `json:"throughputThreshold"`
+}
+
+type CompareLoadTestResultParam struct {
+	BaseKey    string
+	TargetKey  string
+	Thresholds CompareThresholds
+}
+
+type MetricDelta struct {
+	Metric       string  `json:"metric"`
+	Base         float64 `json:"base"`
+	Target       float64 `json:"target"`
+	Delta        float64 `json:"delta"`
+	DeltaPercent float64 `json:"deltaPercent"`
+	FromZero     bool    `json:"fromZero,omitempty"` // the base is 0, so the delta percent is infinite and left 0
+	Regressed    bool    `json:"regressed"`
+}
+
+type LabelComparison struct {
+	Label     string              `json:"label"`
+	Base      *LoadTestStatistics `json:"base,omitempty"`
+	Target    *LoadTestStatistics `json:"target,omitempty"`
+	Deltas    []MetricDelta       `json:"deltas,omitempty"`
+	Change    string              `json:"change,omitempty"` // added or removed when the label is only in one of the results
+	Regressed bool                `json:"regressed"`
+}
+
+type CompareLoadTestResult struct {
+	BaseKey     string            `json:"baseKey"`
+	TargetKey   string            `json:"targetKey"`
+	Thresholds  CompareThresholds `json:"thresholds"`
+	Comparisons []LabelComparison `json:"comparisons"`
+	Regressed   bool              `json:"regressed"`
+}
+
+// LoadTestProgress is the snapshot of a running load test computed from the partially fetched results.
+type LoadTestProgress struct {
+	LoadTestKey     string                       `json:"loadTestKey"`
+	ExecutionStatus constant.ExecutionStatus     `json:"executionStatus"`
+	Finished        bool                         `json:"finished"`
+	Window          string                       `json:"window"`
+	Statistics      []LoadTestProgressStatistics `json:"statistics"`
+	Metrics         []LoadTestProgressMetric     `json:"metrics,omitempty"`
+	UpdatedAt       time.Time                    `json:"updatedAt"`
+}
+
+// LoadTestProgressStatistics holds the totals of a label and the rolling statistics within the window
+// which ends at the last fetched sample.
+type LoadTestProgressStatistics struct {
+	Label             string    `json:"label"`
+	TotalRequestCount int       `json:"totalRequestCount"`
+	TotalErrorCount   int       `json:"totalErrorCount"`
+	RequestCount      int       `json:"requestCount"`
+	Rps               float64   `json:"rps"`
+	ErrorPercent      float64   `json:"errorPercent"`
+	Average           float64   `json:"average"`
+	Median            float64   `json:"median"`
+	NinetyPercent     float64   `json:"ninetyPercent"`
+	NinetyFive        float64   `json:"ninetyFive"`
+	NinetyNine        float64   `json:"ninetyNine"`
+	LastSampleAt      time.Time `json:"lastSampleAt"`
+}
+
+type LoadTestProgressMetric struct {
+	Label     string    `json:"label"`
+	Value     string    `json:"value"`
+	Unit      string    `json:"unit"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type CreateLoadTestScheduleParam struct {
+	Name             string           `json:"name"`
+	CronExpression   string           `json:"cronExpression"`
+	Enabled          bool             `json:"enabled"`
+	RunLoadTestParam RunLoadTestParam `json:"runLoadTestParam"`
+}
+
+type UpdateLoadTestScheduleParam struct {
+	ID               uint             `json:"id"`
+	Name             string           `json:"name"`
+	CronExpression   string           `json:"cronExpression"`
+	Enabled          bool             `json:"enabled"`
+	RunLoadTestParam RunLoadTestParam `json:"runLoadTestParam"`
+}
+
+type GetAllLoadTestSchedulesParam struct {
+	Page int `json:"page"`
+	Size int `json:"size"`
+}
+
+type GetAllLoadTestSchedulesResult struct {
+	LoadTestSchedules []LoadTestScheduleResult `json:"loadTestSchedules,omitempty"`
+	TotalRow          int64                    `json:"totalRow,omitempty"`
+}
+
+type LoadTestScheduleResult struct {
+	ID                         uint             `json:"id"`
+	Name                       string           `json:"name,omitempty"`
+	CronExpression             string           `json:"cronExpression,omitempty"`
+	Enabled                    bool             `json:"enabled"`
+	LoadGeneratorInstallInfoId uint             `json:"loadGeneratorInstallInfoId,omitempty"`
+	RunLoadTestParam           RunLoadTestParam `json:"runLoadTestParam"`
+	NextRunAt                  *time.Time       `json:"nextRunAt,omitempty"`
+	LastRunAt                  *time.Time       `json:"lastRunAt,omitempty"`
+	LastLoadTestKey            string           `json:"lastLoadTestKey,omitempty"`
+	LastRunMessage             string           `json:"lastRunMessage,omitempty"`
+	CreatedAt                  time.Time        `json:"createdAt,omitempty"`
+	UpdatedAt                  time.Time        `json:"updatedAt,omitempty"`
+}
+
+type RunCapacitySearchParam struct {
+	RunLoadTestParam RunLoadTestParam `json:"runLoadTestParam"`
+
+	// the load of each step is the virtual users of the closed model or the requests per second of the open model.
+	LoadModel       string  `json:"loadModel"`
+	StartLoad       int     `json:"startLoad"`
+	StepLoad        int     `json:"stepLoad"`
+	MaxLoad         int     `json:"maxLoad"`
+	StepDuration    int     `json:"stepDuration"`
+	MaxVirtualUsers int     `json:"maxVirtualUsers,omitempty"`
+	MaxErrorPercent float64 `json:"maxErrorPercent"`
+	MaxNinetyFive   float64 `json:"maxNinetyFive,omitempty"`
+}
+
+type GetAllCapacitySearchesParam struct {
+	Page int `json:"page"`
+	Size int `json:"size"`
+}
+
+type GetAllCapacitySearchesResult struct {
+	CapacitySearches []CapacitySearchResult `json:"capacitySearches,omitempty"`
+	TotalRow         int64                  `json:"totalRow,omitempty"`
+}
+
+type CapacitySearchResult struct {
+	ID                       uint                       `json:"id"`
+	TestName                 string                     `json:"testName,omitempty"`
+	Status                   constant.ExecutionStatus   `json:"status"`
+	RunCapacitySearchParam   RunCapacitySearchParam     `json:"runCapacitySearchParam"`
+	MaxSustainableLoad       int                        `json:"maxSustainableLoad"`
+	MaxSustainableThroughput float64                    `json:"maxSustainableThroughput"`
+	BreakingStep             int                        `json:"breakingStep,omitempty"`
+	BreakingLoad             int                        `json:"breakingLoad,omitempty"`
+	BreakingReason           string                     `json:"breakingReason,omitempty"`
+	FailureMessage           string                     `json:"failureMessage,omitempty"`
+	Steps                    []CapacitySearchStepResult `json:"steps,omitempty"`
+	StartAt                  time.Time                  `json:"startAt"`
+	FinishAt                 *time.Time                 `json:"finishAt,omitempty"`
+}
+
+type CapacitySearchStepResult struct {
+	Step         int     `json:"step"`
+	Load         int     `json:"load"`
+	LoadTestKey  string  `json:"loadTestKey"`
+	RequestCount int     `json:"requestCount"`
+	Throughput   float64 `json:"throughput"`
+	ErrorPercent float64 `json:"errorPercent"`
+	NinetyFive   float64 `json:"ninetyFive"`
+	Passed       bool    `json:"passed"`
+	Message      string  `json:"message,omitempty"`
+}
+
+// RunMultiRegionLoadTestParam runs the load test from the load generators in several regions at once.
+// The virtual users or the stage targets of the load test are divided among the regions by their weights.
+type RunMultiRegionLoadTestParam struct {
+	RunLoadTestParam RunLoadTestParam      `json:"runLoadTestParam"`
+	Regions          []LoadTestRegionParam `json:"regions"`
+}
+
+// LoadTestRegionParam is the regional load generator installed near the coordinates such as 37.53/127.02.
+// The weight is 1 when it is not given.
+type LoadTestRegionParam struct {
+	RegionName  string   `json:"regionName"`
+	Coordinates []string `json:"coordinates"`
+	Weight      int      `json:"weight,omitempty"`
+}
+
+type GetAllMultiRegionLoadTestsParam struct {
+	Page int `json:"page"`
+	Size int `json:"size"`
+}
+
+type GetAllMultiRegionLoadTestsResult struct {
+	MultiRegionLoadTests []MultiRegionLoadTestResult `json:"multiRegionLoadTests,omitempty"`
+	TotalRow             int64                       `json:"totalRow,omitempty"`
+}
+
+type MultiRegionLoadTestResult struct {
+	ID                          uint                              `json:"id"`
+	TestName                    string                            `json:"testName,omitempty"`
+	Status                      constant.ExecutionStatus          `json:"status"`
+	RunMultiRegionLoadTestParam RunMultiRegionLoadTestParam       `json:"runMultiRegionLoadTestParam"`
+	FailureMessage              string                            `json:"failureMessage,omitempty"`
+	Regions                     []MultiRegionLoadTestRegionResult `json:"regions,omitempty"`
+	StartAt                     time.Time                         `json:"startAt"`
+	FinishAt                    *time.Time                        `json:"finishAt,omitempty"`
+}
+
+type MultiRegionLoadTestRegionResult struct {
+	RegionName                 string                   `json:"regionName"`
+	Coordinates                []string                 `json:"coordinates"`
+	Weight                     int                      `json:"weight"`
+	LoadTestKey                string                   `json:"loadTestKey,omitempty"`
+	LoadGeneratorInstallInfoId uint                     `json:"loadGeneratorInstallInfoId,omitempty"`
+	ExecutionStatus            constant.ExecutionStatus `json:"executionStatus,omitempty"`
+	FailureMessage             string                   `json:"failureMessage,omitempty"`
+
+	// the location of the load generator which is provisioned for the region.
+	Csp    string `json:"csp,omitempty"`
+	Region string `json:"region,omitempty"`
+	Lat    string `json:"lat,omitempty"`
+	Lon    string `json:"lon,omitempty"`
+}
+
+// RegionLoadTestStatistics is the statistics of a label measured from the load generator of the region.
+type RegionLoadTestStatistics struct {
+	RegionName  string `json:"regionName"`
+	LoadTestKey string `json:"loadTestKey"`
+	LoadTestStatistics
+}
+
+type LoadGeneratorQueueResult struct {
+	LoadGeneratorInstallInfoId uint                   `json:"loadGeneratorInstallInfoId"`
+	RunningCount               int                    `json:"runningCount"`
+	QueuedLoadTests            []QueuedLoadTestResult `json:"queuedLoadTests,omitempty"`
+}
+
+type QueuedLoadTestResult struct {
+	LoadTestKey   string    `json:"loadTestKey"`
+	TestName      string    `json:"testName,omitempty"`
+	QueuePosition int       `json:"queuePosition"`
+	QueuedAt      time.Time `json:"queuedAt"`
+}
+
+type MoveQueuedLoadTestParam struct {
+	LoadTestKey   string `json:"loadTestKey"`
+	QueuePosition int    `json:"queuePosition"`
+}
+
+type UploadLoadTestPlanParam struct {
+	Name        string
+	Description string
+	FileName    string
+	Content     []byte
+	DataFiles   []LoadTestPlanDataFileParam
+}
+
+type LoadTestPlanDataFileParam struct {
+	FileName string
+	Content  []byte
+}
+
+type GetAllLoadTestPlansParam struct {
+	Page int `json:"page"`
+	Size int `json:"size"`
+}
+
+type GetAllLoadTestPlansResult struct {
+	LoadTestPlans []LoadTestPlanResult `json:"loadTestPlans,omitempty"`
+	TotalRow      int64                `json:"totalRow,omitempty"`
+}
+
+type LoadTestPlanResult struct {
+	ID          uint                         `json:"id"`
+	Name        string                       `json:"name,omitempty"`
+	Description string                       `json:"description,omitempty"`
+	FileName    string                       `json:"fileName,omitempty"`
+	DataFiles   []LoadTestPlanDataFileResult `json:"dataFiles,omitempty"`
+	CreatedAt   time.Time                    `json:"createdAt,omitempty"`
+	UpdatedAt   time.Time                    `json:"updatedAt,omitempty"`
+}
+
+type LoadTestPlanDataFileResult struct {
+	ID       uint   `json:"id"`
+	FileName string `json:"fileName,omitempty"`
+	Size     int    `json:"size"`
 }
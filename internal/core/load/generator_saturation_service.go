@@ -0,0 +1,241 @@
+package load
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloud-barista/cm-ant/internal/config"
+	"github.com/cloud-barista/cm-ant/internal/utils"
+)
+
+const (
+	generatorSampleInterval = 10 * time.Second
+	// the load generator is saturated when a metric stays over its threshold for this many samples in a row,
+	// so the short spike such as the start of the engine is not flagged.
+	saturationSustainedSamples = 3
+
+	defaultSaturationCpuPercent    = 90
+	defaultSaturationMemoryPercent = 90
+
+	generatorCpuLabel       = "load_generator_cpu"
+	generatorMemoryLabel    = "load_generator_memory"
+	generatorNetworkRxLabel = "load_generator_network_rx"
+	generatorNetworkTxLabel = "load_generator_network_tx"
+)
+
+var generatorMetricLabels = []string{generatorCpuLabel, generatorMemoryLabel, generatorNetworkRxLabel, generatorNetworkTxLabel}
+
+var generatorMetricUnits = map[string]string{
+	generatorCpuLabel:       "%",
+	generatorMemoryLabel:    "%",
+	generatorNetworkRxLabel: "Mbps",
+	generatorNetworkTxLabel: "Mbps",
+}
+
+// generatorSampleCmd prints the cpu and memory usage percent and the received and sent Mbps of the vm measured for a second.
+// The marker is split in the command, so the echo of the command is not parsed as a sample.
+const generatorSampleCmd = `ant_cpu() { awk '/^cpu /{print $2+$3+$4+$5+$6+$7+$8+$9, $5+$6}' /proc/stat; }; ` +
+	`ant_net() { sed 's/:/ /' /proc/net/dev | awk 'NR>2 && $1!="lo" {r+=$2; t+=$10} END {print r+0, t+0}'; }; ` +
+	`a=$(ant_cpu; ant_net); sleep 1; b=$(ant_cpu; ant_net); ` +
+	`m=$(awk '/^MemTotal:/ {t=$2} /^MemAvailable:/ {a=$2} END {print (t>0 ? 100*(t-a)/t : 0)}' /proc/meminfo); ` +
+	`echo $a $b $m | awk '{dt=$5-$1; printf "%s_%s %.2f %.2f %.3f %.3f\n", "generator", "metrics", (dt>0 ? 100*(1-($6-$2)/dt) : 0), $9, ($7-$3)*8/1000000, ($8-$4)*8/1000000}'`
+
+var generatorSampleRegex = regexp.MustCompile(`generator_metrics ([0-9.]+) ([0-9.]+) ([0-9.]+) ([0-9.]+)`)
+
+// parseGeneratorSample reads the samples of every vm from the output and keeps the highest value of each metric,
+// because the busiest vm limits the load of the cluster.
+func parseGeneratorSample(out string) (map[string]float64, bool) {
+	matches := generatorSampleRegex.FindAllStringSubmatch(out, -1)
+	if len(matches) == 0 {
+		return nil, false
+	}
+
+	sample := make(map[string]float64)
+	for _, m := range matches {
+		for i, label := range generatorMetricLabels {
+			v, err := strconv.ParseFloat(m[i+1], 64)
+			if err != nil {
+				continue
+			}
+			sample[label] = max(sample[label], v)
+		}
+	}
+
+	return sample, true
+}
+
+type generatorSaturation struct {
+	saturated bool
+	message   string
+}
+
+// saturationDetector flags the metrics of the load generator which stay over their thresholds.
+type saturationDetector struct {
+	thresholds map[string]float64
+	over       map[string]int
+	peaks      map[string]float64
+	saturated  map[string]bool
+}
+
+func newSaturationDetector() *saturationDetector {
+	c := config.AppConfig.Load.Saturation
+
+	cpu, memory := c.CpuPercent, c.MemoryPercent
+	if cpu <= 0 {
+		cpu = defaultSaturationCpuPercent
+	}
+	if memory <= 0 {
+		memory = defaultSaturationMemoryPercent
+	}
+
+	thresholds := map[string]float64{
+		generatorCpuLabel:    cpu,
+		generatorMemoryLabel: memory,
+	}
+
+	// the network is checked only when the bandwidth of the load generator is configured
+	if c.NetworkMbps > 0 {
+		thresholds[generatorNetworkRxLabel] = c.NetworkMbps
+		thresholds[generatorNetworkTxLabel] = c.NetworkMbps
+	}
+
+	return &saturationDetector{
+		thresholds: thresholds,
+		over:       make(map[string]int),
+		peaks:      make(map[string]float64),
+		saturated:  make(map[string]bool),
+	}
+}
+
+func (d *saturationDetector) observe(sample map[string]float64) {
+	for label, threshold := range d.thresholds {
+		v, ok := sample[label]
+		if !ok {
+			continue
+		}
+
+		d.peaks[label] = max(d.peaks[label], v)
+
+		if v < threshold {
+			d.over[label] = 0
+			continue
+		}
+
+		d.over[label]++
+		if d.over[label] >= saturationSustainedSamples {
+			d.saturated[label] = true
+		}
+	}
+}
+
+// result returns whether the load generator is saturated with the message of the saturated metrics.
+func (d *saturationDetector) result() (bool, string) {
+	var messages []string
+	for _, label := range generatorMetricLabels {
+		if !d.saturated[label] {
+			continue
+		}
+
+		unit := generatorMetricUnits[label]
+		messages = append(messages, fmt.Sprintf("%s stayed over %g%s (peak %.2f%s)", label, d.thresholds[label], unit, d.peaks[label], unit))
+	}
+
+	if len(messages) == 0 {
+		return false, ""
+	}
+
+	return true, "load generator is saturated; " + strings.Join(messages, ", ")
+}
+
+// sampleLoadGenerator samples the resource usage of the load generator while the engine runs until done is closed.
+// The samples are stored as the metrics of the load test and the saturation of the load generator is returned.
+func (l *LoadService) sampleLoadGenerator(run *loadTestRun, installInfo *LoadGeneratorInstallInfo, done <-chan struct{}) (bool, string) {
+	detector := newSaturationDetector()
+
+	ticker := time.NewTicker(generatorSampleInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return detector.result()
+		case <-ticker.C:
+		}
+
+		// the preparation of the load generator such as the engine installation is not the load of the test
+		if !run.isStarted() {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		out, err := l.loadGeneratorCommandOutput(ctx, installInfo, generatorSampleCmd, false)
+		cancel()
+
+		if err != nil {
+			utils.LogWarnf("Error sampling load generator of load test %s: %v", run.loadTestKey, err)
+			continue
+		}
+
+		sample, ok := parseGeneratorSample(out)
+		if !ok {
+			continue
+		}
+
+		detector.observe(sample)
+
+		now := time.Now()
+		var metrics []LoadGeneratorMetricsRawData
+		for _, label := range generatorMetricLabels {
+			metrics = append(metrics, LoadGeneratorMetricsRawData{
+				LoadTestKey: run.loadTestKey,
+				Label:       label,
+				Value:       sample[label],
+				Unit:        generatorMetricUnits[label],
+				Timestamp:   now,
+			})
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+		err = l.loadRepo.InsertLoadGeneratorMetricsRawDataTx(ctx, metrics)
+		cancel()
+
+		if err != nil {
+			utils.LogErrorf("Error saving load generator metrics of load test %s: %v", run.loadTestKey, err)
+		}
+	}
+}
+
+// loadGeneratorMetricsSummaries returns the sampled metrics of the load generator of the load test by label.
+func (l *LoadService) loadGeneratorMetricsSummaries(loadTestKey string) ([]MetricsSummary, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	rows, err := l.loadRepo.GetLoadGeneratorMetricsRawDataTx(ctx, loadTestKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var metricsSummaries []MetricsSummary
+	indexes := make(map[string]int)
+
+	for _, m := range rows {
+		i, ok := indexes[m.Label]
+		if !ok {
+			i = len(metricsSummaries)
+			indexes[m.Label] = i
+			metricsSummaries = append(metricsSummaries, MetricsSummary{Label: m.Label})
+		}
+
+		metricsSummaries[i].Metrics = append(metricsSummaries[i].Metrics, &MetricsRawData{
+			Value:     strconv.FormatFloat(m.Value, 'f', 3, 64),
+			Unit:      m.Unit,
+			Timestamp: m.Timestamp,
+		})
+	}
+
+	return metricsSummaries, nil
+}
@@ -0,0 +1,48 @@
+package load
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGeneratorSample(t *testing.T) {
+	_, ok := parseGeneratorSample(`{"output":"` + generatorSampleCmd + `"}`)
+	require.False(t, ok)
+
+	// the output of the cluster has the sample of every vm
+	out := `{"results":[{"stdout":{"0":"generator_metrics 35.50 40.00 12.000 3.500\n"}},{"stdout":{"0":"generator_metrics 97.25 20.00 1.000 8.000\n"}}]}`
+	sample, ok := parseGeneratorSample(out)
+	require.True(t, ok)
+	require.Equal(t, 97.25, sample[generatorCpuLabel])
+	require.Equal(t, 40.0, sample[generatorMemoryLabel])
+	require.Equal(t, 12.0, sample[generatorNetworkRxLabel])
+	require.Equal(t, 8.0, sample[generatorNetworkTxLabel])
+}
+
+func TestSaturationDetector(t *testing.T) {
+	d := newSaturationDetector()
+	busy := map[string]float64{generatorCpuLabel: 99, generatorMemoryLabel: 30, generatorNetworkTxLabel: 5000}
+	idle := map[string]float64{generatorCpuLabel: 20, generatorMemoryLabel: 30}
+
+	// the spike shorter than the sustained samples is not saturation
+	for range saturationSustainedSamples - 1 {
+		d.observe(busy)
+	}
+	d.observe(idle)
+
+	saturated, message := d.result()
+	require.False(t, saturated)
+	require.Empty(t, message)
+
+	for range saturationSustainedSamples {
+		d.observe(busy)
+	}
+
+	saturated, message = d.result()
+	require.True(t, saturated)
+	require.Contains(t, message, generatorCpuLabel)
+	require.NotContains(t, message, generatorMemoryLabel)
+	// the network is not checked without the configured bandwidth
+	require.NotContains(t, message, generatorNetworkTxLabel)
+}
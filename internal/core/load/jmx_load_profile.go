@@ -0,0 +1,269 @@
+package load
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+const (
+	loadModelClosed = "closed"
+	loadModelOpen   = "open"
+
+	maxLoadStages = 100
+)
+
+const jmxConcurrencyThreadGroupTemplate = `<com.blazemeter.jmeter.threads.concurrency.ConcurrencyThreadGroup guiclass="com.blazemeter.jmeter.threads.concurrency.ConcurrencyThreadGroupGui" testclass="com.blazemeter.jmeter.threads.concurrency.ConcurrencyThreadGroup" testname="{{.TestName}} Thread Group" enabled="true">
+        <elementProp name="ThreadGroup.main_controller" elementType="com.blazemeter.jmeter.control.VirtualUserController"/>
+        <stringProp name="ThreadGroup.on_sample_error">continue</stringProp>
+        <stringProp name="Hold">{{.Duration}}</stringProp>
+        <stringProp name="Steps">{{.RampUpSteps}}</stringProp>
+        <stringProp name="RampUp">{{.RampUpTime}}</stringProp>
+        <stringProp name="TargetLevel">{{.VirtualUsers}}</stringProp>
+        <stringProp name="Iterations">0</stringProp>
+        <stringProp name="Unit">S</stringProp>
+      </com.blazemeter.jmeter.threads.concurrency.ConcurrencyThreadGroup>`
+
+// the ultimate thread group starts and stops the groups of threads at the given times, which shapes the closed model.
+const jmxUltimateThreadGroupTemplate = `<kg.apc.jmeter.threads.UltimateThreadGroup guiclass="kg.apc.jmeter.threads.UltimateThreadGroupGui" testclass="kg.apc.jmeter.threads.UltimateThreadGroup" testname="{{.TestName}} Thread Group" enabled="true">
+        <collectionProp name="ultimatethreadgroupdata">
+          {{- range $i, $r := .Rows }}
+          <collectionProp name="row{{ $i }}">
+            <stringProp name="threads">{{ $r.Threads }}</stringProp>
+            <stringProp name="delay">{{ $r.Delay }}</stringProp>
+            <stringProp name="startup">{{ $r.Startup }}</stringProp>
+            <stringProp name="hold">{{ $r.Hold }}</stringProp>
+            <stringProp name="shutdown">{{ $r.Shutdown }}</stringProp>
+          </collectionProp>
+          {{- end }}
+        </collectionProp>
+        <elementProp name="ThreadGroup.main_controller" elementType="LoopController" guiclass="LoopControlPanel" testclass="LoopController" testname="Loop Controller" enabled="true">
+          <boolProp name="LoopController.continue_forever">false</boolProp>
+          <intProp name="LoopController.loops">-1</intProp>
+        </elementProp>
+        <stringProp name="ThreadGroup.on_sample_error">continue</stringProp>
+      </kg.apc.jmeter.threads.UltimateThreadGroup>`
+
+// the free-form arrivals thread group starts the iterations at the scheduled rates, which shapes the open model.
+const jmxArrivalsThreadGroupTemplate = `<com.blazemeter.jmeter.threads.arrivals.FreeFormArrivalsThreadGroup guiclass="com.blazemeter.jmeter.threads.arrivals.FreeFormArrivalsThreadGroupGui" testclass="com.blazemeter.jmeter.threads.arrivals.FreeFormArrivalsThreadGroup" testname="{{.TestName}} Thread Group" enabled="true">
+        <collectionProp name="Schedule">
+          {{- range $i, $r := .Rows }}
+          <collectionProp name="row{{ $i }}">
+            <stringProp name="from">{{ $r.From }}</stringProp>
+            <stringProp name="to">{{ $r.To }}</stringProp>
+            <stringProp name="duration">{{ $r.Duration }}</stringProp>
+          </collectionProp>
+          {{- end }}
+        </collectionProp>
+        <elementProp name="ThreadGroup.main_controller" elementType="com.blazemeter.jmeter.control.VirtualUserController"/>
+        <stringProp name="ThreadGroup.on_sample_error">continue</stringProp>
+        <stringProp name="Unit">S</stringProp>
+        <stringProp name="ConcurrencyLimit">{{.ConcurrencyLimit}}</stringProp>
+        <stringProp name="Iterations"></stringProp>
+      </com.blazemeter.jmeter.threads.arrivals.FreeFormArrivalsThreadGroup>`
+
+type jmxThreadGroupTemplateData struct {
+	TestName         string
+	Duration         string
+	RampUpSteps      string
+	RampUpTime       string
+	VirtualUsers     string
+	ConcurrencyLimit string
+	Rows             any
+}
+
+type jmxUltimateThreadGroupRow struct {
+	Threads  int
+	Delay    int
+	Startup  int
+	Hold     int
+	Shutdown int
+}
+
+type jmxArrivalsScheduleRow struct {
+	From     int
+	To       int
+	Duration int
+}
+
+// validateLoadProfile checks the model and the stages of the load profile.
+func validateLoadProfile(p *LoadProfileParam) error {
+	switch strings.ToLower(p.Model) {
+	case loadModelClosed, loadModelOpen:
+	default:
+		return fmt.Errorf("load model %q is not supported", p.Model)
+	}
+
+	if p.MaxVirtualUsers < 0 {
+		return errors.New("max virtual users must not be negative")
+	}
+
+	if len(p.Stages) == 0 || len(p.Stages) > maxLoadStages {
+		return fmt.Errorf("load profile must have 1 to %d stages", maxLoadStages)
+	}
+
+	for i, s := range p.Stages {
+		if s.Duration < 0 || s.Target < 0 {
+			return fmt.Errorf("duration and target of stage %d must not be negative", i+1)
+		}
+	}
+
+	if p.totalDuration() == 0 {
+		return errors.New("total duration of the load profile must be positive")
+	}
+
+	return nil
+}
+
+// totalDuration returns the seconds which the load profile takes.
+func (p *LoadProfileParam) totalDuration() int {
+	total := 0
+	for _, s := range p.Stages {
+		total += s.Duration
+	}
+	return total
+}
+
+func (p *LoadProfileParam) isOpen() bool {
+	return strings.EqualFold(p.Model, loadModelOpen)
+}
+
+// splitAcross returns the load test whose load is divided by the hosts, because every remote host runs
+// the whole thread group in distributed mode. The load of each host is rounded up,
+// so the total load of the hosts is not less than the requested load.
+func (param RunLoadTestParam) splitAcross(hosts int) RunLoadTestParam {
+	if hosts < 2 {
+		return param
+	}
+
+	perHost := func(load int) int {
+		return (load + hosts - 1) / hosts
+	}
+
+	if vu, err := strconv.Atoi(param.VirtualUsers); err == nil {
+		param.VirtualUsers = strconv.Itoa(perHost(vu))
+	}
+
+	if p := param.LoadProfile; p != nil {
+		split := *p
+		split.MaxVirtualUsers = perHost(p.MaxVirtualUsers)
+		split.Stages = make([]LoadStageParam, len(p.Stages))
+		for i, s := range p.Stages {
+			split.Stages[i] = LoadStageParam{Duration: s.Duration, Target: perHost(s.Target)}
+		}
+		param.LoadProfile = &split
+	}
+
+	return param
+}
+
+// threadGroupParseToJmx renders the thread group which shapes the load of the test plan.
+// The concurrency thread group of the plateau is used unless the load profile is given.
+func threadGroupParseToJmx(param RunLoadTestParam) (string, error) {
+	data := jmxThreadGroupTemplateData{
+		TestName:     param.TestName,
+		Duration:     param.Duration,
+		RampUpSteps:  param.RampUpSteps,
+		RampUpTime:   param.RampUpTime,
+		VirtualUsers: param.VirtualUsers,
+	}
+
+	threadGroupTemplate := jmxConcurrencyThreadGroupTemplate
+
+	if p := param.LoadProfile; p != nil {
+		if p.isOpen() {
+			threadGroupTemplate = jmxArrivalsThreadGroupTemplate
+			data.Rows = arrivalsScheduleRows(p.Stages)
+			if p.MaxVirtualUsers > 0 {
+				data.ConcurrencyLimit = fmt.Sprintf("%d", p.MaxVirtualUsers)
+			}
+		} else {
+			threadGroupTemplate = jmxUltimateThreadGroupTemplate
+			data.Rows = ultimateThreadGroupRows(p.Stages)
+		}
+	}
+
+	tmpl, err := template.New("jmxThreadGroupTemplate").Parse(threadGroupTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, data)
+	if err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// arrivalsScheduleRows converts the stages into the rows of the arrivals schedule.
+// A stage of 0 duration has no row, the next row starts from its target instead.
+func arrivalsScheduleRows(stages []LoadStageParam) []jmxArrivalsScheduleRow {
+	var rows []jmxArrivalsScheduleRow
+	level := 0
+	for _, s := range stages {
+		if s.Duration > 0 {
+			rows = append(rows, jmxArrivalsScheduleRow{From: level, To: s.Target, Duration: s.Duration})
+		}
+		level = s.Target
+	}
+	return rows
+}
+
+// ultimateThreadGroupRows converts the stages into the groups of threads of the ultimate thread group.
+// A group is started for each increase of the level, and the latest groups are stopped first for each decrease,
+// splitting the group when only a part of it is stopped. The groups left at the end hold until the test ends.
+func ultimateThreadGroupRows(stages []LoadStageParam) []jmxUltimateThreadGroupRow {
+	var active, rows []*jmxUltimateThreadGroupRow
+
+	stop := func(r *jmxUltimateThreadGroupRow, at, shutdown int) {
+		r.Hold = at - r.Delay - r.Startup
+		r.Shutdown = shutdown
+		rows = append(rows, r)
+	}
+
+	level, at := 0, 0
+	for _, s := range stages {
+		if s.Target > level {
+			active = append(active, &jmxUltimateThreadGroupRow{Threads: s.Target - level, Delay: at, Startup: s.Duration})
+		}
+
+		for remove := level - s.Target; remove > 0; {
+			last := active[len(active)-1]
+			if last.Threads > remove {
+				stopped := *last
+				stopped.Threads = remove
+				last.Threads -= remove
+				stop(&stopped, at, s.Duration)
+				break
+			}
+
+			active = active[:len(active)-1]
+			remove -= last.Threads
+			stop(last, at, s.Duration)
+		}
+
+		level = s.Target
+		at += s.Duration
+	}
+
+	for _, r := range active {
+		stop(r, at, 0)
+	}
+
+	result := make([]jmxUltimateThreadGroupRow, 0, len(rows))
+	for _, r := range rows {
+		result = append(result, *r)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Delay < result[j].Delay
+	})
+
+	return result
+}
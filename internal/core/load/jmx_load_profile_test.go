@@ -0,0 +1,107 @@
+package load
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// spike to 50 users, soak and step down to 20 users
+var spikeStages = []LoadStageParam{
+	{Duration: 10, Target: 10},
+	{Duration: 0, Target: 50},
+	{Duration: 20, Target: 50},
+	{Duration: 10, Target: 20},
+	{Duration: 30, Target: 20},
+}
+
+func TestUltimateThreadGroupRows(t *testing.T) {
+	require.Equal(t, []jmxUltimateThreadGroupRow{
+		{Threads: 10, Delay: 0, Startup: 10, Hold: 60, Shutdown: 0},
+		{Threads: 30, Delay: 10, Startup: 0, Hold: 20, Shutdown: 10},
+		{Threads: 10, Delay: 10, Startup: 0, Hold: 60, Shutdown: 0},
+	}, ultimateThreadGroupRows(spikeStages))
+
+	// ramp down to zero stops every group
+	require.Equal(t, []jmxUltimateThreadGroupRow{
+		{Threads: 5, Delay: 0, Startup: 10, Hold: 0, Shutdown: 10},
+		{Threads: 5, Delay: 0, Startup: 10, Hold: 15, Shutdown: 5},
+		{Threads: 10, Delay: 20, Startup: 5, Hold: 0, Shutdown: 5},
+	}, ultimateThreadGroupRows([]LoadStageParam{
+		{Duration: 10, Target: 10},
+		{Duration: 10, Target: 5},
+		{Duration: 5, Target: 15},
+		{Duration: 5, Target: 0},
+	}))
+}
+
+func TestArrivalsScheduleRows(t *testing.T) {
+	require.Equal(t, []jmxArrivalsScheduleRow{
+		{From: 0, To: 10, Duration: 10},
+		{From: 50, To: 50, Duration: 20},
+		{From: 50, To: 20, Duration: 10},
+		{From: 20, To: 20, Duration: 30},
+	}, arrivalsScheduleRows(spikeStages))
+}
+
+func TestSplitAcross(t *testing.T) {
+	param := RunLoadTestParam{
+		VirtualUsers: "100",
+		LoadProfile: &LoadProfileParam{
+			Model:           loadModelOpen,
+			MaxVirtualUsers: 50,
+			Stages:          []LoadStageParam{{Duration: 10, Target: 30}, {Duration: 20, Target: 31}},
+		},
+	}
+
+	split := param.splitAcross(3)
+	require.Equal(t, "34", split.VirtualUsers)
+	require.Equal(t, 17, split.LoadProfile.MaxVirtualUsers)
+	require.Equal(t, []LoadStageParam{{Duration: 10, Target: 10}, {Duration: 20, Target: 11}}, split.LoadProfile.Stages)
+
+	// the requested load test is not changed
+	require.Equal(t, "100", param.VirtualUsers)
+	require.Equal(t, 30, param.LoadProfile.Stages[0].Target)
+
+	// unlimited virtual users stay unlimited
+	param.LoadProfile.MaxVirtualUsers = 0
+	require.Equal(t, 0, param.splitAcross(3).LoadProfile.MaxVirtualUsers)
+
+	require.Equal(t, param, param.splitAcross(1))
+}
+
+func TestThreadGroupParseToJmx(t *testing.T) {
+	param := RunLoadTestParam{TestName: "profile", VirtualUsers: "10", Duration: "60", RampUpTime: "10", RampUpSteps: "2"}
+
+	threadGroup, err := threadGroupParseToJmx(param)
+	require.NoError(t, err)
+	require.Contains(t, threadGroup, `<stringProp name="TargetLevel">10</stringProp>`)
+
+	param.LoadProfile = &LoadProfileParam{Model: "closed", Stages: spikeStages}
+	threadGroup, err = threadGroupParseToJmx(param)
+	require.NoError(t, err)
+	require.Contains(t, threadGroup, "kg.apc.jmeter.threads.UltimateThreadGroup")
+	require.Contains(t, threadGroup, `<stringProp name="threads">30</stringProp>`)
+
+	param.LoadProfile = &LoadProfileParam{Model: "open", MaxVirtualUsers: 200, Stages: spikeStages}
+	threadGroup, err = threadGroupParseToJmx(param)
+	require.NoError(t, err)
+	require.Contains(t, threadGroup, "FreeFormArrivalsThreadGroup")
+	require.Contains(t, threadGroup, `<stringProp name="ConcurrencyLimit">200</stringProp>`)
+	require.Equal(t, 70, param.LoadProfile.totalDuration())
+}
+
+func TestValidateLoadProfile(t *testing.T) {
+	require.NoError(t, validateLoadProfile(&LoadProfileParam{Model: "open", Stages: spikeStages}))
+
+	invalids := []*LoadProfileParam{
+		{Model: "burst", Stages: spikeStages},
+		{Model: "closed"},
+		{Model: "closed", Stages: []LoadStageParam{{Duration: 0, Target: 10}}},
+		{Model: "closed", Stages: []LoadStageParam{{Duration: 10, Target: -1}}},
+		{Model: "open", MaxVirtualUsers: -1, Stages: spikeStages},
+	}
+	for _, p := range invalids {
+		require.Error(t, validateLoadProfile(p), p)
+	}
+}
@@ -2,23 +2,24 @@ package load
 
 import (
 	"bytes"
+	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net/url"
+	"regexp"
 	"strings"
 	"text/template"
 
+	"github.com/cloud-barista/cm-ant/internal/core/common/constant"
 	"github.com/cloud-barista/cm-ant/internal/utils"
 )
 
 type jmxTemplateData struct {
 	TestName          string
-	Duration          string
-	RampUpSteps       string
-	RampUpTime        string
-	VirtualUsers      string
+	ThreadGroup       string
 	HttpRequests      string
+	Cookies           string
 	AgentHost         string
 	AgentPort         string
 	CpuResultPath     string
@@ -30,13 +31,32 @@ type jmxTemplateData struct {
 }
 
 type jmxHttpTemplateData struct {
-	Method   string                 `json:"method"`
-	Protocol string                 `json:"protocol"`
-	Hostname string                 `json:"hostname"`
-	Port     string                 `json:"port"`
-	Path     string                 `json:"path,omitempty"`
-	Params   []jmxTemplateDataParam `json:"params,omitempty"`
-	BodyData string                 `json:"bodyData,omitempty"`
+	Method          string                     `json:"method"`
+	Protocol        string                     `json:"protocol"`
+	Hostname        string                     `json:"hostname"`
+	Port            string                     `json:"port"`
+	Path            string                     `json:"path,omitempty"`
+	Params          []jmxTemplateDataParam     `json:"params,omitempty"`
+	BodyData        string                     `json:"bodyData,omitempty"`
+	Headers         []jmxTemplateDataParam     `json:"headers,omitempty"`
+	BasicAuth       *jmxTemplateDataParam      `json:"basicAuth,omitempty"`
+	FollowRedirects bool                       `json:"followRedirects"`
+	AutoRedirects   bool                       `json:"autoRedirects"`
+	ConnectTimeout  int                        `json:"connectTimeout"`
+	ResponseTimeout int                        `json:"responseTimeout"`
+	Label           string                     `json:"label"`
+	Extractors      []jmxExtractorTemplateData `json:"extractors,omitempty"`
+	ThinkTime       int                        `json:"thinkTime"`
+}
+
+type jmxExtractorTemplateData struct {
+	Type        string
+	Variable    string
+	Expression  string
+	MatchNumber int
+	Default     string
+	Template    string
+	UseHeaders  bool
 }
 
 type jmxTemplateDataParam struct {
@@ -44,12 +64,51 @@ type jmxTemplateDataParam struct {
 	Value string `json:"value"`
 }
 
-var jmxHttpSamplerTemplate = map[string]string{
-	"GET": `
-	<HTTPSamplerProxy guiclass="HttpTestSampleGui" testclass="HTTPSamplerProxy" testname="{{.Method}} Request" enabled="true">
+const (
+	httpAuthBasic  = "basic"
+	httpAuthBearer = "bearer"
+
+	redirectFollow = "follow"
+	redirectAuto   = "auto"
+	redirectNone   = "none"
+
+	defaultHttpTimeout = 60000
+
+	extractorJsonPath = "jsonpath"
+	extractorRegex    = "regex"
+)
+
+var jmxVariableNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// supportedHttpMethods tells whether the http method sends the body data.
+// Query parameters of the methods without body are rendered as sampler arguments.
+var supportedHttpMethods = map[string]bool{
+	"GET":     false,
+	"HEAD":    false,
+	"OPTIONS": false,
+	"POST":    true,
+	"PUT":     true,
+	"PATCH":   true,
+	"DELETE":  true,
+}
+
+var jmxHttpSamplerTemplate = `
+	<HTTPSamplerProxy guiclass="HttpTestSampleGui" testclass="HTTPSamplerProxy" testname="{{.Label}}" enabled="true">
+		{{- if .BodyData }}
+		<boolProp name="HTTPSampler.postBodyRaw">true</boolProp>
+		<elementProp name="HTTPsampler.Arguments" elementType="Arguments">
+			<collectionProp name="Arguments.arguments">
+				<elementProp name="" elementType="HTTPArgument">
+					<boolProp name="HTTPArgument.always_encode">false</boolProp>
+					<stringProp name="Argument.value">{{.BodyData}}</stringProp>
+					<stringProp name="Argument.metadata">=</stringProp>
+				</elementProp>
+			</collectionProp>
+		</elementProp>
+		{{- else }}
 		<elementProp name="HTTPsampler.Arguments" elementType="Arguments" guiclass="HTTPArgumentsPanel" testclass="Arguments" testname="User Defined Variables" enabled="true">
 			<collectionProp name="Arguments.arguments">
-				{{ range .Params }}
+				{{- range .Params }}
 				<elementProp name="{{ .Name }}" elementType="HTTPArgument">
 					<boolProp name="HTTPArgument.always_encode">false</boolProp>
 					<stringProp name="Argument.value">{{ .Value }}</stringProp>
@@ -57,52 +116,213 @@ var jmxHttpSamplerTemplate = map[string]string{
 					<boolProp name="HTTPArgument.use_equals">true</boolProp>
 					<stringProp name="Argument.name">{{ .Name }}</stringProp>
 				</elementProp>
-				{{ end }}
+				{{- end }}
 			</collectionProp>
 		</elementProp>
+		{{- end }}
 		<stringProp name="HTTPSampler.domain">{{.Hostname}}</stringProp>
 		<stringProp name="HTTPSampler.port">{{.Port}}</stringProp>
 		<stringProp name="HTTPSampler.protocol">{{.Protocol}}</stringProp>
 		<stringProp name="HTTPSampler.path">{{.Path}}</stringProp>
 		<stringProp name="HTTPSampler.method">{{.Method}}</stringProp>
 		<stringProp name="HTTPSampler.contentEncoding">UTF-8</stringProp>
-		<boolProp name="HTTPSampler.follow_redirects">true</boolProp>
-		<boolProp name="HTTPSampler.auto_redirects">false</boolProp>
+		<boolProp name="HTTPSampler.follow_redirects">{{.FollowRedirects}}</boolProp>
+		<boolProp name="HTTPSampler.auto_redirects">{{.AutoRedirects}}</boolProp>
 		<boolProp name="HTTPSampler.use_keepalive">true</boolProp>
 		<boolProp name="HTTPSampler.DO_MULTIPART_POST">false</boolProp>
 		<stringProp name="HTTPSampler.embedded_url_re"></stringProp>
-		<stringProp name="HTTPSampler.connect_timeout">60000</stringProp>
-		<stringProp name="HTTPSampler.response_timeout">60000</stringProp>
+		<stringProp name="HTTPSampler.connect_timeout">{{.ConnectTimeout}}</stringProp>
+		<stringProp name="HTTPSampler.response_timeout">{{.ResponseTimeout}}</stringProp>
 	</HTTPSamplerProxy>
-	<hashTree/>
-	`,
-	"POST": `
-	<HTTPSamplerProxy guiclass="HttpTestSampleGui" testclass="HTTPSamplerProxy" testname="{{.Method}} Request" enabled="true">
-		<boolProp name="HTTPSampler.postBodyRaw">true</boolProp>
-		<elementProp name="HTTPsampler.Arguments" elementType="Arguments">
-			<collectionProp name="Arguments.arguments">
-				<elementProp name="" elementType="HTTPArgument">
-					<boolProp name="HTTPArgument.always_encode">false</boolProp>
-					<stringProp name="Argument.value">{{.BodyData}}</stringProp>
-					<stringProp name="Argument.metadata">=</stringProp>
+	<hashTree>
+		{{- if .Headers }}
+		<HeaderManager guiclass="HeaderPanel" testclass="HeaderManager" testname="HTTP Header Manager" enabled="true">
+			<collectionProp name="HeaderManager.headers">
+				{{- range .Headers }}
+				<elementProp name="" elementType="Header">
+					<stringProp name="Header.name">{{ .Name }}</stringProp>
+					<stringProp name="Header.value">{{ .Value }}</stringProp>
 				</elementProp>
+				{{- end }}
 			</collectionProp>
-		</elementProp>
-		<stringProp name="HTTPSampler.domain">{{.Hostname}}</stringProp>
-		<stringProp name="HTTPSampler.port">{{.Port}}</stringProp>
-		<stringProp name="HTTPSampler.protocol">{{.Protocol}}</stringProp>
-		<stringProp name="HTTPSampler.path">{{.Path}}</stringProp>
-		<stringProp name="HTTPSampler.method">{{.Method}}</stringProp>
-		<stringProp name="HTTPSampler.contentEncoding">UTF-8</stringProp>
-		<boolProp name="HTTPSampler.follow_redirects">true</boolProp>
-		<boolProp name="HTTPSampler.auto_redirects">false</boolProp>
-		<boolProp name="HTTPSampler.use_keepalive">true</boolProp>
-		<boolProp name="HTTPSampler.DO_MULTIPART_POST">false</boolProp>
-		<stringProp name="HTTPSampler.embedded_url_re"></stringProp>
-		<stringProp name="HTTPSampler.connect_timeout">60000</stringProp>
-		<stringProp name="HTTPSampler.response_timeout">60000</stringProp>
-	</HTTPSamplerProxy>
-	`,
+		</HeaderManager>
+		<hashTree/>
+		{{- end }}
+		{{- with .BasicAuth }}
+		<AuthManager guiclass="AuthPanel" testclass="AuthManager" testname="HTTP Authorization Manager" enabled="true">
+			<collectionProp name="AuthManager.auth_list">
+				<elementProp name="" elementType="Authorization">
+					<stringProp name="Authorization.url"></stringProp>
+					<stringProp name="Authorization.username">{{ .Name }}</stringProp>
+					<stringProp name="Authorization.password">{{ .Value }}</stringProp>
+					<stringProp name="Authorization.domain"></stringProp>
+					<stringProp name="Authorization.realm"></stringProp>
+					<stringProp name="Authorization.mechanism">BASIC</stringProp>
+				</elementProp>
+			</collectionProp>
+			<boolProp name="AuthManager.controlledByThreadGroup">false</boolProp>
+		</AuthManager>
+		<hashTree/>
+		{{- end }}
+		{{- range .Extractors }}
+		{{- if eq .Type "jsonpath" }}
+		<JSONPostProcessor guiclass="JSONPostProcessorGui" testclass="JSONPostProcessor" testname="{{ .Variable }} JSON Extractor" enabled="true">
+			<stringProp name="JSONPostProcessor.referenceNames">{{ .Variable }}</stringProp>
+			<stringProp name="JSONPostProcessor.jsonPathExprs">{{ .Expression }}</stringProp>
+			<stringProp name="JSONPostProcessor.match_numbers">{{ .MatchNumber }}</stringProp>
+			<stringProp name="JSONPostProcessor.defaultValues">{{ .Default }}</stringProp>
+		</JSONPostProcessor>
+		<hashTree/>
+		{{- else }}
+		<RegexExtractor guiclass="RegexExtractorGui" testclass="RegexExtractor" testname="{{ .Variable }} Regular Expression Extractor" enabled="true">
+			<stringProp name="RegexExtractor.useHeaders">{{ .UseHeaders }}</stringProp>
+			<stringProp name="RegexExtractor.refname">{{ .Variable }}</stringProp>
+			<stringProp name="RegexExtractor.regex">{{ .Expression }}</stringProp>
+			<stringProp name="RegexExtractor.template">{{ .Template }}</stringProp>
+			<stringProp name="RegexExtractor.default">{{ .Default }}</stringProp>
+			<stringProp name="RegexExtractor.match_number">{{ .MatchNumber }}</stringProp>
+		</RegexExtractor>
+		<hashTree/>
+		{{- end }}
+		{{- end }}
+	</hashTree>
+	{{- if gt .ThinkTime 0 }}
+	<TestAction guiclass="TestActionGui" testclass="TestAction" testname="{{.Label}} Think Time" enabled="true">
+		<intProp name="ActionProcessor.action">1</intProp>
+		<intProp name="ActionProcessor.target">0</intProp>
+		<stringProp name="ActionProcessor.duration">0</stringProp>
+	</TestAction>
+	<hashTree>
+		<ConstantTimer guiclass="ConstantTimerGui" testclass="ConstantTimer" testname="{{.Label}} Think Time Timer" enabled="true">
+			<stringProp name="ConstantTimer.delay">{{.ThinkTime}}</stringProp>
+		</ConstantTimer>
+		<hashTree/>
+	</hashTree>
+	{{- end }}
+	`
+
+var jmxTransactionTemplate = `
+	<TransactionController guiclass="TransactionControllerGui" testclass="TransactionController" testname="{{.Name}}" enabled="true">
+		<boolProp name="TransactionController.includeTimers">false</boolProp>
+		<boolProp name="TransactionController.parent">false</boolProp>
+	</TransactionController>
+	<hashTree>
+	{{.Steps}}
+	</hashTree>
+	`
+
+type jmxTransactionTemplateData struct {
+	Name  string
+	Steps string
+}
+
+var jmxCookieTemplate = `
+		  {{- range . }}
+          <elementProp name="{{ .Name }}" elementType="Cookie" testname="{{ .Name }}">
+            <stringProp name="Cookie.value">{{ .Value }}</stringProp>
+            <stringProp name="Cookie.domain">{{ .Domain }}</stringProp>
+            <stringProp name="Cookie.path">/</stringProp>
+            <boolProp name="Cookie.secure">false</boolProp>
+            <longProp name="Cookie.expires">0</longProp>
+            <boolProp name="Cookie.path_specified">true</boolProp>
+            <boolProp name="Cookie.domain_specified">true</boolProp>
+          </elementProp>
+		  {{- end }}`
+
+type jmxCookieTemplateData struct {
+	Name   string
+	Value  string
+	Domain string
+}
+
+// validateRunLoadTestParam checks the parts of the load test parameter which can not be verified
+// until the test plan is generated, so the wrong request is rejected before the load test starts.
+func validateRunLoadTestParam(param RunLoadTestParam) error {
+	engine, err := getLoadGeneratorEngine(param.Engine)
+	if err != nil {
+		return err
+	}
+
+	if param.TestPlanId != uint(0) && engine.Type() != constant.Jmeter {
+		return fmt.Errorf("stored test plans can not be run by the %s engine", engine.Type())
+	}
+
+	if param.LoadProfile != nil {
+		if param.TestPlanId != uint(0) {
+			return errors.New("load profile can not be applied to the stored test plan")
+		}
+
+		if err := validateLoadProfile(param.LoadProfile); err != nil {
+			return err
+		}
+	}
+
+	if _, ok := engine.(inProcessEngine); ok && param.LoadGeneratorInstallInfoId == uint(0) && param.InstallLoadGenerator.InstallLocation != constant.Local {
+		return fmt.Errorf("%s engine only runs on the local load generator", engine.Type())
+	}
+
+	for _, h := range param.HttpReqs {
+		if _, ok := supportedHttpMethods[strings.ToUpper(h.Method)]; !ok {
+			return fmt.Errorf("http method %q is not supported", h.Method)
+		}
+
+		if h.Auth != nil {
+			switch strings.ToLower(h.Auth.Type) {
+			case httpAuthBasic:
+				if h.Auth.Username == "" {
+					return errors.New("username is required for basic auth")
+				}
+			case httpAuthBearer:
+				if h.Auth.Token == "" {
+					return errors.New("token is required for bearer auth")
+				}
+			default:
+				return fmt.Errorf("auth type %q is not supported", h.Auth.Type)
+			}
+		}
+
+		switch strings.ToLower(h.RedirectPolicy) {
+		case "", redirectFollow, redirectAuto, redirectNone:
+		default:
+			return fmt.Errorf("redirect policy %q is not supported", h.RedirectPolicy)
+		}
+
+		if h.ConnectTimeout < 0 || h.ResponseTimeout < 0 {
+			return errors.New("timeout must not be negative")
+		}
+
+		if h.ThinkTime < 0 {
+			return errors.New("think time must not be negative")
+		}
+
+		for _, e := range h.Extractors {
+			switch strings.ToLower(e.Type) {
+			case extractorJsonPath, extractorRegex:
+			default:
+				return fmt.Errorf("extractor type %q is not supported", e.Type)
+			}
+
+			if !jmxVariableNameRegex.MatchString(e.Variable) {
+				return fmt.Errorf("extractor variable %q is not a valid name", e.Variable)
+			}
+
+			if e.Expression == "" {
+				return fmt.Errorf("expression of extractor %s is required", e.Variable)
+			}
+
+			if e.MatchNumber < 0 {
+				return fmt.Errorf("match number of extractor %s must not be negative", e.Variable)
+			}
+
+			switch strings.ToLower(e.Field) {
+			case "", "body", "headers":
+			default:
+				return fmt.Errorf("extractor field %q is not supported", e.Field)
+			}
+		}
+	}
+
+	return ValidateSloRules(param.SloRules)
 }
 
 func parseTestPlanStructToString(w io.Writer, param RunLoadTestParam, loadGeneratorInstallInfo *LoadGeneratorInstallInfo) error {
@@ -112,28 +332,34 @@ func parseTestPlanStructToString(w io.Writer, param RunLoadTestParam, loadGenera
 		return err
 	}
 
+	cookies, err := cookiesParseToJmx(param.Hostname, param.HttpReqs)
+	if err != nil {
+		return err
+	}
+
+	threadGroup, err := threadGroupParseToJmx(param)
+	if err != nil {
+		return err
+	}
+
 	agentHost := param.AgentHostname
 
 	var tmpl *template.Template
 
 	jmxTemplateData := jmxTemplateData{
 		TestName:     param.TestName,
-		Duration:     param.Duration,
-		RampUpSteps:  param.RampUpSteps,
-		RampUpTime:   param.RampUpTime,
-		VirtualUsers: param.VirtualUsers,
+		ThreadGroup:  threadGroup,
 		HttpRequests: httpRequests,
+		Cookies:      cookies,
 	}
 
 	if agentHost != "" {
-		jmxTemplateData.AgentHost = agentHost
-		jmxTemplateData.AgentPort = "5555"
-		jmxTemplateData.CpuResultPath = fmt.Sprintf("%s/%s_cpu_result.csv", resultPath, param.LoadTestKey)
-		jmxTemplateData.MemoryResultPath = fmt.Sprintf("%s/%s_memory_result.csv", resultPath, param.LoadTestKey)
-		jmxTemplateData.DiskResultPath = fmt.Sprintf("%s/%s_disk_result.csv", resultPath, param.LoadTestKey)
-		jmxTemplateData.NetworkResultPath = fmt.Sprintf("%s/%s_network_result.csv", resultPath, param.LoadTestKey)
-
-		tmpl, err = template.ParseFiles(utils.JoinRootPathWith("/test_plan/default_perfmon.jmx"))
+		setPerfmonTemplateData(&jmxTemplateData, agentHost, resultPath, param.LoadTestKey)
+
+		tmpl, err = template.ParseFiles(
+			utils.JoinRootPathWith("/test_plan/default_perfmon.jmx"),
+			utils.JoinRootPathWith(perfmonCollectorsTemplatePath),
+		)
 	} else {
 		tmpl, err = template.ParseFiles(utils.JoinRootPathWith("/test_plan/default.jmx"))
 	}
@@ -150,67 +376,289 @@ func parseTestPlanStructToString(w io.Writer, param RunLoadTestParam, loadGenera
 	return nil
 }
 
+func setPerfmonTemplateData(d *jmxTemplateData, agentHost, resultPath, loadTestKey string) {
+	d.AgentHost = agentHost
+	d.AgentPort = "5555"
+	d.CpuResultPath = fmt.Sprintf("%s/%s_cpu_result.csv", resultPath, loadTestKey)
+	d.MemoryResultPath = fmt.Sprintf("%s/%s_memory_result.csv", resultPath, loadTestKey)
+	d.DiskResultPath = fmt.Sprintf("%s/%s_disk_result.csv", resultPath, loadTestKey)
+	d.NetworkResultPath = fmt.Sprintf("%s/%s_network_result.csv", resultPath, loadTestKey)
+}
+
+// parseStoredTestPlanToString writes the stored test plan of the library for the load test.
+// The perfmon collectors of the default plan are injected when the monitoring agent host is given.
+func parseStoredTestPlanToString(w io.Writer, param RunLoadTestParam, loadGeneratorInstallInfo *LoadGeneratorInstallInfo, plan LoadTestPlan) error {
+	resultPath := fmt.Sprintf("%s/result", loadGeneratorInstallInfo.InstallPath)
+
+	data := jmxInjectionData{
+		LoadTestKey: param.LoadTestKey,
+		DataDir:     testPlanDataDir(loadGeneratorInstallInfo.InstallPath, param.LoadTestKey),
+		ResultDir:   resultPath,
+	}
+
+	if param.AgentHostname != "" {
+		var perfmon jmxTemplateData
+		setPerfmonTemplateData(&perfmon, param.AgentHostname, resultPath, param.LoadTestKey)
+
+		tmpl, err := template.ParseFiles(utils.JoinRootPathWith(perfmonCollectorsTemplatePath))
+		if err != nil {
+			return err
+		}
+
+		var builder strings.Builder
+		if err := tmpl.ExecuteTemplate(&builder, perfmonCollectorsTemplateName, perfmon); err != nil {
+			return err
+		}
+		data.PerfmonCollectors = builder.String()
+	}
+
+	return injectJmxTestPlan(w, []byte(plan.Content), data)
+}
+
+// renderTestPlan writes the stored test plan when it is given, otherwise the plan generated from the http requests.
+func renderTestPlan(w io.Writer, param RunLoadTestParam, loadGeneratorInstallInfo *LoadGeneratorInstallInfo, plan *LoadTestPlan) error {
+	if plan != nil {
+		return parseStoredTestPlanToString(w, param, loadGeneratorInstallInfo, *plan)
+	}
+	return parseTestPlanStructToString(w, param, loadGeneratorInstallInfo)
+}
+
+// testPlanDataDir is the directory of the load generator where the data files of the stored test plan are placed.
+func testPlanDataDir(loadGeneratorInstallPath, loadTestKey string) string {
+	return fmt.Sprintf("%s/test_plan/%s_data", loadGeneratorInstallPath, loadTestKey)
+}
+
 func httpReqParseToJmx(hostname, port string, httpReqs []RunLoadTestHttpParam) (string, error) {
+	tmpl, err := template.New("jmxHttpSamplerTemplate").Parse(jmxHttpSamplerTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	transactionTmpl, err := template.New("jmxTransactionTemplate").Parse(jmxTransactionTemplate)
+	if err != nil {
+		return "", err
+	}
+
 	var builder strings.Builder
-	for i, req := range httpReqs {
+	var transaction string
+	var steps strings.Builder
+
+	// flushTransaction writes the grouped steps of the current transaction under a transaction controller.
+	flushTransaction := func() error {
+		if transaction == "" {
+			return nil
+		}
+
+		err := transactionTmpl.Execute(&builder, jmxTransactionTemplateData{
+			Name:  escapeXml(transaction),
+			Steps: steps.String(),
+		})
+		if err != nil {
+			return err
+		}
+
+		builder.WriteString("\n")
+		transaction = ""
+		steps.Reset()
+		return nil
+	}
+
+	for _, req := range httpReqs {
 		method := strings.ToUpper(req.Method)
-		jmxTemplate, ok := jmxHttpSamplerTemplate[method]
+		hasBody, ok := supportedHttpMethods[method]
+		if !ok {
+			return "", fmt.Errorf("http method %q is not supported", req.Method)
+		}
 
-		if ok {
-			tmpl, err := template.New(fmt.Sprintf("jmxTemplate-%d-%s", i, method)).Parse(jmxTemplate)
-			if err != nil {
-				return "", err
-			}
+		parsedUrl, err := url.Parse(req.Path)
+		if err != nil {
+			return "", err
+		}
 
-			parsedUrl, err := url.Parse(req.Path)
-			if err != nil {
-				return "", err
-			}
+		h := req.Hostname
+		if h == "" {
+			h = hostname
+		}
 
-			h := req.Hostname
-			if h == "" {
-				h = hostname
-			}
+		p := req.Port
+		if p == "" {
+			p = port
+		}
+
+		jmxHttpTemplateData := jmxHttpTemplateData{
+			Method:          method,
+			Protocol:        escapeXml(req.Protocol),
+			Hostname:        escapeXml(h),
+			Port:            escapeXml(p),
+			Path:            escapeXml(parsedUrl.Path),
+			ConnectTimeout:  defaultHttpTimeout,
+			ResponseTimeout: defaultHttpTimeout,
+			Label:           escapeXml(method + " Request"),
+			ThinkTime:       req.ThinkTime,
+		}
+
+		if req.Name != "" {
+			jmxHttpTemplateData.Label = escapeXml(req.Name)
+		}
 
-			p := req.Port
-			if p == "" {
-				p = port
+		for _, e := range req.Extractors {
+			jmxHttpTemplateData.Extractors = append(jmxHttpTemplateData.Extractors, extractorParseToJmx(e))
+		}
+
+		if req.ConnectTimeout > 0 {
+			jmxHttpTemplateData.ConnectTimeout = req.ConnectTimeout
+		}
+
+		if req.ResponseTimeout > 0 {
+			jmxHttpTemplateData.ResponseTimeout = req.ResponseTimeout
+		}
+
+		switch strings.ToLower(req.RedirectPolicy) {
+		case redirectAuto:
+			jmxHttpTemplateData.AutoRedirects = true
+		case redirectNone:
+		default:
+			jmxHttpTemplateData.FollowRedirects = true
+		}
+
+		if hasBody {
+			// the query of the path is kept as it is because the arguments are used for the body
+			if parsedUrl.RawQuery != "" {
+				jmxHttpTemplateData.Path = escapeXml(parsedUrl.Path + "?" + parsedUrl.RawQuery)
 			}
+			jmxHttpTemplateData.BodyData = escapeXml(req.BodyData)
+		} else {
+			params := make([]jmxTemplateDataParam, 0)
+			parsedParams := parsedUrl.Query()
+			for key, values := range parsedParams {
+				param := jmxTemplateDataParam{
+					Name:  escapeXml(key),
+					Value: escapeXml(values[0]),
+				}
 
-			jmxHttpTemplateData := jmxHttpTemplateData{
-				Method:   method,
-				Protocol: req.Protocol,
-				Hostname: h,
-				Port:     p,
-				Path:     parsedUrl.Path,
+				params = append(params, param)
 			}
+			jmxHttpTemplateData.Params = params
+		}
 
-			if strings.ToUpper(method) == "GET" {
-				params := make([]jmxTemplateDataParam, 0)
-				parsedParams := parsedUrl.Query()
-				for key, values := range parsedParams {
-					param := jmxTemplateDataParam{
-						Name:  key,
-						Value: values[0],
-					}
+		headers := req.Headers
+		if req.ContentType != "" && !hasHeader(headers, "Content-Type") {
+			headers = append(headers, RunLoadTestHttpHeaderParam{Name: "Content-Type", Value: req.ContentType})
+		}
 
-					params = append(params, param)
+		if req.Auth != nil {
+			switch strings.ToLower(req.Auth.Type) {
+			case httpAuthBasic:
+				jmxHttpTemplateData.BasicAuth = &jmxTemplateDataParam{
+					Name:  escapeXml(req.Auth.Username),
+					Value: escapeXml(req.Auth.Password),
 				}
-				jmxHttpTemplateData.Params = params
-			} else if method == "POST" {
-				jmxHttpTemplateData.BodyData = req.BodyData
+			case httpAuthBearer:
+				headers = append(headers, RunLoadTestHttpHeaderParam{Name: "Authorization", Value: "Bearer " + req.Auth.Token})
 			}
+		}
 
-			var buf bytes.Buffer
-			err = tmpl.Execute(&buf, jmxHttpTemplateData)
-			if err != nil {
-				log.Fatalf("Error executing template: %s", err)
+		for _, header := range headers {
+			jmxHttpTemplateData.Headers = append(jmxHttpTemplateData.Headers, jmxTemplateDataParam{
+				Name:  escapeXml(header.Name),
+				Value: escapeXml(header.Value),
+			})
+		}
+
+		if req.Transaction != transaction {
+			if err := flushTransaction(); err != nil {
+				return "", err
 			}
-			builder.WriteString(buf.String())
+			transaction = req.Transaction
 		}
 
-		builder.WriteString("\n")
+		w := &builder
+		if transaction != "" {
+			w = &steps
+		}
+
+		err = tmpl.Execute(w, jmxHttpTemplateData)
+		if err != nil {
+			return "", err
+		}
+
+		w.WriteString("\n")
+	}
+
+	if err := flushTransaction(); err != nil {
+		return "", err
 	}
+
 	result := builder.String()
 	return result, nil
 }
+
+func extractorParseToJmx(e RunLoadTestExtractorParam) jmxExtractorTemplateData {
+	d := jmxExtractorTemplateData{
+		Type:        strings.ToLower(e.Type),
+		Variable:    e.Variable,
+		Expression:  escapeXml(e.Expression),
+		MatchNumber: 1,
+		Default:     escapeXml(e.DefaultValue),
+		Template:    "$1$",
+		UseHeaders:  strings.EqualFold(e.Field, "headers"),
+	}
+
+	if e.MatchNumber > 0 {
+		d.MatchNumber = e.MatchNumber
+	}
+
+	if e.Template != "" {
+		d.Template = escapeXml(e.Template)
+	}
+
+	return d
+}
+
+// cookiesParseToJmx renders the cookies of every http request into the elements of the cookie manager.
+// The cookie is bound to the host of the request which declares it.
+func cookiesParseToJmx(hostname string, httpReqs []RunLoadTestHttpParam) (string, error) {
+	var cookies []jmxCookieTemplateData
+	for _, req := range httpReqs {
+		h := req.Hostname
+		if h == "" {
+			h = hostname
+		}
+
+		for _, c := range req.Cookies {
+			cookies = append(cookies, jmxCookieTemplateData{
+				Name:   escapeXml(c.Name),
+				Value:  escapeXml(c.Value),
+				Domain: escapeXml(h),
+			})
+		}
+	}
+
+	tmpl, err := template.New("jmxCookieTemplate").Parse(jmxCookieTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, cookies)
+	if err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+func hasHeader(headers []RunLoadTestHttpHeaderParam, name string) bool {
+	for _, h := range headers {
+		if strings.EqualFold(h.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func escapeXml(s string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
@@ -0,0 +1,154 @@
+package load
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHttpReqParseToJmx(t *testing.T) {
+	httpReqs := []RunLoadTestHttpParam{
+		{
+			Method:   "get",
+			Protocol: "http",
+			Path:     "/items?q=a&b",
+			Auth:     &RunLoadTestHttpAuthParam{Type: "basic", Username: "user", Password: "p<w>d"},
+		},
+		{
+			Method:          "PATCH",
+			Protocol:        "https",
+			Hostname:        "api.example.com",
+			Path:            "/items/1?dryRun=true",
+			BodyData:        `{"name":"<ant>"}`,
+			ContentType:     "application/json",
+			Headers:         []RunLoadTestHttpHeaderParam{{Name: "X-Trace", Value: "1"}},
+			Auth:            &RunLoadTestHttpAuthParam{Type: "bearer", Token: "token"},
+			ConnectTimeout:  1000,
+			ResponseTimeout: 2000,
+			RedirectPolicy:  "none",
+		},
+		{Method: "DELETE", Protocol: "http", Path: "/items/1"},
+	}
+
+	result, err := httpReqParseToJmx("localhost", "8080", httpReqs)
+	require.NoError(t, err)
+
+	// every sampler must be followed by its hash tree to be a valid test plan
+	var plan struct {
+		Samplers []struct {
+			Props []struct {
+				Name  string `xml:"name,attr"`
+				Value string `xml:",chardata"`
+			} `xml:",any"`
+		} `xml:"HTTPSamplerProxy"`
+		HashTrees []struct {
+			Inner string `xml:",innerxml"`
+		} `xml:"hashTree"`
+	}
+	require.NoError(t, xml.Unmarshal([]byte("<root>"+result+"</root>"), &plan))
+	require.Len(t, plan.Samplers, 3)
+	require.Len(t, plan.HashTrees, 3)
+
+	require.Contains(t, plan.HashTrees[0].Inner, "<stringProp name=\"Authorization.password\">p&lt;w&gt;d</stringProp>")
+	require.NotContains(t, plan.HashTrees[0].Inner, "HeaderManager")
+
+	require.Contains(t, plan.HashTrees[1].Inner, "<stringProp name=\"Header.value\">application/json</stringProp>")
+	require.Contains(t, plan.HashTrees[1].Inner, "<stringProp name=\"Header.value\">Bearer token</stringProp>")
+	require.Contains(t, plan.HashTrees[1].Inner, "<stringProp name=\"Header.name\">X-Trace</stringProp>")
+	require.NotContains(t, plan.HashTrees[1].Inner, "AuthManager")
+
+	require.Contains(t, result, "<stringProp name=\"HTTPSampler.path\">/items/1?dryRun=true</stringProp>")
+	require.Contains(t, result, "<stringProp name=\"Argument.value\">{&#34;name&#34;:&#34;&lt;ant&gt;&#34;}</stringProp>")
+	require.Contains(t, result, "<stringProp name=\"HTTPSampler.domain\">api.example.com</stringProp>")
+	require.Contains(t, result, "<stringProp name=\"HTTPSampler.connect_timeout\">1000</stringProp>")
+	require.Contains(t, result, "<boolProp name=\"HTTPSampler.follow_redirects\">false</boolProp>")
+	require.Equal(t, 2, strings.Count(result, "<boolProp name=\"HTTPSampler.follow_redirects\">true</boolProp>"))
+	require.Contains(t, result, "<stringProp name=\"HTTPSampler.method\">DELETE</stringProp>")
+
+	_, err = httpReqParseToJmx("localhost", "8080", []RunLoadTestHttpParam{{Method: "TRACE"}})
+	require.Error(t, err)
+}
+
+func TestHttpReqParseToJmxScenario(t *testing.T) {
+	httpReqs := []RunLoadTestHttpParam{
+		{
+			Method:      "POST",
+			Protocol:    "http",
+			Path:        "/login",
+			Name:        "login",
+			Transaction: "auth",
+			Extractors: []RunLoadTestExtractorParam{
+				{Type: "jsonpath", Variable: "token", Expression: "$.token", DefaultValue: "NOT_FOUND"},
+			},
+		},
+		{
+			Method:      "GET",
+			Protocol:    "http",
+			Path:        "/me",
+			Name:        "me",
+			Transaction: "auth",
+			Headers:     []RunLoadTestHttpHeaderParam{{Name: "Authorization", Value: "Bearer ${token}"}},
+			Extractors: []RunLoadTestExtractorParam{
+				{Type: "regex", Variable: "userId", Expression: `"id":(\d+)`, MatchNumber: 0, Field: "headers"},
+			},
+			ThinkTime: 500,
+		},
+		{Method: "GET", Protocol: "http", Path: "/items/${userId}"},
+	}
+
+	result, err := httpReqParseToJmx("localhost", "8080", httpReqs)
+	require.NoError(t, err)
+
+	var plan struct {
+		Transactions []struct {
+			Name string `xml:"testname,attr"`
+		} `xml:"TransactionController"`
+		HashTrees []struct {
+			Inner string `xml:",innerxml"`
+		} `xml:"hashTree"`
+		Samplers []struct {
+			Name string `xml:"testname,attr"`
+		} `xml:"HTTPSamplerProxy"`
+	}
+	require.NoError(t, xml.Unmarshal([]byte("<root>"+result+"</root>"), &plan))
+
+	require.Len(t, plan.Transactions, 1)
+	require.Equal(t, "auth", plan.Transactions[0].Name)
+	require.Len(t, plan.HashTrees, 2)
+	require.Equal(t, 2, strings.Count(plan.HashTrees[0].Inner, "<HTTPSamplerProxy"))
+	require.Contains(t, plan.HashTrees[0].Inner, "<stringProp name=\"ConstantTimer.delay\">500</stringProp>")
+
+	require.Len(t, plan.Samplers, 1)
+	require.Equal(t, "GET Request", plan.Samplers[0].Name)
+
+	require.Contains(t, result, `testname="login"`)
+	require.Contains(t, result, "<stringProp name=\"JSONPostProcessor.jsonPathExprs\">$.token</stringProp>")
+	require.Contains(t, result, "<stringProp name=\"JSONPostProcessor.match_numbers\">1</stringProp>")
+	require.Contains(t, result, "<stringProp name=\"RegexExtractor.useHeaders\">true</stringProp>")
+	require.Contains(t, result, "<stringProp name=\"RegexExtractor.template\">$1$</stringProp>")
+	require.Contains(t, result, "<stringProp name=\"HTTPSampler.path\">/items/${userId}</stringProp>")
+}
+
+func TestValidateRunLoadTestParam(t *testing.T) {
+	valid := RunLoadTestParam{HttpReqs: []RunLoadTestHttpParam{{Method: "put", RedirectPolicy: "auto"}}}
+	require.NoError(t, validateRunLoadTestParam(valid))
+
+	invalids := []RunLoadTestHttpParam{
+		{Method: "CONNECT"},
+		{Method: "GET", Auth: &RunLoadTestHttpAuthParam{Type: "digest"}},
+		{Method: "GET", Auth: &RunLoadTestHttpAuthParam{Type: "bearer"}},
+		{Method: "GET", RedirectPolicy: "sometimes"},
+		{Method: "GET", ResponseTimeout: -1},
+		{Method: "GET", ThinkTime: -1},
+		{Method: "GET", Extractors: []RunLoadTestExtractorParam{{Type: "xpath", Variable: "v", Expression: "/a"}}},
+		{Method: "GET", Extractors: []RunLoadTestExtractorParam{{Type: "regex", Variable: "1v", Expression: "a"}}},
+		{Method: "GET", Extractors: []RunLoadTestExtractorParam{{Type: "jsonpath", Variable: "v"}}},
+		{Method: "GET", Extractors: []RunLoadTestExtractorParam{{Type: "jsonpath", Variable: "v", Expression: "$.id", MatchNumber: -1}}},
+		{Method: "GET", Extractors: []RunLoadTestExtractorParam{{Type: "regex", Variable: "v", Expression: "a", Field: "url"}}},
+	}
+	for _, h := range invalids {
+		require.Error(t, validateRunLoadTestParam(RunLoadTestParam{HttpReqs: []RunLoadTestHttpParam{h}}), h)
+	}
+}
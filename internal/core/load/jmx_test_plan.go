@@ -0,0 +1,192 @@
+package load
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+const (
+	perfmonCollectorsTemplatePath = "/test_plan/perfmon_collectors.jmx"
+	perfmonCollectorsTemplateName = "perfmonCollectors"
+)
+
+// jmxTestPlanInfo is what the plan library needs to know about the uploaded test plan.
+type jmxTestPlanInfo struct {
+	ThreadGroupCount int
+	DataFileNames    []string
+}
+
+// jmxInjectionData is used to rewrite the stored test plan for a load test.
+type jmxInjectionData struct {
+	LoadTestKey       string
+	DataDir           string
+	ResultDir         string
+	PerfmonCollectors string
+}
+
+type jmxElement struct {
+	name      string
+	testclass string
+	propName  string
+}
+
+func newJmxElement(t xml.StartElement) jmxElement {
+	e := jmxElement{name: t.Name.Local}
+	for _, a := range t.Attr {
+		switch a.Name.Local {
+		case "testclass":
+			e.testclass = a.Value
+		case "name":
+			e.propName = a.Value
+		}
+	}
+	return e
+}
+
+// filenameOwner returns the kind of the element which owns the filename property on the top of the stack.
+// It is "csv" for the csv data set config, "listener" for the result collectors and empty for the others.
+func filenameOwner(stack []jmxElement) string {
+	if len(stack) < 2 {
+		return ""
+	}
+
+	top := stack[len(stack)-1]
+	if top.name != "stringProp" || top.propName != "filename" {
+		return ""
+	}
+
+	parent := stack[len(stack)-2]
+	switch {
+	case parent.testclass == "CSVDataSet":
+		return "csv"
+	case parent.name == "ResultCollector" || strings.HasSuffix(parent.testclass, "Collector"):
+		return "listener"
+	}
+	return ""
+}
+
+// inspectJmxTestPlan checks that the content is a well-formed jmeter test plan with at least one thread group
+// and returns the file names which are referenced by the csv data set configs.
+func inspectJmxTestPlan(content []byte) (jmxTestPlanInfo, error) {
+	var info jmxTestPlanInfo
+	var stack []jmxElement
+	var hasTestPlan bool
+
+	d := xml.NewDecoder(bytes.NewReader(content))
+	for {
+		token, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return info, fmt.Errorf("test plan is not a valid xml; %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if len(stack) == 0 && t.Name.Local != "jmeterTestPlan" {
+				return info, errors.New("root element of the test plan must be jmeterTestPlan")
+			}
+
+			e := newJmxElement(t)
+			if e.name == "TestPlan" {
+				hasTestPlan = true
+			}
+			if strings.HasSuffix(e.testclass, "ThreadGroup") {
+				info.ThreadGroupCount++
+			}
+			stack = append(stack, e)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if filenameOwner(stack) != "csv" {
+				continue
+			}
+			if name := strings.TrimSpace(string(t)); name != "" {
+				info.DataFileNames = append(info.DataFileNames, name)
+			}
+		}
+	}
+
+	if !hasTestPlan {
+		return info, errors.New("test plan element is not found")
+	}
+
+	if info.ThreadGroupCount == 0 {
+		return info, errors.New("test plan has no thread group")
+	}
+
+	return info, nil
+}
+
+// dataFileBaseName returns the file name of the path referenced by the csv data set config.
+// Names which contain jmeter variables or functions can not be resolved and are returned as empty.
+func dataFileBaseName(name string) string {
+	if strings.Contains(name, "${") {
+		return ""
+	}
+	return path.Base(strings.ReplaceAll(strings.TrimSpace(name), "\\", "/"))
+}
+
+// injectJmxTestPlan writes the stored test plan with the file names bound to the load test.
+// Csv data set configs read the data files from the data directory of the load test,
+// result collectors write into the result directory with the load test key,
+// and the perfmon collectors are appended to the test plan when they are given.
+func injectJmxTestPlan(w io.Writer, content []byte, data jmxInjectionData) error {
+	var stack []jmxElement
+	var listenerCount int
+	var injected bool
+
+	d := xml.NewDecoder(bytes.NewReader(content))
+	enc := xml.NewEncoder(w)
+
+	for {
+		token, err := d.RawToken()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("test plan is not a valid xml; %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			stack = append(stack, newJmxElement(t))
+		case xml.EndElement:
+			// jmeterTestPlan > hashTree > hashTree holds the elements of the test plan
+			if len(stack) == 3 && t.Name.Local == "hashTree" && !injected && data.PerfmonCollectors != "" {
+				if err := enc.Flush(); err != nil {
+					return err
+				}
+				if _, err := io.WriteString(w, data.PerfmonCollectors); err != nil {
+					return err
+				}
+				injected = true
+			}
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			switch filenameOwner(stack) {
+			case "csv":
+				if name := dataFileBaseName(string(t)); name != "" {
+					token = xml.CharData(fmt.Sprintf("%s/%s", data.DataDir, name))
+				}
+			case "listener":
+				if strings.TrimSpace(string(t)) != "" {
+					listenerCount++
+					token = xml.CharData(fmt.Sprintf("%s/%s_listener_%d.csv", data.ResultDir, data.LoadTestKey, listenerCount))
+				}
+			}
+		}
+
+		if err := enc.EncodeToken(token); err != nil {
+			return err
+		}
+	}
+
+	return enc.Flush()
+}
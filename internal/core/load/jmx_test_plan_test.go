@@ -0,0 +1,79 @@
+package load
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const sampleJmxTestPlan = `<?xml version="1.0" encoding="UTF-8"?>
+<jmeterTestPlan version="1.2" properties="5.0" jmeter="5.6">
+  <hashTree>
+    <TestPlan guiclass="TestPlanGui" testclass="TestPlan" testname="sample" enabled="true"/>
+    <hashTree>
+      <ThreadGroup guiclass="ThreadGroupGui" testclass="ThreadGroup" testname="users" enabled="true">
+        <stringProp name="ThreadGroup.num_threads">10</stringProp>
+      </ThreadGroup>
+      <hashTree>
+        <CSVDataSet guiclass="TestBeanGUI" testclass="CSVDataSet" testname="accounts" enabled="true">
+          <stringProp name="filename">C:\data\accounts.csv</stringProp>
+          <stringProp name="variableNames">user,password</stringProp>
+        </CSVDataSet>
+        <hashTree/>
+        <CSVDataSet guiclass="TestBeanGUI" testclass="CSVDataSet" testname="items" enabled="true">
+          <stringProp name="filename">${__P(items)}</stringProp>
+        </CSVDataSet>
+        <hashTree/>
+      </hashTree>
+      <ResultCollector guiclass="SummaryReport" testclass="ResultCollector" testname="summary" enabled="true">
+        <stringProp name="filename">/tmp/summary.csv</stringProp>
+      </ResultCollector>
+      <hashTree/>
+    </hashTree>
+  </hashTree>
+</jmeterTestPlan>`
+
+func TestInspectJmxTestPlan(t *testing.T) {
+	info, err := inspectJmxTestPlan([]byte(sampleJmxTestPlan))
+	require.NoError(t, err)
+	require.Equal(t, 1, info.ThreadGroupCount)
+	require.Equal(t, []string{`C:\data\accounts.csv`, "${__P(items)}"}, info.DataFileNames)
+	require.Equal(t, "accounts.csv", dataFileBaseName(info.DataFileNames[0]))
+	require.Empty(t, dataFileBaseName(info.DataFileNames[1]))
+
+	invalids := []string{
+		`<jmeterTestPlan><hashTree><TestPlan/></hashTree>`,
+		`<testPlan><hashTree/></testPlan>`,
+		`<jmeterTestPlan><hashTree><TestPlan/><hashTree/></hashTree></jmeterTestPlan>`,
+		`<jmeterTestPlan><hashTree><ThreadGroup testclass="ThreadGroup"/></hashTree></jmeterTestPlan>`,
+	}
+	for _, c := range invalids {
+		_, err := inspectJmxTestPlan([]byte(c))
+		require.Error(t, err, c)
+	}
+}
+
+func TestInjectJmxTestPlan(t *testing.T) {
+	var buf bytes.Buffer
+	err := injectJmxTestPlan(&buf, []byte(sampleJmxTestPlan), jmxInjectionData{
+		LoadTestKey:       "key",
+		DataDir:           "/opt/ant/test_plan/key_data",
+		ResultDir:         "/opt/ant/result",
+		PerfmonCollectors: `<PerfMonCollector testname="cpu collector"/><hashTree/>`,
+	})
+	require.NoError(t, err)
+
+	result := buf.String()
+	require.Contains(t, result, `<stringProp name="filename">/opt/ant/test_plan/key_data/accounts.csv</stringProp>`)
+	require.Contains(t, result, `<stringProp name="filename">${__P(items)}</stringProp>`)
+	require.Contains(t, result, `<stringProp name="filename">/opt/ant/result/key_listener_1.csv</stringProp>`)
+	require.Equal(t, 1, strings.Count(result, "cpu collector"))
+
+	// perfmon collectors are placed at the end of the test plan elements
+	require.Regexp(t, `</ResultCollector>\s*<hashTree></hashTree>\s*<PerfMonCollector testname="cpu collector"/><hashTree/></hashTree>`, result)
+
+	_, err = inspectJmxTestPlan(buf.Bytes())
+	require.NoError(t, err)
+}
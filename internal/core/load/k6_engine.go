@@ -0,0 +1,502 @@
+package load
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/cloud-barista/cm-ant/internal/config"
+	"github.com/cloud-barista/cm-ant/internal/core/common/constant"
+	"github.com/cloud-barista/cm-ant/internal/utils"
+)
+
+const (
+	defaultK6Version   = "0.54.0"
+	k6DefaultMaxVUs    = 1000
+	k6TemplatePath     = "/test_plan/default_k6.js"
+	k6DurationMetric   = "http_req_duration"
+	k6WaitingMetric    = "http_req_waiting"
+	k6ConnectingMetric = "http_req_connecting"
+)
+
+// k6Engine runs the script generated from the http requests with grafana k6.
+// Stored jmx test plans and the distributed mode are not supported.
+type k6Engine struct{}
+
+type k6TemplateData struct {
+	Scenario string
+	Steps    string
+	Cookies  string
+}
+
+// k6Scenario is the ramping-vus scenario of the closed model or the ramping-arrival-rate scenario of the open model.
+type k6Scenario struct {
+	Executor         string            `json:"executor"`
+	StartVUs         *int              `json:"startVUs,omitempty"`
+	StartRate        *int              `json:"startRate,omitempty"`
+	TimeUnit         string            `json:"timeUnit,omitempty"`
+	PreAllocatedVUs  int               `json:"preAllocatedVUs,omitempty"`
+	MaxVUs           int               `json:"maxVUs,omitempty"`
+	Stages           []k6Stage         `json:"stages"`
+	GracefulRampDown string            `json:"gracefulRampDown,omitempty"`
+	Tags             map[string]string `json:"tags"`
+}
+
+type k6Stage struct {
+	Duration string `json:"duration"`
+	Target   int    `json:"target"`
+}
+
+type k6Step struct {
+	Label       string               `json:"label"`
+	Transaction string               `json:"transaction"`
+	Method      string               `json:"method"`
+	URL         string               `json:"url"`
+	Body        *string              `json:"body"`
+	Headers     []k6NameValue        `json:"headers"`
+	Timeout     int                  `json:"timeout"`
+	Redirects   *int                 `json:"redirects"`
+	ThinkTime   int                  `json:"thinkTime"`
+	Extractors  []k6ExtractorElement `json:"extractors"`
+}
+
+type k6NameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type k6Cookie struct {
+	URL   string `json:"url"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type k6ExtractorElement struct {
+	Type         string `json:"type"`
+	Variable     string `json:"variable"`
+	Expression   string `json:"expression"`
+	MatchNumber  int    `json:"matchNumber"`
+	DefaultValue string `json:"defaultValue"`
+	Template     string `json:"template"`
+	UseHeaders   bool   `json:"useHeaders"`
+}
+
+func k6Version() string {
+	if v := config.AppConfig.Load.K6.Version; v != "" {
+		return v
+	}
+	return defaultK6Version
+}
+
+func k6BinaryPath(installPath string) string {
+	return fmt.Sprintf("%s/k6-v%s-linux-amd64/k6", installPath, k6Version())
+}
+
+func (k6Engine) Type() constant.LoadGeneratorType {
+	return constant.K6
+}
+
+func (k6Engine) InstallScript(installPath string) (string, []string) {
+	return utils.JoinRootPathWith("/script/install-k6.sh"), []string{
+		fmt.Sprintf("K6_WORK_DIR=%s", installPath),
+		fmt.Sprintf("K6_VERSION=%s", k6Version()),
+	}
+}
+
+func (k6Engine) Installed(installPath string) bool {
+	return utils.ExistCheck(k6BinaryPath(installPath))
+}
+
+func (k6Engine) PlanFileName(loadTestKey string) string {
+	return fmt.Sprintf("%s.js", loadTestKey)
+}
+
+func (k6Engine) RenderPlan(w io.Writer, param RunLoadTestParam, _ *LoadGeneratorInstallInfo, plan *LoadTestPlan) error {
+	if plan != nil {
+		return errors.New("stored test plans are only supported by the jmeter engine")
+	}
+
+	if param.AgentHostname != "" {
+		utils.LogWarnf("Perfmon metrics of load test %s are not collected by the k6 engine", param.LoadTestKey)
+	}
+
+	data, err := k6ScriptData(param)
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := template.ParseFiles(utils.JoinRootPathWith(k6TemplatePath))
+	if err != nil {
+		return err
+	}
+
+	return tmpl.Execute(w, data)
+}
+
+// ExecutionCmd runs k6 with the csv output whose timestamps are in milliseconds like the jmeter result.
+func (e k6Engine) ExecutionCmd(loadGeneratorInstallInfo *LoadGeneratorInstallInfo, loadTestKey string, _ []string) string {
+	installPath := loadGeneratorInstallInfo.InstallPath
+	testPath := fmt.Sprintf("%s/test_plan/%s", installPath, e.PlanFileName(loadTestKey))
+	resultPath := fmt.Sprintf("%s/result/%s", installPath, resultFileNameOf(loadTestKey))
+
+	var builder strings.Builder
+	builder.WriteString("K6_CSV_TIME_FORMAT=unix_milli ")
+	builder.WriteString(k6BinaryPath(installPath))
+	builder.WriteString(" run --quiet --no-usage-report")
+	builder.WriteString(fmt.Sprintf(" --out csv=%s", resultPath))
+	builder.WriteString(fmt.Sprintf(" %s", testPath))
+	builder.WriteString(fmt.Sprintf(" && sudo rm %s", testPath))
+
+	utils.LogInfof("K6 execution command generated: %s", builder.String())
+	return builder.String()
+}
+
+func k6ProcessRegex(loadTestKey string) string {
+	return fmt.Sprintf("'k6 run.*%s'", loadTestKey)
+}
+
+func (k6Engine) KillCmd(loadTestKey string) string {
+	grepRegex := k6ProcessRegex(loadTestKey)
+	utils.LogInfof("Generating kill command for load test key: %s", loadTestKey)
+	return fmt.Sprintf("ps -ef | grep -E %s | grep -v grep | awk '{print $2}' | xargs -r kill -15", grepRegex)
+}
+
+func (k6Engine) RunningCheckCmd(loadTestKey string) string {
+	return engineRunningCheckCmd(k6ProcessRegex(loadTestKey))
+}
+
+func (k6Engine) ParseResult(filePath string) (map[string][]*ResultRawData, error) {
+	return appendK6ResultRawData(filePath)
+}
+
+func (k6Engine) ParseResultRows(csvRows [][]string) (map[string][]*ResultRawData, error) {
+	return k6ResultRawDataOf(csvRows)
+}
+
+func (k6Engine) SupportsDistributed() bool {
+	return false
+}
+
+// k6ScriptData converts the load test parameter into the json values used by the k6 script template.
+func k6ScriptData(param RunLoadTestParam) (k6TemplateData, error) {
+	var data k6TemplateData
+
+	scenario, err := k6ScenarioOf(param)
+	if err != nil {
+		return data, err
+	}
+
+	steps := make([]k6Step, 0, len(param.HttpReqs))
+	cookies := make([]k6Cookie, 0)
+
+	for _, req := range param.HttpReqs {
+		method := strings.ToUpper(req.Method)
+		hasBody, ok := supportedHttpMethods[method]
+		if !ok {
+			return data, fmt.Errorf("http method %q is not supported", req.Method)
+		}
+
+		h := req.Hostname
+		if h == "" {
+			h = param.Hostname
+		}
+
+		baseUrl := fmt.Sprintf("%s://%s", req.Protocol, h)
+		if p := req.Port; p != "" {
+			baseUrl += ":" + p
+		} else if param.Port != "" {
+			baseUrl += ":" + param.Port
+		}
+
+		path := req.Path
+		if path != "" && !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+
+		step := k6Step{
+			Label:       method + " Request",
+			Transaction: req.Transaction,
+			Method:      method,
+			URL:         baseUrl + path,
+			Headers:     make([]k6NameValue, 0),
+			Timeout:     defaultHttpTimeout,
+			ThinkTime:   req.ThinkTime,
+			Extractors:  make([]k6ExtractorElement, 0),
+		}
+
+		if req.Name != "" {
+			step.Label = req.Name
+		}
+
+		if hasBody {
+			body := req.BodyData
+			step.Body = &body
+		}
+
+		if req.ResponseTimeout > 0 {
+			step.Timeout = req.ResponseTimeout
+		}
+
+		if strings.EqualFold(req.RedirectPolicy, redirectNone) {
+			none := 0
+			step.Redirects = &none
+		}
+
+		headers := req.Headers
+		if req.ContentType != "" && !hasHeader(headers, "Content-Type") {
+			headers = append(headers, RunLoadTestHttpHeaderParam{Name: "Content-Type", Value: req.ContentType})
+		}
+
+		if req.Auth != nil {
+			switch strings.ToLower(req.Auth.Type) {
+			case httpAuthBasic:
+				credential := base64.StdEncoding.EncodeToString([]byte(req.Auth.Username + ":" + req.Auth.Password))
+				headers = append(headers, RunLoadTestHttpHeaderParam{Name: "Authorization", Value: "Basic " + credential})
+			case httpAuthBearer:
+				headers = append(headers, RunLoadTestHttpHeaderParam{Name: "Authorization", Value: "Bearer " + req.Auth.Token})
+			}
+		}
+
+		for _, header := range headers {
+			step.Headers = append(step.Headers, k6NameValue{Name: header.Name, Value: header.Value})
+		}
+
+		for _, e := range req.Extractors {
+			// values of the jmx extractor are escaped for xml, so only the defaulted settings are taken
+			x := extractorParseToJmx(e)
+			extractor := k6ExtractorElement{
+				Type:         x.Type,
+				Variable:     e.Variable,
+				Expression:   e.Expression,
+				MatchNumber:  x.MatchNumber,
+				DefaultValue: e.DefaultValue,
+				Template:     e.Template,
+				UseHeaders:   x.UseHeaders,
+			}
+
+			if extractor.Template == "" {
+				extractor.Template = "$1$"
+			}
+
+			step.Extractors = append(step.Extractors, extractor)
+		}
+
+		for _, c := range req.Cookies {
+			cookies = append(cookies, k6Cookie{URL: baseUrl, Name: c.Name, Value: c.Value})
+		}
+
+		steps = append(steps, step)
+	}
+
+	scenarioJson, err := json.Marshal(scenario)
+	if err != nil {
+		return data, err
+	}
+
+	stepsJson, err := json.Marshal(steps)
+	if err != nil {
+		return data, err
+	}
+
+	cookiesJson, err := json.Marshal(cookies)
+	if err != nil {
+		return data, err
+	}
+
+	data.Scenario = string(scenarioJson)
+	data.Steps = string(stepsJson)
+	data.Cookies = string(cookiesJson)
+
+	return data, nil
+}
+
+// k6ScenarioOf converts the load of the test into the k6 scenario. The stages of the load profile are used as they are,
+// and the ramp up of the jmeter concurrency thread group is reproduced with the stages of the ramping-vus executor.
+func k6ScenarioOf(param RunLoadTestParam) (k6Scenario, error) {
+	zero := 0
+	scenario := k6Scenario{
+		Executor:         "ramping-vus",
+		StartVUs:         &zero,
+		GracefulRampDown: "0s",
+		Tags:             map[string]string{"test_name": param.TestName},
+	}
+
+	if p := param.LoadProfile; p != nil {
+		maxTarget := 0
+		for _, s := range p.Stages {
+			scenario.Stages = append(scenario.Stages, k6Stage{Duration: fmt.Sprintf("%ds", s.Duration), Target: s.Target})
+			maxTarget = max(maxTarget, s.Target)
+		}
+
+		if p.isOpen() {
+			scenario.Executor = "ramping-arrival-rate"
+			scenario.StartVUs = nil
+			scenario.StartRate = &zero
+			scenario.TimeUnit = "1s"
+			scenario.GracefulRampDown = ""
+			scenario.MaxVUs = p.MaxVirtualUsers
+			if scenario.MaxVUs == 0 {
+				scenario.MaxVUs = k6DefaultMaxVUs
+			}
+			scenario.PreAllocatedVUs = min(max(maxTarget, 1), scenario.MaxVUs)
+		}
+
+		return scenario, nil
+	}
+
+	vus, err := strconv.Atoi(param.VirtualUsers)
+	if err != nil {
+		return scenario, fmt.Errorf("virtual users must be number; %w", err)
+	}
+
+	duration, err := strconv.Atoi(param.Duration)
+	if err != nil {
+		return scenario, fmt.Errorf("duration must be number; %w", err)
+	}
+
+	rampUpTime, err := strconv.Atoi(param.RampUpTime)
+	if err != nil {
+		return scenario, fmt.Errorf("ramp up time must be number; %w", err)
+	}
+
+	rampUpSteps, err := strconv.Atoi(param.RampUpSteps)
+	if err != nil || rampUpSteps < 1 {
+		rampUpSteps = 1
+	}
+
+	if rampUpTime > 0 {
+		for i := 1; i <= rampUpSteps; i++ {
+			scenario.Stages = append(scenario.Stages, k6Stage{
+				Duration: fmt.Sprintf("%dms", rampUpTime*1000/rampUpSteps),
+				Target:   vus * i / rampUpSteps,
+			})
+		}
+	} else {
+		scenario.Stages = append(scenario.Stages, k6Stage{Duration: "0s", Target: vus})
+	}
+	scenario.Stages = append(scenario.Stages, k6Stage{Duration: fmt.Sprintf("%ds", duration), Target: vus})
+
+	return scenario, nil
+}
+
+// appendK6ResultRawData reads the csv output of k6 whose rows are the samples of each metric.
+// A request is made of the http_req_duration sample, and the waiting and connecting samples
+// which follow it with the same label and timestamp are used as its latency and connect time.
+func appendK6ResultRawData(filePath string) (map[string][]*ResultRawData, error) {
+	csvRows, err := utils.ReadCSV(filePath)
+	if err != nil || csvRows == nil {
+		return nil, err
+	}
+
+	return k6ResultRawDataOf(*csvRows)
+}
+
+// k6ResultRawDataOf groups the requests of the k6 csv rows by label. The first row is the header.
+func k6ResultRawDataOf(csvRows [][]string) (map[string][]*ResultRawData, error) {
+	var resultMap = make(map[string][]*ResultRawData)
+
+	if len(csvRows) <= 1 {
+		return nil, errors.New("result data file is empty")
+	}
+
+	columns := make(map[string]int)
+	for i, name := range csvRows[0] {
+		columns[name] = i
+	}
+
+	for _, c := range []string{"metric_name", "timestamp", "metric_value", "name"} {
+		if _, ok := columns[c]; !ok {
+			return nil, fmt.Errorf("column %s is not found in the k6 result", c)
+		}
+	}
+
+	value := func(row []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return row[i]
+	}
+
+	last := make(map[string]*ResultRawData)
+	no := 0
+
+	for i, row := range csvRows[1:] {
+		metric := value(row, "metric_name")
+		if metric != k6DurationMetric && metric != k6WaitingMetric && metric != k6ConnectingMetric {
+			continue
+		}
+
+		metricValue, err := strconv.ParseFloat(value(row, "metric_value"), 64)
+		if err != nil {
+			log.Printf("[%d] metric value has error %s\n", i, err)
+			continue
+		}
+
+		t, err := parseK6Timestamp(value(row, "timestamp"))
+		if err != nil {
+			log.Printf("[%d] time has error %s\n", i, err)
+			continue
+		}
+
+		label := value(row, "name")
+		elapsed := int(math.Round(metricValue))
+
+		if metric != k6DurationMetric {
+			r, ok := last[label]
+			if !ok || !r.Timestamp.Equal(t) {
+				continue
+			}
+
+			if metric == k6WaitingMetric {
+				r.Latency = elapsed
+			} else {
+				r.Connection = elapsed
+			}
+			continue
+		}
+
+		status := value(row, "status")
+		isError := value(row, "expected_response") == "false"
+		if _, ok := columns["expected_response"]; !ok {
+			code, _ := strconv.Atoi(status)
+			isError = code == 0 || code >= 400
+		}
+
+		r := &ResultRawData{
+			No:        no,
+			Elapsed:   elapsed,
+			URL:       value(row, "url"),
+			IsError:   isError,
+			Timestamp: t,
+		}
+		no++
+
+		last[label] = r
+		resultMap[label] = append(resultMap[label], r)
+	}
+
+	return resultMap, nil
+}
+
+// parseK6Timestamp parses the timestamp of the k6 csv output which is in seconds by default
+// and in milliseconds when the time format is unix_milli.
+func parseK6Timestamp(v string) (time.Time, error) {
+	ts, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return time.Parse(time.RFC3339Nano, v)
+	}
+
+	if ts < 1e11 {
+		return time.Unix(ts, 0), nil
+	}
+	return time.UnixMilli(ts), nil
+}
@@ -0,0 +1,146 @@
+package load
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloud-barista/cm-ant/internal/core/common/constant"
+	"github.com/stretchr/testify/require"
+)
+
+func TestK6ScriptData(t *testing.T) {
+	param := RunLoadTestParam{
+		TestName:     "k6 test",
+		VirtualUsers: "10",
+		Duration:     "60",
+		RampUpTime:   "10",
+		RampUpSteps:  "2",
+		Hostname:     "example.com",
+		Port:         "8080",
+		HttpReqs: []RunLoadTestHttpParam{
+			{
+				Method:         "post",
+				Protocol:       "http",
+				Path:           "login",
+				BodyData:       `{"user":"ant"}`,
+				ContentType:    "application/json",
+				RedirectPolicy: "none",
+				Name:           "login",
+				Transaction:    "auth",
+				Auth:           &RunLoadTestHttpAuthParam{Type: "basic", Username: "ant", Password: "pw"},
+				Cookies:        []RunLoadTestHttpCookieParam{{Name: "session", Value: "1"}},
+				Extractors: []RunLoadTestExtractorParam{
+					{Type: "jsonpath", Variable: "token", Expression: "$.token"},
+				},
+			},
+			{
+				Method:    "GET",
+				Protocol:  "https",
+				Hostname:  "api.example.com",
+				Port:      "443",
+				Path:      "/items",
+				ThinkTime: 500,
+			},
+		},
+	}
+
+	data, err := k6ScriptData(param)
+	require.NoError(t, err)
+
+	var scenario k6Scenario
+	require.NoError(t, json.Unmarshal([]byte(data.Scenario), &scenario))
+	require.Equal(t, "ramping-vus", scenario.Executor)
+	require.Equal(t, "k6 test", scenario.Tags["test_name"])
+	require.Equal(t, []k6Stage{
+		{Duration: "5000ms", Target: 5},
+		{Duration: "5000ms", Target: 10},
+		{Duration: "60s", Target: 10},
+	}, scenario.Stages)
+
+	var steps []k6Step
+	require.NoError(t, json.Unmarshal([]byte(data.Steps), &steps))
+	require.Len(t, steps, 2)
+
+	login := steps[0]
+	require.Equal(t, "login", login.Label)
+	require.Equal(t, "auth", login.Transaction)
+	require.Equal(t, "POST", login.Method)
+	require.Equal(t, "http://example.com:8080/login", login.URL)
+	require.Equal(t, `{"user":"ant"}`, *login.Body)
+	require.Equal(t, 0, *login.Redirects)
+	require.Equal(t, []k6NameValue{
+		{Name: "Content-Type", Value: "application/json"},
+		{Name: "Authorization", Value: "Basic YW50OnB3"},
+	}, login.Headers)
+	require.Equal(t, []k6ExtractorElement{
+		{Type: "jsonpath", Variable: "token", Expression: "$.token", MatchNumber: 1, Template: "$1$"},
+	}, login.Extractors)
+
+	items := steps[1]
+	require.Equal(t, "GET Request", items.Label)
+	require.Equal(t, "https://api.example.com:443/items", items.URL)
+	require.Nil(t, items.Body)
+	require.Nil(t, items.Redirects)
+	require.Equal(t, 500, items.ThinkTime)
+
+	var cookies []k6Cookie
+	require.NoError(t, json.Unmarshal([]byte(data.Cookies), &cookies))
+	require.Equal(t, []k6Cookie{{URL: "http://example.com:8080", Name: "session", Value: "1"}}, cookies)
+
+	param.VirtualUsers = "ten"
+	_, err = k6ScriptData(param)
+	require.Error(t, err)
+
+	param.LoadProfile = &LoadProfileParam{
+		Model:  "open",
+		Stages: []LoadStageParam{{Duration: 30, Target: 50}, {Duration: 0, Target: 200}, {Duration: 10, Target: 200}},
+	}
+	scenario, err = k6ScenarioOf(param)
+	require.NoError(t, err)
+	require.Equal(t, "ramping-arrival-rate", scenario.Executor)
+	require.Equal(t, 0, *scenario.StartRate)
+	require.Equal(t, k6DefaultMaxVUs, scenario.MaxVUs)
+	require.Equal(t, 200, scenario.PreAllocatedVUs)
+	require.Equal(t, []k6Stage{{Duration: "30s", Target: 50}, {Duration: "0s", Target: 200}, {Duration: "10s", Target: 200}}, scenario.Stages)
+}
+
+func TestAppendK6ResultRawData(t *testing.T) {
+	result := `metric_name,timestamp,metric_value,check,error,error_code,expected_response,group,method,name,proto,scenario,service,status,subproto,tls_version,url,extra_tags,metadata
+http_reqs,1700000000000,1.000000,,,,true,,GET,home,HTTP/1.1,default,,200,,,http://example.com/,,
+http_req_duration,1700000000000,12.400000,,,,true,,GET,home,HTTP/1.1,default,,200,,,http://example.com/,,
+http_req_connecting,1700000000000,2.000000,,,,true,,GET,home,HTTP/1.1,default,,200,,,http://example.com/,,
+http_req_waiting,1700000000000,10.600000,,,,true,,GET,home,HTTP/1.1,default,,200,,,http://example.com/,,
+http_req_duration,1700000001000,30.000000,,,,false,,GET,home,HTTP/1.1,default,,500,,,http://example.com/,,
+http_req_duration,1700000001000,5.000000,,,,true,,POST,login,HTTP/1.1,default,,200,,,http://example.com/login,,
+`
+	filePath := filepath.Join(t.TempDir(), "key_result.csv")
+	require.NoError(t, os.WriteFile(filePath, []byte(result), 0644))
+
+	resultMap, err := appendK6ResultRawData(filePath)
+	require.NoError(t, err)
+	require.Len(t, resultMap, 2)
+
+	home := resultMap["home"]
+	require.Len(t, home, 2)
+	require.Equal(t, 12, home[0].Elapsed)
+	require.Equal(t, 11, home[0].Latency)
+	require.Equal(t, 2, home[0].Connection)
+	require.Equal(t, int64(1700000000000), home[0].Timestamp.UnixMilli())
+	require.False(t, home[0].IsError)
+	require.True(t, home[1].IsError)
+	require.Zero(t, home[1].Latency)
+
+	login := resultMap["login"]
+	require.Len(t, login, 1)
+	require.Equal(t, "http://example.com/login", login[0].URL)
+}
+
+func TestWithInstalledEngine(t *testing.T) {
+	installType := withInstalledEngine("jmeter", constant.K6)
+	require.Equal(t, "jmeter,k6", installType)
+	require.Equal(t, installType, withInstalledEngine(installType, constant.K6))
+	require.Equal(t, []constant.LoadGeneratorType{constant.Jmeter, constant.K6}, installedEngines(installType))
+	require.Equal(t, "k6", withInstalledEngine("", constant.K6))
+}
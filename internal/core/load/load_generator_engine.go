@@ -0,0 +1,248 @@
+package load
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cloud-barista/cm-ant/internal/config"
+	"github.com/cloud-barista/cm-ant/internal/core/common/constant"
+	"github.com/cloud-barista/cm-ant/internal/utils"
+	"gorm.io/gorm"
+)
+
+// LoadGeneratorEngine is the tool which generates the load on the load generator.
+// The engine knows how to install itself, render the test plan of the load test,
+// run and stop the test and parse the result file written by the test.
+type LoadGeneratorEngine interface {
+	Type() constant.LoadGeneratorType
+
+	// InstallScript returns the path of the script which installs the engine under the install path
+	// and the environment variables passed to the script.
+	InstallScript(installPath string) (string, []string)
+
+	// Installed tells whether the engine is installed on the local load generator.
+	Installed(installPath string) bool
+
+	// PlanFileName returns the file name of the test plan under the test_plan folder of the install path.
+	PlanFileName(loadTestKey string) string
+
+	RenderPlan(w io.Writer, param RunLoadTestParam, loadGeneratorInstallInfo *LoadGeneratorInstallInfo, plan *LoadTestPlan) error
+
+	// ExecutionCmd returns the command which runs the test plan and writes the result file.
+	// remoteHosts is only given to the engines which support the distributed mode.
+	ExecutionCmd(loadGeneratorInstallInfo *LoadGeneratorInstallInfo, loadTestKey string, remoteHosts []string) string
+
+	KillCmd(loadTestKey string) string
+
+	// RunningCheckCmd returns the command which prints the engineRunningMarker while the engine runs the load test.
+	// The engines which run in process return empty since they do not outlive the ant process.
+	RunningCheckCmd(loadTestKey string) string
+
+	// ParseResult reads the result file and groups the samples by label.
+	ParseResult(filePath string) (map[string][]*ResultRawData, error)
+
+	// ParseResultRows groups the samples of the csv rows of the result file by label. The first row is the header.
+	ParseResultRows(csvRows [][]string) (map[string][]*ResultRawData, error)
+
+	SupportsDistributed() bool
+}
+
+var loadGeneratorEngines = map[constant.LoadGeneratorType]LoadGeneratorEngine{
+	constant.Jmeter: jmeterEngine{},
+	constant.K6:     k6Engine{},
+	constant.Native: nativeEngine{},
+}
+
+// getLoadGeneratorEngine returns the engine of the type. JMeter is used when the type is empty.
+func getLoadGeneratorEngine(t constant.LoadGeneratorType) (LoadGeneratorEngine, error) {
+	if t == "" {
+		t = constant.Jmeter
+	}
+
+	engine, ok := loadGeneratorEngines[constant.LoadGeneratorType(strings.ToLower(string(t)))]
+	if !ok {
+		return nil, fmt.Errorf("load generator engine %q is not supported", t)
+	}
+
+	return engine, nil
+}
+
+// loadTestEngine returns the engine which ran the load test.
+// JMeter is returned for the load tests which are run before the engine is recorded.
+func (l *LoadService) loadTestEngine(loadTestKey string) (LoadGeneratorEngine, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	engineType, err := l.loadRepo.GetLoadTestEngineTx(ctx, loadTestKey)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	return getLoadGeneratorEngine(engineType)
+}
+
+// ensureEngineInstalled installs the engine on the load generator when it is not installed yet.
+// JMeter is installed with the remote load generator, so nothing is done for it remotely.
+// The local load generator may be installed for the engines which run in process, so jmeter is checked locally.
+func (l *LoadService) ensureEngineInstalled(ctx context.Context, engine LoadGeneratorEngine, loadGeneratorInstallInfo *LoadGeneratorInstallInfo) error {
+	installPath := loadGeneratorInstallInfo.InstallPath
+	scriptPath, envs := engine.InstallScript(installPath)
+
+	if loadGeneratorInstallInfo.InstallLocation == constant.Local {
+		// the engine which runs in process has nothing to install
+		if scriptPath == "" {
+			return nil
+		}
+
+		if !engine.Installed(installPath) {
+			utils.LogInfof("Installing %s engine locally", engine.Type())
+			if err := utils.Script(scriptPath, envs); err != nil {
+				return fmt.Errorf("error while installing %s; %w", engine.Type(), err)
+			}
+		}
+
+		l.recordInstalledEngine(ctx, loadGeneratorInstallInfo, engine.Type())
+		return nil
+	}
+
+	if engine.Type() == constant.Jmeter {
+		return nil
+	}
+
+	script, err := utils.ReadToString(scriptPath)
+	if err != nil {
+		return err
+	}
+
+	// the install script skips the installation when the engine is already installed
+	installCmd := fmt.Sprintf("export %s\n%s", strings.Join(envs, " "), script)
+	_, err = l.commandToLoadGenerator(ctx, loadGeneratorInstallInfo, "", []string{installCmd})
+	if err != nil {
+		return fmt.Errorf("error while installing %s; %w", engine.Type(), err)
+	}
+
+	l.recordInstalledEngine(ctx, loadGeneratorInstallInfo, engine.Type())
+	return nil
+}
+
+// installedEngines returns the engines in the install type of the load generator, which are separated by commas.
+func installedEngines(installType string) []constant.LoadGeneratorType {
+	var engines []constant.LoadGeneratorType
+	for _, e := range strings.Split(installType, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			engines = append(engines, constant.LoadGeneratorType(e))
+		}
+	}
+	return engines
+}
+
+// withInstalledEngine returns the install type with the engine added when it is not in it yet.
+func withInstalledEngine(installType string, engineType constant.LoadGeneratorType) string {
+	engines := installedEngines(installType)
+	if slices.Contains(engines, engineType) {
+		return installType
+	}
+
+	names := make([]string, 0, len(engines)+1)
+	for _, e := range engines {
+		names = append(names, string(e))
+	}
+	return strings.Join(append(names, string(engineType)), ",")
+}
+
+// recordInstalledEngine adds the engine to the install type of the load generator, which records the engines installed on it.
+func (l *LoadService) recordInstalledEngine(ctx context.Context, loadGeneratorInstallInfo *LoadGeneratorInstallInfo, engineType constant.LoadGeneratorType) {
+	installType := withInstalledEngine(loadGeneratorInstallInfo.InstallType, engineType)
+	if installType == loadGeneratorInstallInfo.InstallType {
+		return
+	}
+
+	if err := l.loadRepo.UpdateLoadGeneratorInstallTypeTx(ctx, loadGeneratorInstallInfo.ID, installType); err != nil {
+		utils.LogWarnf("Error recording %s engine of load generator %d: %v", engineType, loadGeneratorInstallInfo.ID, err)
+		return
+	}
+	loadGeneratorInstallInfo.InstallType = installType
+}
+
+// engineRunningMarker is printed by the running check command of the engine.
+const engineRunningMarker = "engine_running"
+
+// engineRunningCheckCmd prints the marker when a process matches the regex. The marker is echoed in two pieces,
+// so the command itself which may be echoed back by the remote command does not contain the marker.
+func engineRunningCheckCmd(grepRegex string) string {
+	return fmt.Sprintf("(ps -ef | grep -E %s | grep -v grep > /dev/null && echo 'engine_''running') || true", grepRegex)
+}
+
+// jmeterEngine runs the jmx test plan with apache jmeter.
+type jmeterEngine struct{}
+
+func (jmeterEngine) Type() constant.LoadGeneratorType {
+	return constant.Jmeter
+}
+
+func (jmeterEngine) InstallScript(installPath string) (string, []string) {
+	return utils.JoinRootPathWith("/script/install-jmeter.sh"), []string{
+		fmt.Sprintf("JMETER_WORK_DIR=%s", installPath),
+		fmt.Sprintf("JMETER_VERSION=%s", config.AppConfig.Load.JMeter.Version),
+	}
+}
+
+func (jmeterEngine) Installed(installPath string) bool {
+	return utils.ExistCheck(installPath) && utils.ExistCheck(installPath+"/apache-jmeter-"+config.AppConfig.Load.JMeter.Version)
+}
+
+func (jmeterEngine) PlanFileName(loadTestKey string) string {
+	return fmt.Sprintf("%s.jmx", loadTestKey)
+}
+
+func (jmeterEngine) RenderPlan(w io.Writer, param RunLoadTestParam, loadGeneratorInstallInfo *LoadGeneratorInstallInfo, plan *LoadTestPlan) error {
+	return renderTestPlan(w, param, loadGeneratorInstallInfo, plan)
+}
+
+func (e jmeterEngine) ExecutionCmd(loadGeneratorInstallInfo *LoadGeneratorInstallInfo, loadTestKey string, remoteHosts []string) string {
+	var masterHostname string
+	for _, s := range loadGeneratorInstallInfo.LoadGeneratorServers {
+		if s.IsMaster {
+			masterHostname = s.PrivateIp
+		}
+	}
+
+	return generateJmeterExecutionCmd(
+		loadGeneratorInstallInfo.InstallPath,
+		loadGeneratorInstallInfo.InstallVersion,
+		e.PlanFileName(loadTestKey),
+		resultFileNameOf(loadTestKey),
+		masterHostname,
+		rmiPropertiesPath(loadGeneratorInstallInfo.InstallPath, loadTestKey),
+		remoteHosts,
+	)
+}
+
+func (jmeterEngine) KillCmd(loadTestKey string) string {
+	return killCmdGen(loadTestKey)
+}
+
+func (jmeterEngine) RunningCheckCmd(loadTestKey string) string {
+	return engineRunningCheckCmd(jmeterProcessRegex(loadTestKey))
+}
+
+func (jmeterEngine) ParseResult(filePath string) (map[string][]*ResultRawData, error) {
+	return appendResultRawData(filePath)
+}
+
+func (jmeterEngine) ParseResultRows(csvRows [][]string) (map[string][]*ResultRawData, error) {
+	return resultRawDataOf(csvRows)
+}
+
+func (jmeterEngine) SupportsDistributed() bool {
+	return true
+}
+
+func resultFileNameOf(loadTestKey string) string {
+	return fmt.Sprintf("%s_result.csv", loadTestKey)
+}
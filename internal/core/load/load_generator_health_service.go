@@ -0,0 +1,446 @@
+package load
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloud-barista/cm-ant/internal/config"
+	"github.com/cloud-barista/cm-ant/internal/core/common/constant"
+	"github.com/cloud-barista/cm-ant/internal/infra/outbound/tumblebug"
+	"github.com/cloud-barista/cm-ant/internal/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultHealthCheckInterval = 10 * time.Minute
+	defaultMinFreeDiskMb       = 1024
+
+	generatorHealthy     = "Running"
+	generatorUnhealthy   = "unhealthy"
+	generatorUnreachable = "unreachable"
+)
+
+// requiredJmeterPlugins are the plugins installed by install-jmeter.sh, which the test plans of ant rely on.
+var requiredJmeterPlugins = []string{"perfmon", "casutg"}
+
+// generatorHealthCmd prints the java and jmeter version, the free disk of the install path in MB and the missing plugins.
+// The marker is split in the command, so the echo of the command is not parsed as the health.
+func generatorHealthCmd(installPath, version string) string {
+	jmeterPath := fmt.Sprintf("%s/apache-jmeter-%s", installPath, version)
+
+	return fmt.Sprintf(`j=$(java -version 2>&1 | awk -F'"' '/version/ {print $2; exit}'); `+
+		`m=$([ -f %[2]s/lib/ApacheJMeter_core.jar ] && [ -x %[2]s/bin/jmeter.sh ] && echo %[3]s); `+
+		`d=$(df -Pm %[1]s 2>/dev/null | awk 'NR==2 {print $4}'); `+
+		`p=""; for n in %[4]s; do ls %[2]s/lib/ext/jmeter-plugins-$n-*.jar >/dev/null 2>&1 || p="$p,$n"; done; `+
+		`printf '%%s_%%s java=%%s jmeter=%%s disk=%%s missing=%%s\n' ant health "${j:--}" "${m:--}" "${d:-0}" "${p#,}"`,
+		installPath, jmeterPath, version, strings.Join(requiredJmeterPlugins, " "))
+}
+
+var generatorHealthRegex = regexp.MustCompile(`ant_health java=(\S+) jmeter=(\S+) disk=(\d+) missing=(\S*)`)
+
+type generatorHealth struct {
+	javaVersion    string
+	jmeterVersion  string
+	freeDiskMb     int
+	missingPlugins []string
+}
+
+func parseGeneratorHealth(out string) (generatorHealth, bool) {
+	var h generatorHealth
+
+	m := generatorHealthRegex.FindStringSubmatch(out)
+	if m == nil {
+		return h, false
+	}
+
+	if m[1] != "-" {
+		h.javaVersion = m[1]
+	}
+	if m[2] != "-" {
+		h.jmeterVersion = m[2]
+	}
+	h.freeDiskMb, _ = strconv.Atoi(m[3])
+	if m[4] != "" {
+		h.missingPlugins = strings.Split(m[4], ",")
+	}
+
+	return h, true
+}
+
+// problems returns the drift of the load generator from the installation.
+func (h generatorHealth) problems(installPath, version string, minFreeDiskMb int) []string {
+	var problems []string
+
+	if h.javaVersion == "" {
+		problems = append(problems, "java is not installed")
+	}
+
+	if h.jmeterVersion != version {
+		problems = append(problems, fmt.Sprintf("jmeter %s is not installed on %s", version, installPath))
+	}
+
+	if h.freeDiskMb < minFreeDiskMb {
+		problems = append(problems, fmt.Sprintf("free disk of %s is %dMB, less than %dMB", installPath, h.freeDiskMb, minFreeDiskMb))
+	}
+
+	if len(h.missingPlugins) > 0 {
+		problems = append(problems, fmt.Sprintf("jmeter plugins %s are missing", strings.Join(h.missingPlugins, ", ")))
+	}
+
+	return problems
+}
+
+func minFreeDiskMb() int {
+	minFree := config.AppConfig.Load.Health.MinFreeDiskMb
+	if minFree <= 0 {
+		minFree = defaultMinFreeDiskMb
+	}
+	return minFree
+}
+
+// VerifyLoadGenerator checks that the load generator is reachable and its jmeter installation has not drifted.
+// The status of each server is updated with the result, and jmeter is installed again on drift when reinstall is set.
+func (l *LoadService) VerifyLoadGenerator(loadGeneratorInstallInfoId uint, reinstall bool) (LoadGeneratorHealthResult, error) {
+	unlock := lockLoadGenerator(loadGeneratorInstallInfoId)
+	defer unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	info, err := l.loadRepo.GetValidLoadGeneratorInstallInfoByIdTx(ctx, loadGeneratorInstallInfoId)
+	cancel()
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return LoadGeneratorHealthResult{}, errors.New("cannot find valid load generator install info")
+		}
+		return LoadGeneratorHealthResult{}, err
+	}
+
+	return l.verifyLoadGenerator(&info, reinstall)
+}
+
+// verifyLoadGenerator checks the health of the load generator and saves it. The caller holds the lock of the load generator.
+func (l *LoadService) verifyLoadGenerator(info *LoadGeneratorInstallInfo, reinstall bool) (LoadGeneratorHealthResult, error) {
+	if info.LifecycleStatus == generatorSuspended {
+		return LoadGeneratorHealthResult{}, fmt.Errorf("load generator %d is suspended. resume it before the health check", info.ID)
+	}
+
+	result := l.checkLoadGeneratorHealth(info)
+
+	if !result.Healthy && reinstall && reinstallable(result) {
+		utils.LogWarnf("Reinstalling jmeter on load generator %d; %s", info.ID, strings.Join(result.Problems, "; "))
+
+		if err := l.reinstallLoadGenerator(info); err != nil {
+			result.Problems = append(result.Problems, fmt.Sprintf("failed to reinstall jmeter; %s", err))
+		} else {
+			result = l.checkLoadGeneratorHealth(info)
+			result.Reinstalled = true
+		}
+	}
+
+	for i := range info.LoadGeneratorServers {
+		for _, s := range result.Servers {
+			if s.ID == info.LoadGeneratorServers[i].ID {
+				info.LoadGeneratorServers[i].Status = s.Status
+			}
+		}
+	}
+
+	info.HealthMessage = strings.Join(result.Problems, "; ")
+	info.HealthCheckedAt = &result.CheckedAt
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	err := l.loadRepo.UpdateLoadGeneratorHealthTx(ctx, info)
+	if err != nil {
+		return result, err
+	}
+
+	if !result.Healthy {
+		utils.LogWarnf("Load generator %d is unhealthy; %s", info.ID, info.HealthMessage)
+	}
+
+	return result, nil
+}
+
+// reinstallable reports whether the problems are on the installation of the running vms, which the install script can fix.
+func reinstallable(result LoadGeneratorHealthResult) bool {
+	if len(result.Servers) == 0 {
+		return len(result.Problems) > 0
+	}
+
+	drifted := false
+	for _, s := range result.Servers {
+		if !s.Reachable {
+			return false
+		}
+		if len(s.Problems) > 0 {
+			drifted = true
+		}
+	}
+
+	return drifted
+}
+
+func (l *LoadService) checkLoadGeneratorHealth(info *LoadGeneratorInstallInfo) LoadGeneratorHealthResult {
+	result := LoadGeneratorHealthResult{
+		LoadGeneratorInstallInfoId: info.ID,
+		CheckedAt:                  time.Now(),
+	}
+
+	cmd := generatorHealthCmd(info.InstallPath, info.InstallVersion)
+
+	if info.InstallLocation == constant.Local {
+		out, err := utils.InlineCmdOutput(cmd)
+		h, ok := parseGeneratorHealth(out)
+		if !ok {
+			result.Problems = append(result.Problems, fmt.Sprintf("failed to check the local load generator; %s", commandFailure(out, err)))
+		} else {
+			result.Problems = h.problems(info.InstallPath, info.InstallVersion, minFreeDiskMb())
+		}
+
+		result.Healthy = len(result.Problems) == 0
+		return result
+	}
+
+	// the host registered by ssh has no vm on tumblebug, so it is only checked by ssh
+	vms := make(map[string]tumblebug.VmRes)
+	if info.InstallLocation != constant.Ssh {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		mci, err := l.tumblebugClient.GetMciWithContext(ctx, antNsId, info.mciId())
+		cancel()
+
+		if err != nil {
+			if !errors.Is(err, tumblebug.ErrNotFound) {
+				result.Problems = append(result.Problems, fmt.Sprintf("failed to get the vms of the load generator; %s", err))
+				return result
+			}
+		} else {
+			for _, vm := range mci.Vm {
+				vms[vm.Uid] = vm
+			}
+		}
+	}
+
+	for _, s := range info.LoadGeneratorServers {
+		sr := l.checkServerHealth(info, s, vms, cmd)
+
+		name := s.VmId
+		if name == "" {
+			name = s.PublicIp
+		}
+		for _, p := range sr.Problems {
+			result.Problems = append(result.Problems, fmt.Sprintf("%s: %s", name, p))
+		}
+		result.Servers = append(result.Servers, sr)
+	}
+
+	result.Healthy = len(result.Problems) == 0
+	return result
+}
+
+func (l *LoadService) checkServerHealth(info *LoadGeneratorInstallInfo, s LoadGeneratorServer, vms map[string]tumblebug.VmRes, cmd string) LoadGeneratorServerHealthResult {
+	sr := LoadGeneratorServerHealthResult{
+		ID:       s.ID,
+		VmId:     s.VmId,
+		PublicIp: s.PublicIp,
+	}
+
+	if info.InstallLocation != constant.Ssh {
+		vm, ok := vms[s.VmUid]
+		if !ok {
+			sr.Status = generatorTerminated
+			sr.Problems = append(sr.Problems, "vm is not found on the mci")
+			return sr
+		}
+
+		if !strings.EqualFold(vm.Status, generatorHealthy) {
+			sr.Status = vm.Status
+			sr.Problems = append(sr.Problems, fmt.Sprintf("vm is %s", vm.Status))
+			return sr
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	out, err := l.commandToLoadGenerator(ctx, info, s.VmId, []string{cmd})
+	cancel()
+
+	h, ok := parseGeneratorHealth(out)
+	if err != nil || !ok {
+		sr.Status = generatorUnreachable
+		sr.Problems = append(sr.Problems, fmt.Sprintf("server is not reachable by ssh; %s", commandFailure(out, err)))
+		return sr
+	}
+
+	sr.Reachable = true
+	sr.JavaVersion = h.javaVersion
+	sr.JmeterVersion = h.jmeterVersion
+	sr.FreeDiskMb = h.freeDiskMb
+	sr.MissingPlugins = h.missingPlugins
+	sr.Problems = h.problems(info.InstallPath, info.InstallVersion, minFreeDiskMb())
+
+	sr.Status = generatorHealthy
+	if len(sr.Problems) > 0 {
+		sr.Status = generatorUnhealthy
+	}
+
+	return sr
+}
+
+// commandFailure describes why the health is not printed by the command.
+func commandFailure(out string, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	out = strings.TrimSpace(out)
+	if len(out) > 200 {
+		out = out[:200] + "..."
+	}
+	return fmt.Sprintf("unexpected output %q", out)
+}
+
+// reinstallLoadGenerator runs the install script on the load generator again.
+func (l *LoadService) reinstallLoadGenerator(info *LoadGeneratorInstallInfo) error {
+	installScriptPath := utils.JoinRootPathWith("/script/install-jmeter.sh")
+
+	if info.InstallLocation == constant.Local {
+		return utils.Script(installScriptPath, []string{
+			fmt.Sprintf("JMETER_WORK_DIR=%s", info.InstallPath),
+			fmt.Sprintf("JMETER_VERSION=%s", info.InstallVersion),
+		})
+	}
+
+	if info.InstallLocation == constant.Ssh {
+		return l.installJmeterBySsh(info)
+	}
+
+	installationCommand, err := utils.ReadToString(installScriptPath)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	addAuthorizedKeyCommand, err := l.authorizedKeyCommandOf(ctx, info)
+	if err != nil {
+		return err
+	}
+
+	commandReq := tumblebug.SendCommandReq{
+		Command: []string{installationCommand, addAuthorizedKeyCommand},
+	}
+
+	_, err = l.tumblebugClient.CommandToMciWithContext(ctx, antNsId, info.mciId(), commandReq)
+	return err
+}
+
+// runningExecutionStatuses are the statuses of load test which is using its load generator.
+var runningExecutionStatuses = []constant.ExecutionStatus{
+	constant.OnPreparing,
+	constant.OnRunning,
+	constant.OnFetching,
+}
+
+// preflightLoadGenerator checks the jmeter load generator before the load test runs,
+// so the load test fails at once with the problems instead of the error of the engine command.
+// The reinstall is skipped while the other load tests are using the load generator, because it would break them.
+func (l *LoadService) preflightLoadGenerator(info *LoadGeneratorInstallInfo) error {
+	unlock := lockLoadGenerator(info.ID)
+	defer unlock()
+
+	reinstall := config.AppConfig.Load.Health.AutoReinstall
+	if reinstall {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		count, err := l.loadRepo.CountRunningLoadTestExecutionStateTx(ctx, info.ID)
+		cancel()
+
+		// the load test itself is on preparing
+		if err != nil || count > 1 {
+			reinstall = false
+		}
+	}
+
+	result, err := l.verifyLoadGenerator(info, reinstall)
+	if err != nil {
+		utils.LogWarnf("Error saving health of load generator %d: %v", info.ID, err)
+	}
+
+	if !result.Healthy {
+		return fmt.Errorf("load generator %d is unhealthy; %s", info.ID, strings.Join(result.Problems, "; "))
+	}
+
+	return nil
+}
+
+// RunLoadGeneratorHealthChecker checks the health of the running remote and ssh load generators periodically until the context is canceled.
+// The load generators running load tests are skipped, because the reinstall would break the load tests.
+func (l *LoadService) RunLoadGeneratorHealthChecker(ctx context.Context) {
+	interval := config.AppConfig.Load.Health.CheckInterval
+	if interval <= 0 {
+		interval = defaultHealthCheckInterval
+	}
+
+	utils.LogInfof("Load generator health checker started with check interval %s", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			utils.LogInfo("Load generator health checker stopped")
+			return
+		case <-ticker.C:
+			l.checkLoadGenerators(ctx)
+		}
+	}
+}
+
+func (l *LoadService) checkLoadGenerators(ctx context.Context) {
+	c, cancel := context.WithTimeout(ctx, 10*time.Second)
+	infos, err := l.loadRepo.GetRunningLoadGeneratorInstallInfosTx(c, []constant.InstallLocation{constant.Remote, constant.Ssh})
+	cancel()
+
+	if err != nil {
+		utils.LogErrorf("Error fetching running load generators: %v", err)
+		return
+	}
+
+	for _, info := range infos {
+		if err := l.checkIdleLoadGenerator(ctx, info.ID); err != nil {
+			utils.LogWarnf("Error checking health of load generator %d: %v", info.ID, err)
+		}
+	}
+}
+
+// checkIdleLoadGenerator checks the health of the load generator when no load test is using it.
+// The load tests are counted under the lock of the load generator, which the preflight of a starting load test waits for.
+func (l *LoadService) checkIdleLoadGenerator(ctx context.Context, loadGeneratorInstallInfoId uint) error {
+	unlock := lockLoadGenerator(loadGeneratorInstallInfoId)
+	defer unlock()
+
+	c, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	count, err := l.loadRepo.CountRunningLoadTestExecutionStateTx(c, loadGeneratorInstallInfoId)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return nil
+	}
+
+	info, err := l.loadRepo.GetValidLoadGeneratorInstallInfoByIdTx(c, loadGeneratorInstallInfoId)
+	if err != nil {
+		return err
+	}
+
+	_, err = l.verifyLoadGenerator(&info, config.AppConfig.Load.Health.AutoReinstall)
+	return err
+}
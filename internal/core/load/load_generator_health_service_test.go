@@ -0,0 +1,44 @@
+package load
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGeneratorHealth(t *testing.T) {
+	out := "printf '%s_%s java=%s' ant health\nant_health java=17.0.12 jmeter=5.6 disk=20480 missing=\n"
+	h, ok := parseGeneratorHealth(out)
+	require.True(t, ok)
+	require.Equal(t, "17.0.12", h.javaVersion)
+	require.Equal(t, "5.6", h.jmeterVersion)
+	require.Equal(t, 20480, h.freeDiskMb)
+	require.Empty(t, h.missingPlugins)
+	require.Empty(t, h.problems("/opt/ant/jmeter", "5.6", 1024))
+
+	h, ok = parseGeneratorHealth("ant_health java=- jmeter=- disk=512 missing=perfmon,casutg")
+	require.True(t, ok)
+	require.Equal(t, []string{"perfmon", "casutg"}, h.missingPlugins)
+	require.Equal(t, []string{
+		"java is not installed",
+		"jmeter 5.6 is not installed on /opt/ant/jmeter",
+		"free disk of /opt/ant/jmeter is 512MB, less than 1024MB",
+		"jmeter plugins perfmon, casutg are missing",
+	}, h.problems("/opt/ant/jmeter", "5.6", 1024))
+
+	_, ok = parseGeneratorHealth("connection refused")
+	require.False(t, ok)
+}
+
+func TestReinstallable(t *testing.T) {
+	drifted := LoadGeneratorHealthResult{Servers: []LoadGeneratorServerHealthResult{
+		{Reachable: true},
+		{Reachable: true, Problems: []string{"java is not installed"}},
+	}}
+	require.True(t, reinstallable(drifted))
+
+	drifted.Servers[0].Reachable = false
+	require.False(t, reinstallable(drifted))
+
+	require.True(t, reinstallable(LoadGeneratorHealthResult{Problems: []string{"java is not installed"}}))
+}
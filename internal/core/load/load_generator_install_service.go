@@ -5,6 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/cloud-barista/cm-ant/internal/config"
@@ -14,6 +19,9 @@ import (
 	"gorm.io/gorm"
 )
 
+// regionNameRegex limits the region name to the characters which are valid in the mci id.
+var regionNameRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,18}[a-z0-9])?$`)
+
 const (
 	antNsId            = "ant-default-ns"
 	antMciDescription  = "Default MCI for Cloud Migration Verification"
@@ -30,6 +38,17 @@ const (
 	antVmSubGroupSize = "1"
 	antVmUserPassword = ""
 
+	antWorkerVmDescription = "Default worker VM for distributed load test"
+	antWorkerVmLabel       = "DynamicVm,AntDefault,AntWorker"
+	antWorkerVmName        = "ant-default-worker-vm"
+	maxWorkerCount         = 10
+
+	// the master and the workers reach each other with these ports over the private network in distributed mode.
+	// jmeter opens up to three ports from the client rmi local port on the master for the callbacks of the workers.
+	jmeterServerPort         = "1099"
+	jmeterServerRmiLocalPort = "50000"
+	jmeterClientRmiLocalPort = "50010"
+
 	antPubKeyName  = "id_rsa_ant.pub"
 	antPrivKeyName = "id_rsa_ant"
 
@@ -46,12 +65,29 @@ func (l *LoadService) InstallLoadGenerator(param InstallLoadGeneratorParam) (Loa
 
 	var result LoadGeneratorInstallInfoResult
 
+	if param.RegionName != "" {
+		if param.InstallLocation != constant.Remote {
+			return result, errors.New("regional load generator is only installed remotely")
+		}
+
+		if !regionNameRegex.MatchString(param.RegionName) {
+			return result, fmt.Errorf("region name %q must be lowercase letters, digits and hyphens up to 20 characters", param.RegionName)
+		}
+	}
+
+	if param.InstallLocation == constant.Remote {
+		if err := param.LoadGeneratorVmParam.withDefaults().validate(); err != nil {
+			return result, err
+		}
+	}
+
 	loadGeneratorInstallInfo := &LoadGeneratorInstallInfo{
 		InstallLocation: param.InstallLocation,
-		InstallType:     "jmeter",
+		InstallType:     string(constant.Jmeter),
 		InstallPath:     config.AppConfig.Load.JMeter.Dir,
 		InstallVersion:  config.AppConfig.Load.JMeter.Version,
 		Status:          "starting",
+		RegionName:      param.RegionName,
 	}
 
 	err := l.loadRepo.GetOrInsertLoadGeneratorInstallInfoTx(ctx, loadGeneratorInstallInfo)
@@ -86,8 +122,20 @@ func (l *LoadService) InstallLoadGenerator(param InstallLoadGeneratorParam) (Loa
 		jmeterPath := config.AppConfig.Load.JMeter.Dir
 		jmeterVersion := config.AppConfig.Load.JMeter.Version
 
+		engine, err := getLoadGeneratorEngine(param.Engine)
+		if err != nil {
+			return result, err
+		}
+
 		exist := utils.ExistCheck(jmeterPath) && utils.ExistCheck(jmeterPath+"/apache-jmeter-"+jmeterVersion)
-		if !exist {
+		if _, ok := engine.(inProcessEngine); ok {
+			// the engine runs inside ant, so only the folders of the load generator are prepared
+			for _, dir := range []string{"result", "test_plan"} {
+				if err := os.MkdirAll(fmt.Sprintf("%s/%s", jmeterPath, dir), 0755); err != nil {
+					return result, err
+				}
+			}
+		} else if !exist {
 			err := utils.Script(installScriptPath, []string{
 				fmt.Sprintf("JMETER_WORK_DIR=%s", jmeterPath),
 				fmt.Sprintf("JMETER_VERSION=%s", jmeterVersion),
@@ -102,18 +150,14 @@ func (l *LoadService) InstallLoadGenerator(param InstallLoadGeneratorParam) (Loa
 		utils.LogInfo("Local installation of JMeter completed successfully")
 	case constant.Remote:
 		utils.LogInfo("Starting remote installation of JMeter")
-		// get the spec and image information
-		recommendVm, err := l.getRecommendVm(ctx, param.Coordinates)
-		if err != nil {
-			utils.LogError("Failed to get recommended VM:", err)
-			return result, err
+		if param.WorkerCount < 0 || param.WorkerCount > maxWorkerCount {
+			return result, fmt.Errorf("worker count must be between 0 and %d", maxWorkerCount)
 		}
-		antVmCommonSpec := recommendVm[0].Name
-		recommendVmConnName := recommendVm[0].ConnectionName
-		antVmCommonImage, err := utils.ReplaceAtIndex(antVmCommonSpec, imageOs, "+", 2)
 
+		// get the spec and image information
+		antVm, err := l.getLoadGeneratorVm(ctx, param.LoadGeneratorVmParam, param.Coordinates)
 		if err != nil {
-			utils.LogError("Error replacing VM spec index:", err)
+			utils.LogError("Failed to get load generator VM:", err)
 			return result, err
 		}
 
@@ -124,8 +168,9 @@ func (l *LoadService) InstallLoadGenerator(param InstallLoadGeneratorParam) (Loa
 			return result, err
 		}
 
-		// get the ant default mci
-		antMci, err := l.getAndDefaultMci(ctx, antVmCommonSpec, antVmCommonImage, recommendVmConnName)
+		// get the ant default mci or the mci of the region
+		mciId := regionalMciId(param.RegionName)
+		antMci, err := l.getAndDefaultMci(ctx, mciId, antVm)
 		if err != nil {
 			utils.LogError("Error getting or creating default mci:", err)
 			return result, err
@@ -142,7 +187,7 @@ func (l *LoadService) InstallLoadGenerator(param InstallLoadGeneratorParam) (Loa
 				return result, err
 			}
 			time.Sleep(defaultDelay)
-			antMci, err = l.getAndDefaultMci(ctx, antVmCommonSpec, antVmCommonImage, recommendVmConnName)
+			antMci, err = l.getAndDefaultMci(ctx, mciId, antVm)
 			if err != nil {
 				utils.LogError("Error getting MCI after resume attempt:", err)
 				return result, err
@@ -156,7 +201,14 @@ func (l *LoadService) InstallLoadGenerator(param InstallLoadGeneratorParam) (Loa
 			return result, errors.New("there is no running vm on ant default mci")
 		}
 
-		addAuthorizedKeyCommand, err := getAddAuthorizedKeyCommand(antPrivKeyName, antPubKeyName)
+		// provision additional worker vms when distributed mode is requested
+		antMci, err = l.ensureWorkerVms(ctx, antMci, param.WorkerCount, antVm)
+		if err != nil {
+			utils.LogError("Error provisioning worker VMs:", err)
+			return result, err
+		}
+
+		addAuthorizedKeyCommand, err := l.authorizedKeyCommandOf(ctx, loadGeneratorInstallInfo)
 		if err != nil {
 			utils.LogError("Error getting add authorized key command:", err)
 			return result, err
@@ -190,8 +242,11 @@ func (l *LoadService) InstallLoadGenerator(param InstallLoadGeneratorParam) (Loa
 		}
 
 		loadGeneratorServers := make([]LoadGeneratorServer, 0)
+		masterVmId := findMasterVmId(antMci.Vm)
+		vms := loadGeneratorVmsOf(antMci.Vm, param.WorkerCount)
+		isCluster := param.WorkerCount > 0
 
-		for i, vm := range antMci.Vm {
+		for _, vm := range vms {
 			var loadGeneratorServer LoadGeneratorServer
 
 			l, ok := marking[vm.Uid]
@@ -218,9 +273,9 @@ func (l *LoadService) InstallLoadGenerator(param InstallLoadGeneratorParam) (Loa
 				loadGeneratorServer.StartTime = vm.CreatedTime
 				loadGeneratorServer.AdditionalVmKey = vm.CspResourceId
 				loadGeneratorServer.Label = "temp-label"
-				loadGeneratorServer.IsCluster = false
-				loadGeneratorServer.IsMaster = i == 0
-				loadGeneratorServer.ClusterSize = uint64(len(antMci.Vm))
+				loadGeneratorServer.IsCluster = isCluster
+				loadGeneratorServer.IsMaster = vm.Id == masterVmId
+				loadGeneratorServer.ClusterSize = uint64(len(vms))
 			} else {
 				loadGeneratorServer = LoadGeneratorServer{
 					VmUid:           vm.Uid,
@@ -242,9 +297,9 @@ func (l *LoadService) InstallLoadGenerator(param InstallLoadGeneratorParam) (Loa
 					StartTime:       vm.CreatedTime,
 					AdditionalVmKey: vm.CspResourceId,
 					Label:           "temp-label",
-					IsCluster:       false,
-					IsMaster:        i == 0,
-					ClusterSize:     uint64(len(antMci.Vm)),
+					IsCluster:       isCluster,
+					IsMaster:        vm.Id == masterVmId,
+					ClusterSize:     uint64(len(vms)),
 				}
 			}
 
@@ -266,8 +321,35 @@ func (l *LoadService) InstallLoadGenerator(param InstallLoadGeneratorParam) (Loa
 		}
 
 		loadGeneratorInstallInfo.LoadGeneratorServers = loadGeneratorServers
-		loadGeneratorInstallInfo.PublicKeyName = antPubKeyName
-		loadGeneratorInstallInfo.PrivateKeyName = antPrivKeyName
+		loadGeneratorInstallInfo.MciId = mciId
+		// the key of the load generator is kept in the keystore when it is enabled
+		if !keystoreEnabled() {
+			loadGeneratorInstallInfo.PublicKeyName = antPubKeyName
+			loadGeneratorInstallInfo.PrivateKeyName = antPrivKeyName
+		}
+		loadGeneratorInstallInfo.IsCluster = isCluster
+		loadGeneratorInstallInfo.ClusterSize = uint64(len(loadGeneratorServers))
+
+		// jmeter opens the rmi ports on the cluster, which only the vms of the cluster may reach
+		if isCluster {
+			err = l.restrictClusterFirewall(ctx, vms)
+			if err != nil {
+				utils.LogError("Error restricting firewall of load generator cluster:", err)
+				return result, err
+			}
+		}
+
+		// the vms are created by the install, so their host keys are trusted now and verified afterwards
+		err = l.pinHostKeys(ctx, loadGeneratorInstallInfo)
+		if err != nil {
+			utils.LogError("Error pinning host keys of load generator servers:", err)
+			return result, err
+		}
+
+		// the install resumes the suspended vms, so the load generator starts its idle time from now
+		installedAt := time.Now()
+		loadGeneratorInstallInfo.LifecycleStatus = generatorRunning
+		loadGeneratorInstallInfo.LastUsedAt = &installedAt
 	}
 
 	loadGeneratorInstallInfo.Status = "installed"
@@ -277,73 +359,57 @@ func (l *LoadService) InstallLoadGenerator(param InstallLoadGeneratorParam) (Loa
 		return result, err
 	}
 
+	// master id is only known after the servers are saved
+	for _, s := range loadGeneratorInstallInfo.LoadGeneratorServers {
+		if s.IsMaster && loadGeneratorInstallInfo.MasterId != s.ID {
+			loadGeneratorInstallInfo.MasterId = s.ID
+			err = l.loadRepo.UpdateLoadGeneratorInstallInfoTx(ctx, loadGeneratorInstallInfo)
+			if err != nil {
+				utils.LogError("Error updating master id of LoadGeneratorInstallInfo:", err)
+				return result, err
+			}
+		}
+	}
+
 	utils.LogInfo("LoadGeneratorInstallInfo updated successfully")
 
-	loadGeneratorServerResults := make([]LoadGeneratorServerResult, 0)
-	for _, l := range loadGeneratorInstallInfo.LoadGeneratorServers {
-		lr := LoadGeneratorServerResult{
-			ID:              l.ID,
-			Csp:             l.Csp,
-			Region:          l.Region,
-			Zone:            l.Zone,
-			PublicIp:        l.PublicIp,
-			PrivateIp:       l.PrivateIp,
-			PublicDns:       l.PublicDns,
-			MachineType:     l.MachineType,
-			Status:          l.Status,
-			SshPort:         l.SshPort,
-			Lat:             l.Lat,
-			Lon:             l.Lon,
-			Username:        l.Username,
-			VmId:            l.VmId,
-			StartTime:       l.StartTime,
-			AdditionalVmKey: l.AdditionalVmKey,
-			Label:           l.Label,
-			CreatedAt:       l.CreatedAt,
-			UpdatedAt:       l.UpdatedAt,
-		}
-		loadGeneratorServerResults = append(loadGeneratorServerResults, lr)
-	}
-
-	result.ID = loadGeneratorInstallInfo.ID
-	result.InstallLocation = loadGeneratorInstallInfo.InstallLocation
-	result.InstallType = loadGeneratorInstallInfo.InstallType
-	result.InstallPath = loadGeneratorInstallInfo.InstallPath
-	result.InstallVersion = loadGeneratorInstallInfo.InstallVersion
-	result.Status = loadGeneratorInstallInfo.Status
-	result.PublicKeyName = loadGeneratorInstallInfo.PublicKeyName
-	result.PrivateKeyName = loadGeneratorInstallInfo.PrivateKeyName
-	result.CreatedAt = loadGeneratorInstallInfo.CreatedAt
-	result.UpdatedAt = loadGeneratorInstallInfo.UpdatedAt
-	result.LoadGeneratorServers = loadGeneratorServerResults
+	result = loadGeneratorInstallInfoResultOf(loadGeneratorInstallInfo)
 
 	utils.LogInfo("InstallLoadGenerator completed successfully")
 
 	return result, nil
 }
 
-// getAndDefaultMci retrieves or creates the default MCI.
-func (l *LoadService) getAndDefaultMci(ctx context.Context, antVmCommonSpec, antVmCommonImage, antVmConnectionName string) (tumblebug.MciRes, error) {
+// regionalMciId returns the mci of the regional load generator, or the default mci without the region.
+func regionalMciId(regionName string) string {
+	if regionName == "" {
+		return antMciId
+	}
+	return fmt.Sprintf("ant-%s-mci", regionName)
+}
+
+// getAndDefaultMci retrieves or creates the mci of the load generator.
+func (l *LoadService) getAndDefaultMci(ctx context.Context, mciId string, antVm loadGeneratorVm) (tumblebug.MciRes, error) {
 	var antMci tumblebug.MciRes
 	var err error
-	antMci, err = l.tumblebugClient.GetMciWithContext(ctx, antNsId, antMciId)
+	antMci, err = l.tumblebugClient.GetMciWithContext(ctx, antNsId, mciId)
 	if err != nil {
 		if errors.Is(err, tumblebug.ErrNotFound) {
 			dynamicMciArg := tumblebug.DynamicMciReq{
 				Description:     antMciDescription,
 				InstallMonAgent: antInstallMonAgent,
 				Label:           map[string]string{antLabelKey: antMciLabel},
-				Name:            antMciId,
+				Name:            mciId,
 				SystemLabel:     "",
 				VM: []tumblebug.DynamicVmReq{
 					{
-						CommonImage:    antVmCommonImage,
-						CommonSpec:     antVmCommonSpec,
-						ConnectionName: antVmConnectionName,
+						CommonImage:    antVm.image,
+						CommonSpec:     antVm.spec,
+						ConnectionName: antVm.connectionName,
 						Description:    antVmDescription,
 						Label:          map[string]string{antLabelKey: antVmLabel},
 						Name:           antVmName,
-						RootDiskSize:   antVmRootDiskSize,
+						RootDiskSize:   antVm.rootDiskSize,
 						RootDiskType:   antVmRootDiskType,
 						SubGroupSize:   antVmSubGroupSize,
 						VMUserPassword: antVmUserPassword,
@@ -360,19 +426,19 @@ func (l *LoadService) getAndDefaultMci(ctx context.Context, antVmCommonSpec, ant
 		}
 	} else if antMci.Vm != nil && len(antMci.Vm) == 0 {
 		dynamicVmArg := tumblebug.DynamicVmReq{
-			CommonImage:    antVmCommonImage,
-			CommonSpec:     antVmCommonSpec,
-			ConnectionName: antVmConnectionName,
+			CommonImage:    antVm.image,
+			CommonSpec:     antVm.spec,
+			ConnectionName: antVm.connectionName,
 			Description:    antVmDescription,
 			Label:          map[string]string{antLabelKey: antVmLabel},
 			Name:           antVmName,
-			RootDiskSize:   antVmRootDiskSize,
+			RootDiskSize:   antVm.rootDiskSize,
 			RootDiskType:   antVmRootDiskType,
 			SubGroupSize:   antVmSubGroupSize,
 			VMUserPassword: antVmUserPassword,
 		}
 
-		antMci, err = l.tumblebugClient.DynamicVmWithContext(ctx, antNsId, antMciId, dynamicVmArg)
+		antMci, err = l.tumblebugClient.DynamicVmWithContext(ctx, antNsId, mciId, dynamicVmArg)
 		time.Sleep(defaultDelay)
 		if err != nil {
 			return antMci, err
@@ -381,74 +447,200 @@ func (l *LoadService) getAndDefaultMci(ctx context.Context, antVmCommonSpec, ant
 	return antMci, nil
 }
 
-// getRecommendVm retrieves recommendVm to specify the location of provisioning.
-func (l *LoadService) getRecommendVm(ctx context.Context, coordinates []string) (tumblebug.RecommendVmResList, error) {
-	recommendVmArg := tumblebug.RecommendVmReq{
-		Filter: tumblebug.Filter{
-			Policy: []tumblebug.FilterPolicy{
-				{
-					Condition: []tumblebug.Condition{
-						{
-							Operand:  "2",
-							Operator: ">=",
-						},
-						{
-							Operand:  "8",
-							Operator: "<=",
-						},
-					},
-					Metric: "vCPU",
-				},
-				{
-					Condition: []tumblebug.Condition{
-						{
-							Operand:  "4",
-							Operator: ">=",
-						},
-						{
-							Operand:  "8",
-							Operator: "<=",
-						},
-					},
-					Metric: "memoryGiB",
-				},
-				{
-					Condition: []tumblebug.Condition{
-						{
-							Operand: "aws",
-						},
-					},
-					Metric: "providerName",
-				},
-			},
-		},
-		Limit: "1",
-		Priority: tumblebug.Priority{
-			Policy: []tumblebug.Policy{
-				{
-					Metric: "location",
-					Parameter: []tumblebug.Parameter{
-						{
-							Key: "coordinateClose",
-							Val: coordinates,
-						},
-					},
-				},
-			},
-		},
+// ensureWorkerVms provisions worker vms on the ant default mci until it has at least workerCount workers.
+// Worker vms are created as a separate sub group so that the master can be distinguished from them.
+func (l *LoadService) ensureWorkerVms(ctx context.Context, antMci tumblebug.MciRes, workerCount int, antVm loadGeneratorVm) (tumblebug.MciRes, error) {
+	lack := workerCount - countWorkerVms(antMci.Vm)
+	if lack <= 0 {
+		return antMci, nil
 	}
 
-	recommendRes, err := l.tumblebugClient.GetRecommendVmWithContext(ctx, recommendVmArg)
+	utils.LogInfof("Provisioning %d worker vms for distributed load test", lack)
+	dynamicVmArg := tumblebug.DynamicVmReq{
+		CommonImage:    antVm.image,
+		CommonSpec:     antVm.spec,
+		ConnectionName: antVm.connectionName,
+		Description:    antWorkerVmDescription,
+		Label:          map[string]string{antLabelKey: antWorkerVmLabel},
+		Name:           fmt.Sprintf("%s-%d", antWorkerVmName, time.Now().Unix()),
+		RootDiskSize:   antVm.rootDiskSize,
+		RootDiskType:   antVmRootDiskType,
+		SubGroupSize:   strconv.Itoa(lack),
+		VMUserPassword: antVmUserPassword,
+	}
 
+	res, err := l.tumblebugClient.DynamicVmWithContext(ctx, antNsId, antMci.Id, dynamicVmArg)
+	time.Sleep(defaultDelay)
 	if err != nil {
-		return nil, err
+		return res, err
+	}
+
+	return res, nil
+}
+
+// isWorkerVm reports whether the vm belongs to one of the worker sub groups.
+func isWorkerVm(vm tumblebug.VmRes) bool {
+	return strings.HasPrefix(vm.SubGroupId, antWorkerVmName)
+}
+
+func countWorkerVms(vms []tumblebug.VmRes) int {
+	count := 0
+	for _, vm := range vms {
+		if isWorkerVm(vm) {
+			count++
+		}
 	}
+	return count
+}
 
-	if len(recommendRes) == 0 {
-		return nil, errors.New("there is no recommended vm list")
+// loadGeneratorVmsOf returns the master and the requested count of the workers.
+// The mci keeps the workers of the former distributed install, so the workers over the count are left idle.
+func loadGeneratorVmsOf(vms []tumblebug.VmRes, workerCount int) []tumblebug.VmRes {
+	masterVmId := findMasterVmId(vms)
+
+	var selected, workers []tumblebug.VmRes
+	for _, vm := range vms {
+		if vm.Id == masterVmId {
+			selected = append(selected, vm)
+		} else if isWorkerVm(vm) {
+			workers = append(workers, vm)
+		}
 	}
+	sort.SliceStable(workers, func(i, j int) bool {
+		return workers[i].Id < workers[j].Id
+	})
 
-	return recommendRes, nil
+	if len(workers) > workerCount {
+		utils.LogInfof("%d worker vms of the mci are left idle", len(workers)-workerCount)
+		workers = workers[:workerCount]
+	}
+
+	return append(selected, workers...)
+}
+
+// clusterFirewallRules returns the inbound rules which the security groups of the cluster need,
+// the ssh from anywhere and every tcp port from the private ips of the vms of the cluster.
+func clusterFirewallRules(vms []tumblebug.VmRes) []tumblebug.FirewallRuleReq {
+	var rules []tumblebug.FirewallRuleReq
+	seen := make(map[tumblebug.FirewallRuleReq]bool)
+	add := func(r tumblebug.FirewallRuleReq) {
+		if !seen[r] {
+			seen[r] = true
+			rules = append(rules, r)
+		}
+	}
+
+	for _, vm := range vms {
+		sshPort := vm.SSHPort
+		if sshPort == "" {
+			sshPort = defaultSshPort
+		}
+		add(tumblebug.FirewallRuleReq{FromPort: sshPort, ToPort: sshPort, IPProtocol: "tcp", Direction: "inbound", CIDR: "0.0.0.0/0"})
+	}
+
+	for _, vm := range vms {
+		if vm.PrivateIP != "" {
+			add(tumblebug.FirewallRuleReq{FromPort: "1", ToPort: "65535", IPProtocol: "tcp", Direction: "inbound", CIDR: vm.PrivateIP + "/32"})
+		}
+	}
+
+	return rules
+}
+
+// exposesRmiPort reports whether the rule opens any rmi port of jmeter to anywhere.
+func exposesRmiPort(r tumblebug.FirewallRuleReq) bool {
+	if !strings.EqualFold(r.Direction, "inbound") || r.CIDR != "0.0.0.0/0" {
+		return false
+	}
+
+	if !strings.EqualFold(r.IPProtocol, "tcp") && !strings.EqualFold(r.IPProtocol, "all") && r.IPProtocol != "-1" {
+		return false
+	}
+
+	from, err := strconv.Atoi(r.FromPort)
+	if err != nil {
+		// the rule without the port range opens every port
+		return true
+	}
+	to, err := strconv.Atoi(r.ToPort)
+	if err != nil {
+		to = from
+	}
+
+	for _, p := range []string{jmeterServerPort, jmeterServerRmiLocalPort, jmeterClientRmiLocalPort} {
+		port, _ := strconv.Atoi(p)
+		// the client opens up to three ports from its local port
+		if port <= to && from <= port+2 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// restrictClusterFirewall adds the rules of the cluster to the security groups of the vms
+// and removes the rules which open the rmi ports of jmeter to anywhere.
+func (l *LoadService) restrictClusterFirewall(ctx context.Context, vms []tumblebug.VmRes) error {
+	rules := clusterFirewallRules(vms)
+
+	seen := make(map[string]bool)
+	for _, vm := range vms {
+		for _, sgId := range vm.SecurityGroupIds {
+			if seen[sgId] {
+				continue
+			}
+			seen[sgId] = true
+
+			sg, err := l.tumblebugClient.GetSecurityGroupWithContext(ctx, antNsId, sgId)
+			if err != nil {
+				return fmt.Errorf("failed to get security group %s; %w", sgId, err)
+			}
+
+			existing := make(map[tumblebug.FirewallRuleReq]bool)
+			var exposing []tumblebug.FirewallRuleReq
+			for _, r := range sg.FirewallRules {
+				existing[tumblebug.FirewallRuleReq{FromPort: r.FromPort, ToPort: r.ToPort, IPProtocol: strings.ToLower(r.IPProtocol), Direction: strings.ToLower(r.Direction), CIDR: r.CIDR}] = true
+				if exposesRmiPort(r) {
+					exposing = append(exposing, r)
+				}
+			}
+
+			var missing []tumblebug.FirewallRuleReq
+			for _, r := range rules {
+				if !existing[r] {
+					missing = append(missing, r)
+				}
+			}
+
+			// the ssh rule is added before the open rule is removed, so the vms stay reachable
+			if len(missing) > 0 {
+				err = l.tumblebugClient.CreateFirewallRulesWithContext(ctx, antNsId, sgId, tumblebug.FirewallRulesReq{FirewallRules: missing})
+				if err != nil {
+					return fmt.Errorf("failed to add firewall rules to security group %s; %w", sgId, err)
+				}
+			}
+
+			if len(exposing) > 0 {
+				utils.LogInfof("Removing %d firewall rules which open the rmi ports from security group %s", len(exposing), sgId)
+				err = l.tumblebugClient.DeleteFirewallRulesWithContext(ctx, antNsId, sgId, tumblebug.FirewallRulesReq{FirewallRules: exposing})
+				if err != nil {
+					return fmt.Errorf("failed to remove firewall rules from security group %s; %w", sgId, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// findMasterVmId returns the id of the first vm which is not a worker.
+func findMasterVmId(vms []tumblebug.VmRes) string {
+	for _, vm := range vms {
+		if !isWorkerVm(vm) {
+			return vm.Id
+		}
+	}
+	return ""
 }
 
 // validDefaultNs checks if the default namespace exists, and creates it if not.
@@ -473,6 +665,9 @@ func (l *LoadService) validDefaultNs(ctx context.Context, antNsId string) error
 }
 
 func (l *LoadService) UninstallLoadGenerator(param UninstallLoadGeneratorParam) error {
+	unlock := lockLoadGenerator(param.LoadGeneratorInstallInfoId)
+	defer unlock()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -500,6 +695,11 @@ func (l *LoadService) UninstallLoadGenerator(param UninstallLoadGeneratorParam)
 			return fmt.Errorf("error while uninstalling load generator: %s", err)
 		}
 	case constant.Remote:
+		// the suspended vms can not run the script, and the next install runs the install script again anyway
+		if loadGeneratorInstallInfo.LifecycleStatus == generatorSuspended {
+			utils.LogInfof("Load generator %d is suspended. skip the uninstall script", loadGeneratorInstallInfo.ID)
+			break
+		}
 
 		uninstallCommand, err := utils.ReadToString(uninstallScriptPath)
 		if err != nil {
@@ -511,7 +711,7 @@ func (l *LoadService) UninstallLoadGenerator(param UninstallLoadGeneratorParam)
 			Command: []string{uninstallCommand},
 		}
 
-		_, err = l.tumblebugClient.CommandToMciWithContext(ctx, antNsId, antMciId, commandReq)
+		_, err = l.tumblebugClient.CommandToMciWithContext(ctx, antNsId, loadGeneratorInstallInfo.mciId(), commandReq)
 		if err != nil {
 			if errors.Is(err, context.DeadlineExceeded) {
 				utils.LogError("VM is not in running state. Cannot connect to the VMs.")
@@ -521,10 +721,27 @@ func (l *LoadService) UninstallLoadGenerator(param UninstallLoadGeneratorParam)
 			return err
 		}
 
-		// err = l.tumblebugClient.ControlLifecycleWithContext(ctx, antNsId, antMciId, "suspend")
-		// if err != nil {
-		// 	return err
-		// }
+		// the vms are kept suspended for the next install instead of running without the load generator
+		err = l.tumblebugClient.ControlLifecycleWithContext(ctx, antNsId, loadGeneratorInstallInfo.mciId(), generatorSuspendAction)
+		if err != nil {
+			utils.LogWarnf("Error suspending vms of uninstalled load generator %d: %v", loadGeneratorInstallInfo.ID, err)
+		} else {
+			loadGeneratorInstallInfo.LifecycleStatus = generatorSuspended
+		}
+	case constant.Ssh:
+		uninstallCommand, err := utils.ReadToString(uninstallScriptPath)
+		if err != nil {
+			utils.LogError("Error reading uninstall script:", err)
+			return err
+		}
+
+		uninstallCommand = fmt.Sprintf("export JMETER_WORK_DIR=%s JMETER_VERSION=%s\n%s", loadGeneratorInstallInfo.InstallPath, loadGeneratorInstallInfo.InstallVersion, uninstallCommand)
+
+		// the host is not owned by ant, so it is deregistered even when it is not reachable anymore
+		_, err = l.commandToLoadGenerator(ctx, &loadGeneratorInstallInfo, "", []string{uninstallCommand})
+		if err != nil {
+			utils.LogWarnf("Error uninstalling jmeter on the host of load generator %d: %v", loadGeneratorInstallInfo.ID, err)
+		}
 	}
 
 	loadGeneratorInstallInfo.Status = "deleted"
@@ -555,47 +772,8 @@ func (l *LoadService) GetAllLoadGeneratorInstallInfo(param GetAllLoadGeneratorIn
 		return result, err
 	}
 
-	for _, l := range pagedResult {
-		loadGeneratorServerResults := make([]LoadGeneratorServerResult, 0)
-		for _, s := range l.LoadGeneratorServers {
-			lsr := LoadGeneratorServerResult{
-				ID:              s.ID,
-				Csp:             s.Csp,
-				Region:          s.Region,
-				Zone:            s.Zone,
-				PublicIp:        s.PublicIp,
-				PrivateIp:       s.PrivateIp,
-				PublicDns:       s.PublicDns,
-				MachineType:     s.MachineType,
-				Status:          s.Status,
-				SshPort:         s.SshPort,
-				Lat:             s.Lat,
-				Lon:             s.Lon,
-				Username:        s.Username,
-				VmId:            s.VmId,
-				StartTime:       s.StartTime,
-				AdditionalVmKey: s.AdditionalVmKey,
-				Label:           s.Label,
-				CreatedAt:       s.CreatedAt,
-				UpdatedAt:       s.UpdatedAt,
-			}
-			loadGeneratorServerResults = append(loadGeneratorServerResults, lsr)
-		}
-		lr := LoadGeneratorInstallInfoResult{
-			ID:                   l.ID,
-			InstallLocation:      l.InstallLocation,
-			InstallType:          l.InstallType,
-			InstallPath:          l.InstallPath,
-			InstallVersion:       l.InstallVersion,
-			Status:               l.Status,
-			PublicKeyName:        l.PublicKeyName,
-			PrivateKeyName:       l.PrivateKeyName,
-			CreatedAt:            l.CreatedAt,
-			UpdatedAt:            l.UpdatedAt,
-			LoadGeneratorServers: loadGeneratorServerResults,
-		}
-
-		infos = append(infos, lr)
+	for i := range pagedResult {
+		infos = append(infos, loadGeneratorInstallInfoResultOf(&pagedResult[i]))
 	}
 
 	result.LoadGeneratorInstallInfoResults = infos
@@ -604,3 +782,55 @@ func (l *LoadService) GetAllLoadGeneratorInstallInfo(param GetAllLoadGeneratorIn
 
 	return result, nil
 }
+
+func loadGeneratorInstallInfoResultOf(info *LoadGeneratorInstallInfo) LoadGeneratorInstallInfoResult {
+	loadGeneratorServerResults := make([]LoadGeneratorServerResult, 0)
+	for _, s := range info.LoadGeneratorServers {
+		lsr := LoadGeneratorServerResult{
+			ID:              s.ID,
+			Csp:             s.Csp,
+			Region:          s.Region,
+			Zone:            s.Zone,
+			PublicIp:        s.PublicIp,
+			PrivateIp:       s.PrivateIp,
+			PublicDns:       s.PublicDns,
+			MachineType:     s.MachineType,
+			Status:          s.Status,
+			SshPort:         s.SshPort,
+			Lat:             s.Lat,
+			Lon:             s.Lon,
+			Username:        s.Username,
+			VmId:            s.VmId,
+			StartTime:       s.StartTime,
+			AdditionalVmKey: s.AdditionalVmKey,
+			Label:           s.Label,
+			IsCluster:       s.IsCluster,
+			IsMaster:        s.IsMaster,
+			CreatedAt:       s.CreatedAt,
+			UpdatedAt:       s.UpdatedAt,
+		}
+		loadGeneratorServerResults = append(loadGeneratorServerResults, lsr)
+	}
+
+	return LoadGeneratorInstallInfoResult{
+		ID:                   info.ID,
+		InstallLocation:      info.InstallLocation,
+		InstallType:          info.InstallType,
+		InstallPath:          info.InstallPath,
+		InstallVersion:       info.InstallVersion,
+		Status:               info.Status,
+		RegionName:           info.RegionName,
+		LifecycleStatus:      info.LifecycleStatus,
+		LastUsedAt:           info.LastUsedAt,
+		HealthMessage:        info.HealthMessage,
+		HealthCheckedAt:      info.HealthCheckedAt,
+		PublicKeyName:        info.PublicKeyName,
+		PrivateKeyName:       info.PrivateKeyName,
+		IsCluster:            info.IsCluster,
+		MasterId:             info.MasterId,
+		ClusterSize:          info.ClusterSize,
+		CreatedAt:            info.CreatedAt,
+		UpdatedAt:            info.UpdatedAt,
+		LoadGeneratorServers: loadGeneratorServerResults,
+	}
+}
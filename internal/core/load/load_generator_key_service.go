@@ -0,0 +1,358 @@
+package load
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cloud-barista/cm-ant/internal/config"
+	"github.com/cloud-barista/cm-ant/internal/core/common/constant"
+	"github.com/cloud-barista/cm-ant/internal/utils"
+	"golang.org/x/crypto/ssh"
+	"gorm.io/gorm"
+)
+
+const (
+	generatorKeyActive  = "active"
+	generatorKeyPending = "pending"
+	generatorKeyRevoked = "revoked"
+
+	generatorRsaKeyBits = 4096
+)
+
+var errKeystoreDisabled = errors.New("keystore is disabled. set load.keystore.masterKey to enable it")
+
+// keystoreEnabled reports whether each load generator has its own key pair in the keystore.
+// The load generators share the key file in ~/.ssh of the server when it is disabled.
+func keystoreEnabled() bool {
+	return config.AppConfig.Load.Keystore.MasterKey != ""
+}
+
+func generatorKeyType() string {
+	keyType := config.AppConfig.Load.Keystore.KeyType
+	if keyType == "" {
+		return utils.ED25519Key
+	}
+	return keyType
+}
+
+// keystoreCipher returns the cipher which encrypts the private keys with the master key of the keystore.
+// The master key is hashed, so a passphrase of any length can be the master key.
+func keystoreCipher() (cipher.AEAD, error) {
+	masterKey := config.AppConfig.Load.Keystore.MasterKey
+	if masterKey == "" {
+		return nil, errKeystoreDisabled
+	}
+
+	key := sha256.Sum256([]byte(masterKey))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// keyAdditionalData binds the encrypted private key to its load generator,
+// so the encrypted key copied to another load generator is not decrypted.
+func keyAdditionalData(loadGeneratorInstallInfoId uint) []byte {
+	return []byte(fmt.Sprintf("load-generator-%d", loadGeneratorInstallInfoId))
+}
+
+func encryptPrivateKey(aead cipher.AEAD, loadGeneratorInstallInfoId uint, privateKey []byte) (string, error) {
+	return sealSecret(aead, keyAdditionalData(loadGeneratorInstallInfoId), privateKey)
+}
+
+func decryptPrivateKey(aead cipher.AEAD, loadGeneratorInstallInfoId uint, encrypted string) ([]byte, error) {
+	privateKey, err := openSecret(aead, keyAdditionalData(loadGeneratorInstallInfoId), encrypted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt the private key of load generator %d. the master key may be changed; %w", loadGeneratorInstallInfoId, err)
+	}
+
+	return privateKey, nil
+}
+
+// sealSecret encrypts the secret with the random nonce, and encodes the nonce and the ciphertext together.
+// The additional data must be given again to open the secret.
+func sealSecret(aead cipher.AEAD, additionalData, secret []byte) (string, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, secret, additionalData)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func openSecret(aead cipher.AEAD, additionalData []byte, encrypted string) ([]byte, error) {
+	sealed, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("encrypted secret is broken")
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, additionalData)
+}
+
+func fingerprintOf(publicKey string) string {
+	pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(publicKey))
+	if err != nil {
+		return ""
+	}
+	return ssh.FingerprintSHA256(pub)
+}
+
+// newLoadGeneratorKey generates the pending key pair of the load generator, and returns it with the plain private key.
+func newLoadGeneratorKey(loadGeneratorInstallInfoId uint) (*LoadGeneratorKey, []byte, error) {
+	aead, err := keystoreCipher()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyType := generatorKeyType()
+	privateKey, publicKey, err := utils.GenerateSSHKey(keyType, generatorRsaKeyBits)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	encrypted, err := encryptPrivateKey(aead, loadGeneratorInstallInfoId, privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pub := strings.TrimSpace(string(publicKey))
+	return &LoadGeneratorKey{
+		LoadGeneratorInstallInfoId: loadGeneratorInstallInfoId,
+		KeyType:                    keyType,
+		PublicKey:                  pub,
+		Fingerprint:                fingerprintOf(pub),
+		EncryptedPrivateKey:        encrypted,
+		Status:                     generatorKeyPending,
+	}, privateKey, nil
+}
+
+// ensureLoadGeneratorKey returns the active key of the load generator, which is generated when the load generator has no key yet.
+func (l *LoadService) ensureLoadGeneratorKey(ctx context.Context, info *LoadGeneratorInstallInfo) (LoadGeneratorKey, error) {
+	key, err := l.loadRepo.GetActiveLoadGeneratorKeyTx(ctx, info.ID)
+	if err == nil {
+		return key, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return key, err
+	}
+
+	newKey, _, err := newLoadGeneratorKey(info.ID)
+	if err != nil {
+		return key, err
+	}
+
+	newKey.Status = generatorKeyActive
+	if err := l.loadRepo.InsertLoadGeneratorKeyTx(ctx, newKey); err != nil {
+		return key, err
+	}
+
+	utils.LogInfof("Generated %s key %s of load generator %d", newKey.KeyType, newKey.Fingerprint, info.ID)
+	return *newKey, nil
+}
+
+// authorizedKeyCommandOf returns the command which authorizes the key of the load generator on its servers.
+// The key of the load generator is authorized when the keystore is enabled, otherwise the shared key.
+func (l *LoadService) authorizedKeyCommandOf(ctx context.Context, info *LoadGeneratorInstallInfo) (string, error) {
+	if !keystoreEnabled() {
+		return getAddAuthorizedKeyCommand(antPrivKeyName, antPubKeyName)
+	}
+
+	key, err := l.ensureLoadGeneratorKey(ctx, info)
+	if err != nil {
+		return "", err
+	}
+
+	return addAuthorizedKeyCommandOf(key.PublicKey)
+}
+
+// loadGeneratorPrivateKey returns the private key which reaches the servers of the load generator.
+// The active key in the keystore is used when there is, otherwise the key file of the private key name in ~/.ssh,
+// which is the shared key or the key of the host registered by ssh.
+func (l *LoadService) loadGeneratorPrivateKey(ctx context.Context, loadGeneratorInstallInfoId uint, privateKeyName string) ([]byte, error) {
+	if keystoreEnabled() {
+		key, err := l.loadRepo.GetActiveLoadGeneratorKeyTx(ctx, loadGeneratorInstallInfoId)
+		if err == nil {
+			aead, err := keystoreCipher()
+			if err != nil {
+				return nil, err
+			}
+			return decryptPrivateKey(aead, loadGeneratorInstallInfoId, key.EncryptedPrivateKey)
+		}
+
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	}
+
+	if privateKeyName == "" {
+		return nil, fmt.Errorf("load generator %d has no ssh key", loadGeneratorInstallInfoId)
+	}
+
+	return utils.ReadPrivateKey(privateKeyName)
+}
+
+// sharedPublicKey returns the public key which the load generators installed before the keystore share.
+func sharedPublicKey() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	pub, err := utils.ReadToString(fmt.Sprintf("%s/.ssh/%s", homeDir, antPubKeyName))
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(pub), nil
+}
+
+// RotateLoadGeneratorKey replaces the ssh key of the load generator with a new key pair in the keystore.
+// The new key is authorized on every server and checked by ssh before it is activated, then the former keys of ant,
+// which are the former key in the keystore and the shared key of the remote load generator, are removed from the authorized keys.
+// The key of the host registered by ssh is not removed, because the user authorized it rather than ant.
+func (l *LoadService) RotateLoadGeneratorKey(loadGeneratorInstallInfoId uint) (LoadGeneratorKeyResult, error) {
+	var result LoadGeneratorKeyResult
+
+	if !keystoreEnabled() {
+		return result, errKeystoreDisabled
+	}
+
+	unlock := lockLoadGenerator(loadGeneratorInstallInfoId)
+	defer unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	info, err := l.loadRepo.GetValidLoadGeneratorInstallInfoByIdTx(ctx, loadGeneratorInstallInfoId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return result, errors.New("cannot find valid load generator install info")
+		}
+		return result, err
+	}
+
+	if info.InstallLocation == constant.Local {
+		return result, fmt.Errorf("load generator %d is local and has no ssh key", info.ID)
+	}
+
+	if info.LifecycleStatus == generatorSuspended {
+		return result, fmt.Errorf("load generator %d is suspended. resume it before the key rotation", info.ID)
+	}
+
+	count, err := l.loadRepo.CountActiveLoadTestExecutionStateTx(ctx, info.ID)
+	if err != nil {
+		return result, err
+	}
+
+	if count > 0 {
+		return result, fmt.Errorf("load generator %d has %d unfinished load tests", info.ID, count)
+	}
+
+	var formerKeys []string
+	former, err := l.loadRepo.GetActiveLoadGeneratorKeyTx(ctx, info.ID)
+	if err == nil {
+		formerKeys = append(formerKeys, former.PublicKey)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return result, err
+	}
+
+	if info.InstallLocation == constant.Remote {
+		if pub, err := sharedPublicKey(); err == nil {
+			formerKeys = append(formerKeys, pub)
+		}
+	}
+
+	key, privateKey, err := newLoadGeneratorKey(info.ID)
+	if err != nil {
+		return result, err
+	}
+
+	if err := l.loadRepo.InsertLoadGeneratorKeyTx(ctx, key); err != nil {
+		return result, err
+	}
+
+	// the new key is removed again from the servers where it is authorized, and the former key stays active
+	fail := func(err error) (LoadGeneratorKeyResult, error) {
+		if cmd, cerr := revokeAuthorizedKeyCommandOf(key.PublicKey); cerr == nil {
+			if _, rerr := l.commandToLoadGenerator(ctx, &info, "", []string{cmd}); rerr != nil {
+				utils.LogWarnf("Error removing the new key %s of load generator %d: %v", key.Fingerprint, info.ID, rerr)
+			}
+		}
+
+		revokedAt := time.Now()
+		key.Status = generatorKeyRevoked
+		key.RevokedAt = &revokedAt
+		if uerr := l.loadRepo.UpdateLoadGeneratorKeyTx(ctx, key); uerr != nil {
+			utils.LogErrorf("Error revoking the new key %s of load generator %d: %v", key.Fingerprint, info.ID, uerr)
+		}
+
+		return result, err
+	}
+
+	addCmd, err := addAuthorizedKeyCommandOf(key.PublicKey)
+	if err != nil {
+		return fail(err)
+	}
+
+	if _, err := l.commandToLoadGenerator(ctx, &info, "", []string{addCmd}); err != nil {
+		return fail(fmt.Errorf("failed to authorize the new key; %w", err))
+	}
+
+	for _, s := range info.LoadGeneratorServers {
+		if _, err := sshCommandOutput(ctx, s, privateKey, "true"); err != nil {
+			return fail(fmt.Errorf("new key is not accepted by %s; %w", s.PublicIp, err))
+		}
+	}
+
+	if err := l.loadRepo.ActivateLoadGeneratorKeyTx(ctx, key, time.Now()); err != nil {
+		return fail(err)
+	}
+
+	utils.LogInfof("Rotated the key of load generator %d to %s key %s", info.ID, key.KeyType, key.Fingerprint)
+
+	result = LoadGeneratorKeyResult{
+		LoadGeneratorInstallInfoId: info.ID,
+		KeyType:                    key.KeyType,
+		Fingerprint:                key.Fingerprint,
+		PublicKey:                  key.PublicKey,
+		CreatedAt:                  key.CreatedAt,
+	}
+
+	// the ssh host is reached by the new key which is active now
+	var revokeCmds []string
+	for _, pub := range formerKeys {
+		cmd, err := revokeAuthorizedKeyCommandOf(pub)
+		if err != nil {
+			utils.LogWarnf("Error revoking the former key of load generator %d: %v", info.ID, err)
+			continue
+		}
+
+		revokeCmds = append(revokeCmds, cmd)
+		result.RevokedFingerprints = append(result.RevokedFingerprints, fingerprintOf(pub))
+	}
+
+	if len(revokeCmds) > 0 {
+		if _, err := l.commandToLoadGenerator(ctx, &info, "", revokeCmds); err != nil {
+			return result, fmt.Errorf("new key %s is active, but failed to remove the former keys from the servers; %w", key.Fingerprint, err)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,56 @@
+package load
+
+import (
+	"testing"
+
+	"github.com/cloud-barista/cm-ant/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptPrivateKey(t *testing.T) {
+	masterKey := config.AppConfig.Load.Keystore.MasterKey
+	t.Cleanup(func() { config.AppConfig.Load.Keystore.MasterKey = masterKey })
+
+	config.AppConfig.Load.Keystore.MasterKey = ""
+	_, err := keystoreCipher()
+	require.ErrorIs(t, err, errKeystoreDisabled)
+
+	config.AppConfig.Load.Keystore.MasterKey = "master"
+	aead, err := keystoreCipher()
+	require.NoError(t, err)
+
+	encrypted, err := encryptPrivateKey(aead, 1, []byte("private key"))
+	require.NoError(t, err)
+
+	privateKey, err := decryptPrivateKey(aead, 1, encrypted)
+	require.NoError(t, err)
+	require.Equal(t, "private key", string(privateKey))
+
+	// the encrypted key of another load generator is not decrypted
+	_, err = decryptPrivateKey(aead, 2, encrypted)
+	require.Error(t, err)
+
+	config.AppConfig.Load.Keystore.MasterKey = "changed"
+	aead, err = keystoreCipher()
+	require.NoError(t, err)
+	_, err = decryptPrivateKey(aead, 1, encrypted)
+	require.Error(t, err)
+}
+
+func TestNewLoadGeneratorKey(t *testing.T) {
+	masterKey := config.AppConfig.Load.Keystore.MasterKey
+	t.Cleanup(func() { config.AppConfig.Load.Keystore.MasterKey = masterKey })
+	config.AppConfig.Load.Keystore.MasterKey = "master"
+
+	key, privateKey, err := newLoadGeneratorKey(1)
+	require.NoError(t, err)
+	require.Equal(t, generatorKeyPending, key.Status)
+	require.Equal(t, fingerprintOf(key.PublicKey), key.Fingerprint)
+	require.NotContains(t, key.EncryptedPrivateKey, string(privateKey))
+
+	aead, err := keystoreCipher()
+	require.NoError(t, err)
+	decrypted, err := decryptPrivateKey(aead, 1, key.EncryptedPrivateKey)
+	require.NoError(t, err)
+	require.Equal(t, privateKey, decrypted)
+}
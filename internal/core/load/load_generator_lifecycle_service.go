@@ -0,0 +1,301 @@
+package load
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cloud-barista/cm-ant/internal/config"
+	"github.com/cloud-barista/cm-ant/internal/core/common/constant"
+	"github.com/cloud-barista/cm-ant/internal/infra/outbound/tumblebug"
+	"github.com/cloud-barista/cm-ant/internal/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	generatorRunning    = "running"
+	generatorSuspended  = "suspended"
+	generatorTerminated = "terminated"
+
+	// the actions are passed to the lifecycle control of tumblebug as they are
+	generatorSuspendAction   = "suspend"
+	generatorResumeAction    = "resume"
+	generatorTerminateAction = "terminate"
+	generatorNoAction        = "none"
+
+	defaultGeneratorIdleTimeout       = time.Hour
+	defaultGeneratorIdleCheckInterval = 5 * time.Minute
+
+	generatorResumeTimeout       = 5 * time.Minute
+	generatorResumeCheckInterval = 10 * time.Second
+)
+
+// generatorLifecycleLocks serializes the lifecycle changes of each load generator,
+// so the idle reaper does not suspend the load generator which a load test is resuming.
+var generatorLifecycleLocks sync.Map
+
+func lockLoadGenerator(loadGeneratorInstallInfoId uint) func() {
+	m, _ := generatorLifecycleLocks.LoadOrStore(loadGeneratorInstallInfoId, &sync.Mutex{})
+	mu := m.(*sync.Mutex)
+	mu.Lock()
+	return mu.Unlock
+}
+
+// SuspendLoadGenerator suspends the vms of the remote load generator which has no unfinished load test.
+func (l *LoadService) SuspendLoadGenerator(loadGeneratorInstallInfoId uint) error {
+	return l.controlLoadGenerator(loadGeneratorInstallInfoId, generatorSuspendAction)
+}
+
+// ResumeLoadGenerator resumes the suspended vms of the remote load generator and waits until they are running.
+func (l *LoadService) ResumeLoadGenerator(loadGeneratorInstallInfoId uint) error {
+	return l.controlLoadGenerator(loadGeneratorInstallInfoId, generatorResumeAction)
+}
+
+// TerminateLoadGenerator terminates the vms of the remote load generator which has no unfinished load test and deletes its mci.
+// The terminated load generator is not used anymore, and the next install provisions new vms.
+func (l *LoadService) TerminateLoadGenerator(loadGeneratorInstallInfoId uint) error {
+	return l.controlLoadGenerator(loadGeneratorInstallInfoId, generatorTerminateAction)
+}
+
+func (l *LoadService) controlLoadGenerator(loadGeneratorInstallInfoId uint, action string) error {
+	unlock := lockLoadGenerator(loadGeneratorInstallInfoId)
+	defer unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), generatorResumeTimeout+time.Minute)
+	defer cancel()
+
+	info, err := l.loadRepo.GetValidLoadGeneratorInstallInfoByIdTx(ctx, loadGeneratorInstallInfoId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("cannot find valid load generator install info")
+		}
+		return err
+	}
+
+	if info.InstallLocation != constant.Remote {
+		return fmt.Errorf("load generator %d is not a remote load generator", loadGeneratorInstallInfoId)
+	}
+
+	if action == generatorResumeAction {
+		return l.resumeLoadGenerator(ctx, &info)
+	}
+
+	count, err := l.loadRepo.CountActiveLoadTestExecutionStateTx(ctx, loadGeneratorInstallInfoId)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return fmt.Errorf("load generator %d has %d unfinished load tests", loadGeneratorInstallInfoId, count)
+	}
+
+	if action == generatorTerminateAction {
+		return l.terminateLoadGenerator(ctx, &info)
+	}
+
+	return l.suspendLoadGenerator(ctx, &info)
+}
+
+func (l *LoadService) suspendLoadGenerator(ctx context.Context, info *LoadGeneratorInstallInfo) error {
+	if info.LifecycleStatus == generatorSuspended {
+		return nil
+	}
+
+	err := l.tumblebugClient.ControlLifecycleWithContext(ctx, antNsId, info.mciId(), generatorSuspendAction)
+	if err != nil {
+		return fmt.Errorf("failed to suspend load generator %d; %w", info.ID, err)
+	}
+
+	info.LifecycleStatus = generatorSuspended
+	for i := range info.LoadGeneratorServers {
+		info.LoadGeneratorServers[i].Status = "Suspended"
+	}
+
+	utils.LogInfof("Load generator %d is suspended", info.ID)
+	return l.loadRepo.UpdateLoadGeneratorInstallInfoTx(ctx, info)
+}
+
+func (l *LoadService) terminateLoadGenerator(ctx context.Context, info *LoadGeneratorInstallInfo) error {
+	err := l.tumblebugClient.DeleteMciWithContext(ctx, antNsId, info.mciId(), generatorTerminateAction)
+	if err != nil && !errors.Is(err, tumblebug.ErrNotFound) {
+		return fmt.Errorf("failed to terminate load generator %d; %w", info.ID, err)
+	}
+
+	info.Status = generatorTerminated
+	info.LifecycleStatus = generatorTerminated
+	for i := range info.LoadGeneratorServers {
+		info.LoadGeneratorServers[i].Status = generatorTerminated
+	}
+
+	utils.LogInfof("Load generator %d is terminated", info.ID)
+	return l.loadRepo.UpdateLoadGeneratorInstallInfoTx(ctx, info)
+}
+
+// resumeLoadGenerator resumes the suspended vms and refreshes the servers,
+// because the public ip of the vm may change while it is suspended.
+func (l *LoadService) resumeLoadGenerator(ctx context.Context, info *LoadGeneratorInstallInfo) error {
+	if info.LifecycleStatus != generatorSuspended {
+		return nil
+	}
+
+	utils.LogInfof("Resuming load generator %d", info.ID)
+	err := l.tumblebugClient.ControlLifecycleWithContext(ctx, antNsId, info.mciId(), generatorResumeAction)
+	if err != nil {
+		return fmt.Errorf("failed to resume load generator %d; %w", info.ID, err)
+	}
+
+	deadline := time.Now().Add(generatorResumeTimeout)
+	var mci tumblebug.MciRes
+	for {
+		time.Sleep(generatorResumeCheckInterval)
+
+		mci, err = l.tumblebugClient.GetMciWithContext(ctx, antNsId, info.mciId())
+		if err == nil && len(mci.Vm) > 0 && mci.StatusCount.CountRunning >= len(mci.Vm) {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("vms of load generator %d are not running after %s", info.ID, generatorResumeTimeout)
+		}
+	}
+
+	vms := make(map[string]tumblebug.VmRes)
+	for _, vm := range mci.Vm {
+		vms[vm.Uid] = vm
+	}
+
+	for i, s := range info.LoadGeneratorServers {
+		vm, ok := vms[s.VmUid]
+		if !ok {
+			continue
+		}
+
+		info.LoadGeneratorServers[i].PublicIp = vm.PublicIP
+		info.LoadGeneratorServers[i].PrivateIp = vm.PrivateIP
+		info.LoadGeneratorServers[i].PublicDns = vm.PublicDNS
+		info.LoadGeneratorServers[i].Status = vm.Status
+	}
+
+	resumedAt := time.Now()
+	info.LifecycleStatus = generatorRunning
+	info.LastUsedAt = &resumedAt
+
+	utils.LogInfof("Load generator %d is resumed", info.ID)
+	return l.loadRepo.UpdateLoadGeneratorInstallInfoTx(ctx, info)
+}
+
+// ensureLoadGeneratorRunning resumes the load generator of the load test when it is suspended,
+// and refreshes the install info with the resumed servers.
+func (l *LoadService) ensureLoadGeneratorRunning(info *LoadGeneratorInstallInfo) error {
+	if info.InstallLocation != constant.Remote {
+		return nil
+	}
+
+	unlock := lockLoadGenerator(info.ID)
+	defer unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), generatorResumeTimeout+time.Minute)
+	defer cancel()
+
+	latest, err := l.loadRepo.GetValidLoadGeneratorInstallInfoByIdTx(ctx, info.ID)
+	if err != nil {
+		return fmt.Errorf("load generator %d is not available; %w", info.ID, err)
+	}
+
+	if err := l.resumeLoadGenerator(ctx, &latest); err != nil {
+		return err
+	}
+
+	*info = latest
+	return nil
+}
+
+// touchLoadGenerator marks the load generator used now, so the idle reaper counts its idle time from now.
+func (l *LoadService) touchLoadGenerator(loadGeneratorInstallInfoId uint) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := l.loadRepo.UpdateLoadGeneratorLastUsedAtTx(ctx, loadGeneratorInstallInfoId, time.Now()); err != nil {
+		utils.LogErrorf("Error updating last used time of load generator %d: %v", loadGeneratorInstallInfoId, err)
+	}
+}
+
+// RunLoadGeneratorReaper suspends or terminates the remote load generators which are idle longer than the configured idle timeout.
+// It runs until the given context is canceled.
+func (l *LoadService) RunLoadGeneratorReaper(ctx context.Context) {
+	c := config.AppConfig.Load.Generator
+
+	action := c.IdleAction
+	if action == "" {
+		action = generatorSuspendAction
+	}
+
+	switch action {
+	case generatorNoAction:
+		utils.LogInfo("Load generator reaper is disabled")
+		return
+	case generatorSuspendAction, generatorTerminateAction:
+	default:
+		utils.LogErrorf("Load generator reaper is disabled by the unknown idle action %q", action)
+		return
+	}
+
+	timeout := c.IdleTimeout
+	if timeout <= 0 {
+		timeout = defaultGeneratorIdleTimeout
+	}
+
+	interval := c.IdleCheckInterval
+	if interval <= 0 {
+		interval = defaultGeneratorIdleCheckInterval
+	}
+
+	utils.LogInfof("Load generator reaper started to %s load generators idle for %s", action, timeout)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			utils.LogInfo("Load generator reaper stopped")
+			return
+		case now := <-ticker.C:
+			l.reapIdleLoadGenerators(ctx, now, timeout, action)
+		}
+	}
+}
+
+func (l *LoadService) reapIdleLoadGenerators(ctx context.Context, now time.Time, timeout time.Duration, action string) {
+	c, cancel := context.WithTimeout(ctx, 10*time.Second)
+	// the ssh load generators are the hosts of the users, which are not suspended nor removed
+	infos, err := l.loadRepo.GetRunningLoadGeneratorInstallInfosTx(c, []constant.InstallLocation{constant.Remote})
+	cancel()
+
+	if err != nil {
+		utils.LogErrorf("Error fetching running load generators: %v", err)
+		return
+	}
+
+	for _, info := range infos {
+		idleSince := loadGeneratorIdleSince(info)
+		if now.Sub(idleSince) < timeout {
+			continue
+		}
+
+		utils.LogInfof("Load generator %d is idle since %s. %s it", info.ID, idleSince.Format(time.RFC3339), action)
+		if err := l.controlLoadGenerator(info.ID, action); err != nil {
+			utils.LogWarnf("Error reaping idle load generator %d: %v", info.ID, err)
+		}
+	}
+}
+
+// loadGeneratorIdleSince returns when the load generator is used last.
+// The load generator installed before the last used time is recorded is idle since its last update.
+func loadGeneratorIdleSince(info LoadGeneratorInstallInfo) time.Time {
+	if info.LastUsedAt != nil {
+		return *info.LastUsedAt
+	}
+	return info.UpdatedAt
+}
@@ -0,0 +1,20 @@
+package load
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadGeneratorIdleSince(t *testing.T) {
+	updatedAt := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	lastUsedAt := updatedAt.Add(-time.Hour)
+
+	info := LoadGeneratorInstallInfo{}
+	info.UpdatedAt = updatedAt
+	require.Equal(t, updatedAt, loadGeneratorIdleSince(info))
+
+	info.LastUsedAt = &lastUsedAt
+	require.Equal(t, lastUsedAt, loadGeneratorIdleSince(info))
+}
@@ -0,0 +1,318 @@
+package load
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloud-barista/cm-ant/internal/config"
+	"github.com/cloud-barista/cm-ant/internal/core/common/constant"
+	"github.com/cloud-barista/cm-ant/internal/infra/outbound/tumblebug"
+	"github.com/cloud-barista/cm-ant/internal/utils"
+	"golang.org/x/crypto/ssh"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultSshPort = "22"
+
+	sshConnectTimeout = time.Minute
+	sshInstallTimeout = 15 * time.Minute
+)
+
+// withDefaults returns the param whose empty ssh port is replaced with the default.
+func (p RegisterLoadGeneratorParam) withDefaults() RegisterLoadGeneratorParam {
+	p.PublicIp = strings.TrimSpace(p.PublicIp)
+	p.PrivateIp = strings.TrimSpace(p.PrivateIp)
+	if p.SshPort == "" {
+		p.SshPort = defaultSshPort
+	}
+	return p
+}
+
+// validate checks the param after the defaults are applied.
+func (p RegisterLoadGeneratorParam) validate() error {
+	if p.PublicIp == "" || strings.ContainsAny(p.PublicIp, " \t/@:") {
+		return fmt.Errorf("public ip %q must be the ip or the host name of the host", p.PublicIp)
+	}
+
+	port, err := strconv.Atoi(p.SshPort)
+	if err != nil || port < 1 || port > 65535 {
+		return fmt.Errorf("ssh port %q must be between 1 and 65535", p.SshPort)
+	}
+
+	if p.Username == "" || strings.ContainsAny(p.Username, " \t/@:") {
+		return fmt.Errorf("username %q is invalid", p.Username)
+	}
+
+	// the key is referenced by the name in ~/.ssh of the server, so the path out of it is not allowed
+	name := p.PrivateKeyName
+	if name == "" || name != filepath.Base(name) || name == "." || name == ".." {
+		return fmt.Errorf("private key name %q must be the file name in ~/.ssh of the server", name)
+	}
+
+	return nil
+}
+
+// RegisterLoadGenerator registers the existing host as the load generator and installs jmeter on it over ssh,
+// so the load test runs on the host which tumblebug does not manage, such as the bastion or the on-premise server.
+// The host must accept the private key for the user and allow sudo without the password like the vms of tumblebug.
+func (l *LoadService) RegisterLoadGenerator(param RegisterLoadGeneratorParam) (LoadGeneratorInstallInfoResult, error) {
+	var result LoadGeneratorInstallInfoResult
+
+	param = param.withDefaults()
+	if err := param.validate(); err != nil {
+		return result, err
+	}
+
+	host := fmt.Sprintf("%s:%s", param.PublicIp, param.SshPort)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	existing, err := l.loadRepo.GetInstalledLoadGeneratorInstallInfoByHostTx(ctx, constant.Ssh, param.PublicIp, param.SshPort)
+	cancel()
+
+	if err == nil {
+		return result, fmt.Errorf("host %s is already registered as load generator %d", host, existing.ID)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return result, err
+	}
+
+	server := LoadGeneratorServer{
+		PublicIp:    param.PublicIp,
+		PrivateIp:   param.PrivateIp,
+		SshPort:     param.SshPort,
+		Username:    param.Username,
+		Label:       param.Label,
+		Status:      "starting",
+		IsMaster:    true,
+		ClusterSize: 1,
+	}
+
+	privateKey, err := utils.ReadPrivateKey(param.PrivateKeyName)
+	if err != nil {
+		return result, fmt.Errorf("failed to read private key %s; %w", param.PrivateKeyName, err)
+	}
+
+	// the user registers the host, so its host key is trusted now and verified afterwards
+	server.HostKey, err = utils.ScanHostKey(server.PublicIp, sshPortOf(server), server.Username, privateKey)
+	if err != nil {
+		return result, fmt.Errorf("failed to connect to %s by ssh; %w", host, err)
+	}
+
+	// the host is checked before it is saved, so the unreachable host is not registered
+	ctx, cancel = context.WithTimeout(context.Background(), sshConnectTimeout)
+	hostname, err := sshCommandOutput(ctx, server, privateKey, "hostname")
+	cancel()
+
+	if err != nil {
+		return result, fmt.Errorf("failed to connect to %s by ssh; %w", host, err)
+	}
+	server.VmName = strings.TrimSpace(hostname)
+
+	loadGeneratorInstallInfo := &LoadGeneratorInstallInfo{
+		InstallLocation:      constant.Ssh,
+		InstallType:          string(constant.Jmeter),
+		InstallPath:          config.AppConfig.Load.JMeter.Dir,
+		InstallVersion:       config.AppConfig.Load.JMeter.Version,
+		Status:               "starting",
+		PrivateKeyName:       param.PrivateKeyName,
+		ClusterSize:          1,
+		LoadGeneratorServers: []LoadGeneratorServer{server},
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	err = l.loadRepo.InsertLoadGeneratorInstallInfoTx(ctx, loadGeneratorInstallInfo)
+	cancel()
+
+	if err != nil {
+		return result, err
+	}
+
+	defer func() {
+		if loadGeneratorInstallInfo.Status == "starting" {
+			loadGeneratorInstallInfo.Status = "failed"
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			if err := l.loadRepo.UpdateLoadGeneratorInstallInfoTx(ctx, loadGeneratorInstallInfo); err != nil {
+				utils.LogErrorf("Error updating load generator %d to failed status: %v", loadGeneratorInstallInfo.ID, err)
+			}
+		}
+	}()
+
+	utils.LogInfof("Installing jmeter on host %s of load generator %d", host, loadGeneratorInstallInfo.ID)
+	if err := l.installJmeterBySsh(loadGeneratorInstallInfo); err != nil {
+		return result, fmt.Errorf("failed to install jmeter on %s; %w", host, err)
+	}
+
+	registeredAt := time.Now()
+	loadGeneratorInstallInfo.Status = "installed"
+	loadGeneratorInstallInfo.LastUsedAt = &registeredAt
+	loadGeneratorInstallInfo.MasterId = loadGeneratorInstallInfo.LoadGeneratorServers[0].ID
+	loadGeneratorInstallInfo.LoadGeneratorServers[0].Status = generatorHealthy
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	err = l.loadRepo.UpdateLoadGeneratorInstallInfoTx(ctx, loadGeneratorInstallInfo)
+	cancel()
+
+	if err != nil {
+		return result, err
+	}
+
+	utils.LogInfof("Host %s is registered as load generator %d", host, loadGeneratorInstallInfo.ID)
+	return loadGeneratorInstallInfoResultOf(loadGeneratorInstallInfo), nil
+}
+
+// installJmeterBySsh runs the install script on the host of the load generator registered by ssh.
+// The script skips the installation which is done already, so it is run again on drift.
+func (l *LoadService) installJmeterBySsh(info *LoadGeneratorInstallInfo) error {
+	script, err := utils.ReadToString(utils.JoinRootPathWith("/script/install-jmeter.sh"))
+	if err != nil {
+		return err
+	}
+
+	installCmd := fmt.Sprintf("export JMETER_WORK_DIR=%s JMETER_VERSION=%s\n%s", info.InstallPath, info.InstallVersion, script)
+
+	ctx, cancel := context.WithTimeout(context.Background(), sshInstallTimeout)
+	defer cancel()
+
+	out, err := l.commandToLoadGenerator(ctx, info, "", []string{installCmd})
+	if err != nil {
+		return fmt.Errorf("%w; %s", err, lastLines(out, 5))
+	}
+
+	return nil
+}
+
+// lastLines returns the last n lines of the output, where the script prints why it failed.
+func lastLines(out string, n int) string {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// commandToLoadGenerator runs the commands on the remote load generator in order and returns the output.
+// The commands run on the vm when the vm id is given, otherwise on every vm of the mci.
+// The load generator registered by ssh runs them on its host over ssh instead of tumblebug.
+func (l *LoadService) commandToLoadGenerator(ctx context.Context, info *LoadGeneratorInstallInfo, vmId string, commands []string) (string, error) {
+	if info.InstallLocation == constant.Ssh {
+		host, err := sshHostOf(info)
+		if err != nil {
+			return "", err
+		}
+		privateKey, err := l.loadGeneratorPrivateKey(ctx, info.ID, info.PrivateKeyName)
+		if err != nil {
+			return "", err
+		}
+		return sshCommandOutput(ctx, host, privateKey, commands...)
+	}
+
+	commandReq := tumblebug.SendCommandReq{
+		Command: commands,
+	}
+
+	if vmId != "" {
+		return l.tumblebugClient.CommandToVmWithContext(ctx, antNsId, info.mciId(), vmId, commandReq)
+	}
+
+	return l.tumblebugClient.CommandToMciWithContext(ctx, antNsId, info.mciId(), commandReq)
+}
+
+// sshHostOf returns the host of the load generator registered by ssh.
+func sshHostOf(info *LoadGeneratorInstallInfo) (LoadGeneratorServer, error) {
+	for _, s := range info.LoadGeneratorServers {
+		if s.IsMaster {
+			return s, nil
+		}
+	}
+	return LoadGeneratorServer{}, fmt.Errorf("load generator %d has no host", info.ID)
+}
+
+// sshCommandOutput runs the commands in order with bash on the server over ssh and returns the combined output.
+// Each command is given to bash through the stdin, so it is not quoted for the login shell of the user.
+// The commands after the failed one are not run.
+func sshCommandOutput(ctx context.Context, server LoadGeneratorServer, privateKey []byte, commands ...string) (string, error) {
+	client, err := sshClientOf(server, privateKey)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+
+	// closing the connection makes the running command return when the context is done
+	stop := context.AfterFunc(ctx, func() { client.Close() })
+	defer stop()
+
+	var out strings.Builder
+	for _, cmd := range commands {
+		session, err := client.NewSession()
+		if err != nil {
+			return out.String(), err
+		}
+
+		session.Stdin = strings.NewReader(cmd)
+		o, err := session.CombinedOutput("bash -s")
+		session.Close()
+
+		out.Write(o)
+		if ctx.Err() != nil {
+			return out.String(), ctx.Err()
+		}
+		if err != nil {
+			return out.String(), err
+		}
+	}
+
+	return out.String(), nil
+}
+
+func sshPortOf(server LoadGeneratorServer) string {
+	if server.SshPort == "" {
+		return defaultSshPort
+	}
+	return server.SshPort
+}
+
+// sshClientOf connects to the server of the load generator by ssh.
+// The host key is verified by the key pinned at the registration or the install,
+// and by known_hosts of the server for the load generator installed before the pinning.
+func sshClientOf(server LoadGeneratorServer, privateKey []byte) (*ssh.Client, error) {
+	callback, err := utils.HostKeyCallbackOf(server.HostKey, config.AppConfig.Load.Ssh.TrustUnknownHosts)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.GetClientWithKey(server.PublicIp, sshPortOf(server), server.Username, privateKey, callback)
+}
+
+// pinHostKeys records the host keys of the servers which are not pinned yet.
+// It is called while the load generator is installed, which is when its vms are trusted.
+func (l *LoadService) pinHostKeys(ctx context.Context, info *LoadGeneratorInstallInfo) error {
+	privateKey, err := l.loadGeneratorPrivateKey(ctx, info.ID, info.PrivateKeyName)
+	if err != nil {
+		return err
+	}
+
+	for i, s := range info.LoadGeneratorServers {
+		if s.HostKey != "" {
+			continue
+		}
+
+		hostKey, err := utils.ScanHostKey(s.PublicIp, sshPortOf(s), s.Username, privateKey)
+		if err != nil {
+			return fmt.Errorf("failed to pin the host key of %s; %w", s.PublicIp, err)
+		}
+
+		info.LoadGeneratorServers[i].HostKey = hostKey
+		utils.LogInfof("Pinned host key %s of %s for load generator %d", fingerprintOf(hostKey), s.PublicIp, info.ID)
+	}
+
+	return nil
+}
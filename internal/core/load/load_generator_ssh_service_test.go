@@ -0,0 +1,39 @@
+package load
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterLoadGeneratorParamValidate(t *testing.T) {
+	p := RegisterLoadGeneratorParam{PublicIp: " 10.0.0.5 ", Username: "ubuntu", PrivateKeyName: "bastion.pem"}.withDefaults()
+	require.Equal(t, "10.0.0.5", p.PublicIp)
+	require.Equal(t, "22", p.SshPort)
+	require.NoError(t, p.validate())
+
+	p.SshPort = "2222"
+	require.NoError(t, p.validate())
+
+	valid := p
+	for _, invalid := range []func(p *RegisterLoadGeneratorParam){
+		func(p *RegisterLoadGeneratorParam) { p.PublicIp = "" },
+		func(p *RegisterLoadGeneratorParam) { p.PublicIp = "10.0.0.5:22" },
+		func(p *RegisterLoadGeneratorParam) { p.SshPort = "ssh" },
+		func(p *RegisterLoadGeneratorParam) { p.SshPort = "70000" },
+		func(p *RegisterLoadGeneratorParam) { p.Username = "" },
+		func(p *RegisterLoadGeneratorParam) { p.PrivateKeyName = "" },
+		func(p *RegisterLoadGeneratorParam) { p.PrivateKeyName = "../id_rsa" },
+		func(p *RegisterLoadGeneratorParam) { p.PrivateKeyName = "/etc/ssh/ssh_host_rsa_key" },
+		func(p *RegisterLoadGeneratorParam) { p.PrivateKeyName = ".." },
+	} {
+		p := valid
+		invalid(&p)
+		require.Error(t, p.validate())
+	}
+}
+
+func TestLastLines(t *testing.T) {
+	require.Equal(t, "c\nd", lastLines("a\nb\nc\nd\n", 2))
+	require.Equal(t, "a", lastLines("a", 5))
+}
@@ -0,0 +1,256 @@
+package load
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cloud-barista/cm-ant/internal/core/common/constant"
+	"github.com/cloud-barista/cm-ant/internal/infra/outbound/tumblebug"
+	"github.com/cloud-barista/cm-ant/internal/utils"
+)
+
+const (
+	defaultVmProvider     = "aws"
+	defaultVmMinVCpu      = 2
+	defaultVmMaxVCpu      = 8
+	defaultVmMinMemoryGiB = 4
+	defaultVmMaxMemoryGiB = 8
+	defaultVmPriority     = constant.LocationPriority
+
+	maxVmRootDiskSize = 2048
+)
+
+// loadGeneratorVm is the vm which is provisioned for the load generator.
+type loadGeneratorVm struct {
+	spec           string
+	image          string
+	connectionName string
+	rootDiskSize   string
+}
+
+// withDefaults returns the param whose zero values are replaced with the defaults.
+func (p LoadGeneratorVmParam) withDefaults() LoadGeneratorVmParam {
+	if len(p.Providers) == 0 {
+		p.Providers = []string{defaultVmProvider}
+	}
+	// the default bound is moved to the given bound of the other side, so only one side of the range can be given
+	if p.MinVCpu == 0 {
+		p.MinVCpu = defaultVmMinVCpu
+		if p.MaxVCpu > 0 {
+			p.MinVCpu = min(p.MinVCpu, p.MaxVCpu)
+		}
+	}
+	if p.MaxVCpu == 0 {
+		p.MaxVCpu = max(defaultVmMaxVCpu, p.MinVCpu)
+	}
+	if p.MinMemoryGiB == 0 {
+		p.MinMemoryGiB = defaultVmMinMemoryGiB
+		if p.MaxMemoryGiB > 0 {
+			p.MinMemoryGiB = min(p.MinMemoryGiB, p.MaxMemoryGiB)
+		}
+	}
+	if p.MaxMemoryGiB == 0 {
+		p.MaxMemoryGiB = max(defaultVmMaxMemoryGiB, p.MinMemoryGiB)
+	}
+	if p.Priority == "" {
+		p.Priority = defaultVmPriority
+	}
+	return p
+}
+
+// validate checks the param after the defaults are applied.
+func (p LoadGeneratorVmParam) validate() error {
+	for _, provider := range p.Providers {
+		if provider == "" || strings.ToLower(provider) != provider {
+			return fmt.Errorf("provider %q must be the lowercase name of the csp such as aws", provider)
+		}
+	}
+
+	if p.MinVCpu < 1 || p.MinVCpu > p.MaxVCpu {
+		return fmt.Errorf("vcpu range %d-%d is invalid", p.MinVCpu, p.MaxVCpu)
+	}
+
+	if p.MinMemoryGiB < 1 || p.MinMemoryGiB > p.MaxMemoryGiB {
+		return fmt.Errorf("memory range %d-%d GiB is invalid", p.MinMemoryGiB, p.MaxMemoryGiB)
+	}
+
+	switch p.Priority {
+	case constant.LocationPriority, constant.CostPriority, constant.PerformancePriority:
+	default:
+		return fmt.Errorf("priority %q is not one of location, cost and performance", p.Priority)
+	}
+
+	if p.Spec != "" && len(strings.Split(p.Spec, "+")) != 3 {
+		return fmt.Errorf("spec %q must be the common spec such as aws+ap-northeast-2+t3.xlarge", p.Spec)
+	}
+
+	if p.Image != "" && strings.Contains(p.Image, "+") && len(strings.Split(p.Image, "+")) != 3 {
+		return fmt.Errorf("image %q must be the common image such as aws+ap-northeast-2+ubuntu22.04", p.Image)
+	}
+
+	if p.RootDiskSize < 0 || p.RootDiskSize > maxVmRootDiskSize {
+		return fmt.Errorf("root disk size must be between 0 and %d GB", maxVmRootDiskSize)
+	}
+
+	return nil
+}
+
+// vmImageOf returns the common image of the vm, which is the os of the image in the region of the spec
+// unless the common image is given.
+func vmImageOf(spec, image string) (string, error) {
+	if image == "" {
+		image = imageOs
+	}
+
+	if strings.Contains(image, "+") {
+		return image, nil
+	}
+
+	return utils.ReplaceAtIndex(spec, image, "+", 2)
+}
+
+func vmRootDiskSizeOf(size int) string {
+	if size == 0 {
+		return antVmRootDiskSize
+	}
+	return strconv.Itoa(size)
+}
+
+// getLoadGeneratorVm returns the vm to provision for the load generator with the validated param.
+// The given spec is used as it is, otherwise the spec is recommended near the coordinates.
+func (l *LoadService) getLoadGeneratorVm(ctx context.Context, param LoadGeneratorVmParam, coordinates []string) (loadGeneratorVm, error) {
+	var vm loadGeneratorVm
+
+	param = param.withDefaults()
+
+	vm.spec = param.Spec
+	if vm.spec == "" {
+		recommendVm, err := l.getRecommendVm(ctx, param, coordinates)
+		if err != nil {
+			return vm, err
+		}
+
+		utils.LogInfof("Recommended load generator vm %s (%d vcpu, %d GiB) by %s", recommendVm.Name, recommendVm.VCPU, recommendVm.MemoryGiB, param.Priority)
+		vm.spec = recommendVm.Name
+		vm.connectionName = recommendVm.ConnectionName
+	}
+
+	image, err := vmImageOf(vm.spec, param.Image)
+	if err != nil {
+		return vm, fmt.Errorf("failed to get the image of spec %s; %w", vm.spec, err)
+	}
+
+	vm.image = image
+	vm.rootDiskSize = vmRootDiskSizeOf(param.RootDiskSize)
+	return vm, nil
+}
+
+// getRecommendVm retrieves the recommended vm of each provider and picks one of them by the priority.
+func (l *LoadService) getRecommendVm(ctx context.Context, param LoadGeneratorVmParam, coordinates []string) (tumblebug.RecommendVmRes, error) {
+	var candidates []tumblebug.RecommendVmRes
+
+	for _, provider := range param.Providers {
+		recommendRes, err := l.tumblebugClient.GetRecommendVmWithContext(ctx, recommendVmReq(param, provider, coordinates))
+		if err != nil {
+			// the provider may not be registered on tumblebug, so the other providers are still tried
+			utils.LogWarnf("Error getting recommended vm of provider %s: %v", provider, err)
+			continue
+		}
+
+		if len(recommendRes) > 0 {
+			candidates = append(candidates, recommendRes[0])
+		}
+	}
+
+	if len(candidates) == 0 {
+		return tumblebug.RecommendVmRes{}, errors.New("there is no recommended vm list")
+	}
+
+	return pickRecommendVm(candidates, param.Priority), nil
+}
+
+func recommendVmReq(param LoadGeneratorVmParam, provider string, coordinates []string) tumblebug.RecommendVmReq {
+	priority := tumblebug.Policy{
+		Metric: string(param.Priority),
+	}
+
+	if param.Priority == constant.LocationPriority {
+		priority.Parameter = []tumblebug.Parameter{
+			{
+				Key: "coordinateClose",
+				Val: coordinates,
+			},
+		}
+	}
+
+	return tumblebug.RecommendVmReq{
+		Filter: tumblebug.Filter{
+			Policy: []tumblebug.FilterPolicy{
+				{
+					Condition: []tumblebug.Condition{
+						{
+							Operand:  strconv.Itoa(param.MinVCpu),
+							Operator: ">=",
+						},
+						{
+							Operand:  strconv.Itoa(param.MaxVCpu),
+							Operator: "<=",
+						},
+					},
+					Metric: "vCPU",
+				},
+				{
+					Condition: []tumblebug.Condition{
+						{
+							Operand:  strconv.Itoa(param.MinMemoryGiB),
+							Operator: ">=",
+						},
+						{
+							Operand:  strconv.Itoa(param.MaxMemoryGiB),
+							Operator: "<=",
+						},
+					},
+					Metric: "memoryGiB",
+				},
+				{
+					Condition: []tumblebug.Condition{
+						{
+							Operand: provider,
+						},
+					},
+					Metric: "providerName",
+				},
+			},
+		},
+		Limit: "1",
+		Priority: tumblebug.Priority{
+			Policy: []tumblebug.Policy{priority},
+		},
+	}
+}
+
+// pickRecommendVm picks the vm among the recommendations of the providers.
+// The cheapest vm is picked for the cost and the biggest vm for the performance,
+// while the providers are preferred in the given order for the location.
+func pickRecommendVm(candidates []tumblebug.RecommendVmRes, priority constant.VmPriority) tumblebug.RecommendVmRes {
+	picked := candidates[0]
+
+	for _, c := range candidates[1:] {
+		switch priority {
+		case constant.CostPriority:
+			// the vm without the price is not regarded as cheap
+			if c.CostPerHour > 0 && (picked.CostPerHour <= 0 || c.CostPerHour < picked.CostPerHour) {
+				picked = c
+			}
+		case constant.PerformancePriority:
+			if c.VCPU > picked.VCPU || (c.VCPU == picked.VCPU && c.MemoryGiB > picked.MemoryGiB) {
+				picked = c
+			}
+		}
+	}
+
+	return picked
+}
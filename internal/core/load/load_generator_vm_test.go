@@ -0,0 +1,102 @@
+package load
+
+import (
+	"testing"
+
+	"github.com/cloud-barista/cm-ant/internal/core/common/constant"
+	"github.com/cloud-barista/cm-ant/internal/infra/outbound/tumblebug"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadGeneratorVmParamDefaults(t *testing.T) {
+	p := LoadGeneratorVmParam{}.withDefaults()
+	require.Equal(t, []string{"aws"}, p.Providers)
+	require.Equal(t, 2, p.MinVCpu)
+	require.Equal(t, 8, p.MaxVCpu)
+	require.Equal(t, 4, p.MinMemoryGiB)
+	require.Equal(t, 8, p.MaxMemoryGiB)
+	require.Equal(t, constant.LocationPriority, p.Priority)
+	require.NoError(t, p.validate())
+
+	p = LoadGeneratorVmParam{MinVCpu: 16, MaxMemoryGiB: 2}.withDefaults()
+	require.Equal(t, 16, p.MaxVCpu)
+	require.Equal(t, 2, p.MinMemoryGiB)
+	require.NoError(t, p.validate())
+
+	require.Error(t, LoadGeneratorVmParam{MinVCpu: 8, MaxVCpu: 4}.withDefaults().validate())
+	require.Error(t, LoadGeneratorVmParam{Providers: []string{"AWS"}}.withDefaults().validate())
+	require.Error(t, LoadGeneratorVmParam{Priority: "latency"}.withDefaults().validate())
+	require.Error(t, LoadGeneratorVmParam{Spec: "t3.xlarge"}.withDefaults().validate())
+	require.Error(t, LoadGeneratorVmParam{RootDiskSize: -1}.withDefaults().validate())
+}
+
+func TestVmImageOf(t *testing.T) {
+	image, err := vmImageOf("aws+ap-northeast-2+t3.xlarge", "")
+	require.NoError(t, err)
+	require.Equal(t, "aws+ap-northeast-2+ubuntu22.04", image)
+
+	image, err = vmImageOf("gcp+asia-northeast3+e2-standard-4", "ubuntu24.04")
+	require.NoError(t, err)
+	require.Equal(t, "gcp+asia-northeast3+ubuntu24.04", image)
+
+	image, err = vmImageOf("aws+ap-northeast-2+t3.xlarge", "aws+ap-northeast-2+ubuntu20.04")
+	require.NoError(t, err)
+	require.Equal(t, "aws+ap-northeast-2+ubuntu20.04", image)
+}
+
+func TestPickRecommendVm(t *testing.T) {
+	candidates := []tumblebug.RecommendVmRes{
+		{Name: "aws", VCPU: 4, MemoryGiB: 8, CostPerHour: 0.2},
+		{Name: "azure", VCPU: 8, MemoryGiB: 8},
+		{Name: "gcp", VCPU: 8, MemoryGiB: 16, CostPerHour: 0.1},
+	}
+
+	require.Equal(t, "aws", pickRecommendVm(candidates, constant.LocationPriority).Name)
+	require.Equal(t, "gcp", pickRecommendVm(candidates, constant.CostPriority).Name)
+	require.Equal(t, "gcp", pickRecommendVm(candidates, constant.PerformancePriority).Name)
+}
+
+func TestLoadGeneratorVmsOf(t *testing.T) {
+	vms := []tumblebug.VmRes{
+		{Id: "worker-2", SubGroupId: antWorkerVmName + "-1"},
+		{Id: "master", SubGroupId: antVmName},
+		{Id: "worker-1", SubGroupId: antWorkerVmName + "-1"},
+	}
+
+	ids := func(vms []tumblebug.VmRes) []string {
+		var ids []string
+		for _, vm := range vms {
+			ids = append(ids, vm.Id)
+		}
+		return ids
+	}
+
+	// the workers of the former distributed install are not used by the single install
+	require.Equal(t, []string{"master"}, ids(loadGeneratorVmsOf(vms, 0)))
+	require.Equal(t, []string{"master", "worker-1"}, ids(loadGeneratorVmsOf(vms, 1)))
+	require.Equal(t, []string{"master", "worker-1", "worker-2"}, ids(loadGeneratorVmsOf(vms, 5)))
+}
+
+func TestClusterFirewallRules(t *testing.T) {
+	vms := []tumblebug.VmRes{
+		{Id: "master", PrivateIP: "10.0.0.5", SSHPort: "22"},
+		{Id: "worker-1", PrivateIP: "10.0.0.6"},
+	}
+
+	require.Equal(t, []tumblebug.FirewallRuleReq{
+		{FromPort: "22", ToPort: "22", IPProtocol: "tcp", Direction: "inbound", CIDR: "0.0.0.0/0"},
+		{FromPort: "1", ToPort: "65535", IPProtocol: "tcp", Direction: "inbound", CIDR: "10.0.0.5/32"},
+		{FromPort: "1", ToPort: "65535", IPProtocol: "tcp", Direction: "inbound", CIDR: "10.0.0.6/32"},
+	}, clusterFirewallRules(vms))
+
+	for _, r := range clusterFirewallRules(vms) {
+		require.False(t, exposesRmiPort(r))
+	}
+
+	require.True(t, exposesRmiPort(tumblebug.FirewallRuleReq{FromPort: "1", ToPort: "65535", IPProtocol: "TCP", Direction: "inbound", CIDR: "0.0.0.0/0"}))
+	require.True(t, exposesRmiPort(tumblebug.FirewallRuleReq{FromPort: "50012", ToPort: "50012", IPProtocol: "tcp", Direction: "inbound", CIDR: "0.0.0.0/0"}))
+	require.True(t, exposesRmiPort(tumblebug.FirewallRuleReq{IPProtocol: "all", Direction: "inbound", CIDR: "0.0.0.0/0"}))
+	require.False(t, exposesRmiPort(tumblebug.FirewallRuleReq{FromPort: "1", ToPort: "65535", IPProtocol: "udp", Direction: "inbound", CIDR: "0.0.0.0/0"}))
+	require.False(t, exposesRmiPort(tumblebug.FirewallRuleReq{FromPort: "1", ToPort: "65535", IPProtocol: "tcp", Direction: "outbound", CIDR: "0.0.0.0/0"}))
+	require.False(t, exposesRmiPort(tumblebug.FirewallRuleReq{FromPort: "8080", ToPort: "8080", IPProtocol: "tcp", Direction: "inbound", CIDR: "0.0.0.0/0"}))
+}
@@ -3,12 +3,18 @@ package load
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"os"
+	"path"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cloud-barista/cm-ant/internal/core/common/constant"
@@ -28,6 +34,24 @@ func (l *LoadService) RunLoadTest(param RunLoadTestParam) (string, error) {
 
 	utils.LogInfof("Starting load test with key: %s", loadTestKey)
 
+	if err := validateRunLoadTestParam(param); err != nil {
+		return "", err
+	}
+
+	engine, err := getLoadGeneratorEngine(param.Engine)
+	if err != nil {
+		return "", err
+	}
+	param.Engine = engine.Type()
+	param.InstallLoadGenerator.Engine = engine.Type()
+
+	if param.TestPlanId != uint(0) {
+		if _, err := l.loadRepo.GetLoadTestPlanTx(ctx, param.TestPlanId); err != nil {
+			utils.LogErrorf("Error retrieving load test plan %d: %v", param.TestPlanId, err)
+			return "", fmt.Errorf("load test plan %d is not found; %w", param.TestPlanId, err)
+		}
+	}
+
 	if param.LoadGeneratorInstallInfoId == uint(0) {
 		utils.LogInfo("No LoadGeneratorInstallInfoId provided, installing load generator...")
 		result, err := l.InstallLoadGenerator(param.InstallLoadGenerator)
@@ -52,13 +76,11 @@ func (l *LoadService) RunLoadTest(param RunLoadTestParam) (string, error) {
 		return "", err
 	}
 
-	duration, err := strconv.Atoi(param.Duration)
-	if err != nil {
-		return "", err
+	if _, ok := engine.(inProcessEngine); ok && loadGeneratorInstallInfo.InstallLocation != constant.Local {
+		return "", fmt.Errorf("%s engine only runs on the local load generator", engine.Type())
 	}
 
-	rampUpTime, err := strconv.Atoi(param.RampUpTime)
-
+	totalExpectedSecond, err := expectedExecutionSecond(param)
 	if err != nil {
 		return "", err
 	}
@@ -68,26 +90,62 @@ func (l *LoadService) RunLoadTest(param RunLoadTestParam) (string, error) {
 		LoadTestKey:                 loadTestKey,
 		ExecutionStatus:             constant.OnPreparing,
 		StartAt:                     time.Now(),
-		TotalExpectedExcutionSecond: uint64(duration + rampUpTime),
+		TotalExpectedExcutionSecond: uint64(totalExpectedSecond),
 	}
 
-	go l.processLoadTest(param, &loadGeneratorInstallInfo, &stateArg)
-
 	var hs []LoadTestExecutionHttpInfo
 
 	for _, h := range param.HttpReqs {
 		hh := LoadTestExecutionHttpInfo{
-			Method:   h.Method,
-			Protocol: h.Protocol,
-			Hostname: h.Hostname,
-			Port:     h.Port,
-			Path:     h.Path,
-			BodyData: h.BodyData,
+			Method:          h.Method,
+			Protocol:        h.Protocol,
+			Hostname:        h.Hostname,
+			Port:            h.Port,
+			Path:            h.Path,
+			BodyData:        h.BodyData,
+			ContentType:     h.ContentType,
+			ConnectTimeout:  h.ConnectTimeout,
+			ResponseTimeout: h.ResponseTimeout,
+			RedirectPolicy:  h.RedirectPolicy,
+			Name:            h.Name,
+			Transaction:     h.Transaction,
+			ThinkTime:       h.ThinkTime,
+		}
+
+		if h.Auth != nil {
+			hh.AuthType = h.Auth.Type
+		}
+
+		if len(h.Headers) > 0 {
+			b, _ := json.Marshal(redactedHeaders(h.Headers))
+			hh.Headers = string(b)
+		}
+
+		if len(h.Cookies) > 0 {
+			b, _ := json.Marshal(redactedCookies(h.Cookies))
+			hh.Cookies = string(b)
+		}
+
+		if len(h.Extractors) > 0 {
+			b, _ := json.Marshal(h.Extractors)
+			hh.Extractors = string(b)
 		}
 
 		hs = append(hs, hh)
 	}
 
+	var loadProfile string
+	if param.LoadProfile != nil {
+		b, _ := json.Marshal(param.LoadProfile)
+		loadProfile = string(b)
+	}
+
+	var sloRules string
+	if len(param.SloRules) > 0 {
+		b, _ := json.Marshal(param.SloRules)
+		sloRules = string(b)
+	}
+
 	loadArg := LoadTestExecutionInfo{
 		LoadTestKey:                loadTestKey,
 		TestName:                   param.TestName,
@@ -100,6 +158,10 @@ func (l *LoadService) RunLoadTest(param RunLoadTestParam) (string, error) {
 		AgentInstalled:             param.AgentInstalled,
 		AgentHostname:              param.AgentHostname,
 		LoadGeneratorInstallInfoId: loadGeneratorInstallInfo.ID,
+		TestPlanId:                 param.TestPlanId,
+		Engine:                     param.Engine,
+		LoadProfile:                loadProfile,
+		SloRules:                   sloRules,
 		LoadTestExecutionHttpInfos: hs,
 	}
 
@@ -110,54 +172,186 @@ func (l *LoadService) RunLoadTest(param RunLoadTestParam) (string, error) {
 		return "", err
 	}
 
+	run := newLoadTestRun(loadTestKey)
+	position := l.submitLoadTest(&queuedLoadTest{
+		loadTestKey: loadTestKey,
+		testName:    param.TestName,
+		generatorId: loadGeneratorInstallInfo.ID,
+		queuedAt:    time.Now(),
+		start: func() {
+			// the load test may have waited in the queue, so it starts from now
+			stateArg.ExecutionStatus = constant.OnPreparing
+			stateArg.QueuePosition = 0
+			stateArg.StartAt = time.Now()
+			if err := l.loadRepo.UpdateLoadTestExecutionStateTx(context.Background(), &stateArg); err != nil {
+				utils.LogErrorf("Error updating load test execution state: %v", err)
+			}
+
+			fail := func(message string) {
+				run.done()
+
+				finishAt := time.Now()
+				stateArg.ExecutionStatus = constant.TestFailed
+				stateArg.FailureMessage = message
+				stateArg.FinishAt = &finishAt
+				if err := l.loadRepo.UpdateLoadTestExecutionStateTx(context.Background(), &stateArg); err != nil {
+					utils.LogErrorf("Error updating load test execution state: %v", err)
+				}
+			}
+
+			// the idle load generator may be suspended while the load test waited
+			if err := l.ensureLoadGeneratorRunning(&loadGeneratorInstallInfo); err != nil {
+				utils.LogErrorf("Error resuming load generator of load test %s: %v", loadTestKey, err)
+				fail(fmt.Sprintf("failed to resume the load generator; %s", err))
+				return
+			}
+
+			if engine.Type() == constant.Jmeter {
+				if err := l.preflightLoadGenerator(&loadGeneratorInstallInfo); err != nil {
+					utils.LogErrorf("Load test %s is not started; %v", loadTestKey, err)
+					fail(err.Error())
+					return
+				}
+			}
+
+			l.processLoadTest(param, &loadGeneratorInstallInfo, &stateArg, run)
+		},
+	})
+
+	if position > 0 {
+		utils.LogInfof("Load test queued successfully with key: %s", loadTestKey)
+		return loadTestKey, nil
+	}
+
 	utils.LogInfof("Load test started successfully with key: %s", loadTestKey)
 
 	return loadTestKey, nil
 
 }
 
+// sensitiveHeaderKeywords are the parts of the names of the headers which carry the credentials.
+var sensitiveHeaderKeywords = []string{"auth", "token", "secret", "password", "key", "session", "cookie", "credential"}
+
+// isSensitiveHeader reports whether the value of the header may be a credential,
+// such as authorization, x-api-key or the session id.
+func isSensitiveHeader(name string) bool {
+	n := strings.ToLower(name)
+	for _, k := range sensitiveHeaderKeywords {
+		if strings.Contains(n, k) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactedHeaders returns the copy of the headers whose values of the sensitive headers are redacted.
+func redactedHeaders(headers []RunLoadTestHttpHeaderParam) []RunLoadTestHttpHeaderParam {
+	redacted := make([]RunLoadTestHttpHeaderParam, len(headers))
+	for i, h := range headers {
+		redacted[i] = h
+		if h.Value != "" && isSensitiveHeader(h.Name) {
+			redacted[i].Value = redactedSecret
+		}
+	}
+	return redacted
+}
+
+// redactedCookies returns the copy of the cookies whose values are redacted, since any cookie may be the session.
+func redactedCookies(cookies []RunLoadTestHttpCookieParam) []RunLoadTestHttpCookieParam {
+	redacted := make([]RunLoadTestHttpCookieParam, len(cookies))
+	for i, c := range cookies {
+		redacted[i] = c
+		if c.Value != "" {
+			redacted[i].Value = redactedSecret
+		}
+	}
+	return redacted
+}
+
+// expectedExecutionSecond returns the seconds which the load test is expected to take.
+func expectedExecutionSecond(param RunLoadTestParam) (int, error) {
+	if param.LoadProfile != nil {
+		return param.LoadProfile.totalDuration(), nil
+	}
+
+	duration, err := strconv.Atoi(param.Duration)
+	if err != nil {
+		return 0, err
+	}
+
+	rampUpTime, err := strconv.Atoi(param.RampUpTime)
+	if err != nil {
+		return 0, err
+	}
+
+	return duration + rampUpTime, nil
+}
+
 // processLoadTest executes the load test.
 // Depending on whether the installation location is local or remote, it creates the test plan and runs test commands.
 // Fetches and saves test results from the local or remote system.
-func (l *LoadService) processLoadTest(param RunLoadTestParam, loadGeneratorInstallInfo *LoadGeneratorInstallInfo, loadTestExecutionState *LoadTestExecutionState) {
+func (l *LoadService) processLoadTest(param RunLoadTestParam, loadGeneratorInstallInfo *LoadGeneratorInstallInfo, loadTestExecutionState *LoadTestExecutionState, run *loadTestRun) {
+	defer run.done()
 
 	loadTestDone := make(chan bool)
 
-	var username string
-	var publicIp string
-	var port string
-	for _, s := range loadGeneratorInstallInfo.LoadGeneratorServers {
-		if s.IsMaster {
-			username = s.Username
-			publicIp = s.PublicIp
-			port = s.SshPort
+	engine, err := getLoadGeneratorEngine(param.Engine)
+	if err != nil {
+		// nothing is fetched without the engine, so the state is finished as failed right away
+		utils.LogErrorf("Error getting engine of load test %s: %v", param.LoadTestKey, err)
+		loadTestExecutionState.ExecutionStatus = constant.TestFailed
+		loadTestExecutionState.FailureMessage = err.Error()
+		finishAt := time.Now()
+		loadTestExecutionState.FinishAt = &finishAt
+		if len(param.SloRules) > 0 {
+			loadTestExecutionState.Verdict = constant.VerdictError
 		}
-	}
 
-	home, err := os.UserHomeDir()
-	if err != nil {
+		if updateErr := l.loadRepo.UpdateLoadTestExecutionStateTx(context.Background(), loadTestExecutionState); updateErr != nil {
+			utils.LogErrorf("Error updating load test execution state: %v", updateErr)
+		}
 		return
 	}
 
-	dataParam := &fetchDataParam{
-		LoadTestDone:    loadTestDone,
-		LoadTestKey:     param.LoadTestKey,
-		InstallLocation: loadGeneratorInstallInfo.InstallLocation,
-		InstallPath:     loadGeneratorInstallInfo.InstallPath,
-		PublicKeyName:   loadGeneratorInstallInfo.PublicKeyName,
-		PrivateKeyName:  loadGeneratorInstallInfo.PrivateKeyName,
-		Username:        username,
-		PublicIp:        publicIp,
-		Port:            port,
-		AgentInstalled:  param.AgentInstalled,
-		Home:            home,
-	}
+	dataParam := newFetchDataParam(param.LoadTestKey, param.AgentInstalled, loadGeneratorInstallInfo, engine, loadTestDone)
+	fetchDone := dataParam.FetchDone
 
 	go l.fetchData(dataParam)
 
+	samplingDone := make(chan struct{})
+	saturation := make(chan generatorSaturation, 1)
+	go func() {
+		saturated, message := l.sampleLoadGenerator(run, loadGeneratorInstallInfo, samplingDone)
+		saturation <- generatorSaturation{saturated, message}
+	}()
+
 	defer func() {
 		loadTestDone <- true
 		close(loadTestDone)
+
+		close(samplingDone)
+		s := <-saturation
+		loadTestExecutionState.GeneratorSaturated = s.saturated
+		loadTestExecutionState.GeneratorSaturationMessage = s.message
+		if s.saturated {
+			utils.LogWarnf("Result of load test %s may be limited by the load generator; %s", param.LoadTestKey, s.message)
+		}
+
+		if loadTestExecutionState.ExecutionStatus == constant.Successed || loadTestExecutionState.ExecutionStatus == constant.Stopped {
+			// the whole result is ingested by the last fetch, so the load test is finished only after it.
+			// slo rules and the capacity search rely on this to evaluate the result.
+			<-fetchDone
+
+			if loadTestExecutionState.ExecutionStatus == constant.Successed && len(param.SloRules) > 0 {
+				l.evaluateSlo(param, loadTestExecutionState)
+			}
+		}
+
+		// the slo rules can not be evaluated without the whole result, so the run which is not succeeded has the error verdict
+		if loadTestExecutionState.ExecutionStatus != constant.Successed && len(param.SloRules) > 0 {
+			loadTestExecutionState.Verdict = constant.VerdictError
+		}
+
 		updateErr := l.loadRepo.UpdateLoadTestExecutionStateTx(context.Background(), loadTestExecutionState)
 		if updateErr != nil {
 			utils.LogErrorf("Error updating load test execution state: %v", updateErr)
@@ -165,11 +359,24 @@ func (l *LoadService) processLoadTest(param RunLoadTestParam, loadGeneratorInsta
 		}
 	}()
 
-	compileDuration, executionDuration, loadTestErr := l.executeLoadTest(param, loadGeneratorInstallInfo)
+	compileDuration, executionDuration, loadTestErr := l.executeLoadTest(param, loadGeneratorInstallInfo, run)
 
 	loadTestExecutionState.CompileDuration = compileDuration
 	loadTestExecutionState.ExecutionDuration = executionDuration
 
+	// the engine exits with an error when it is killed, so the stop is checked before the error
+	if run.isStopped() {
+		utils.LogInfof("Load test %s is stopped. the partial result is fetched", param.LoadTestKey)
+		if updateErr := l.updateLoadTestExecution(loadTestExecutionState); updateErr != nil {
+			utils.LogErrorf("Error updating stopped load test %s: %v", param.LoadTestKey, updateErr)
+		}
+
+		loadTestExecutionState.ExecutionStatus = constant.Stopped
+		finishAt := time.Now()
+		loadTestExecutionState.FinishAt = &finishAt
+		return
+	}
+
 	if loadTestErr != nil {
 		loadTestExecutionState.ExecutionStatus = constant.TestFailed
 		loadTestExecutionState.FailureMessage = loadTestErr.Error()
@@ -190,12 +397,23 @@ func (l *LoadService) processLoadTest(param RunLoadTestParam, loadGeneratorInsta
 	loadTestExecutionState.ExecutionStatus = constant.Successed
 }
 
-func (l *LoadService) executeLoadTest(param RunLoadTestParam, loadGeneratorInstallInfo *LoadGeneratorInstallInfo) (string, string, error) {
+// evaluateSlo sets the slo results and the verdict of the load test execution state.
+func (l *LoadService) evaluateSlo(param RunLoadTestParam, loadTestExecutionState *LoadTestExecutionState) {
+	results, verdict, err := l.evaluateSloOfLoadTest(param.LoadTestKey, param.SloRules)
+	if err != nil {
+		utils.LogErrorf("Error evaluating slo of load test %s: %v", param.LoadTestKey, err)
+		loadTestExecutionState.FailureMessage = fmt.Sprintf("failed to evaluate slo; %s", err)
+	}
+
+	utils.LogInfof("Slo verdict of load test %s: %s", param.LoadTestKey, verdict)
+	loadTestExecutionState.Verdict = verdict
+	loadTestExecutionState.SloResults = results
+}
+
+func (l *LoadService) executeLoadTest(param RunLoadTestParam, loadGeneratorInstallInfo *LoadGeneratorInstallInfo, run *loadTestRun) (string, string, error) {
 	installLocation := loadGeneratorInstallInfo.InstallLocation
 	loadTestKey := param.LoadTestKey
 	loadGeneratorInstallPath := loadGeneratorInstallInfo.InstallPath
-	testPlanName := fmt.Sprintf("%s.jmx", loadTestKey)
-	resultFileName := fmt.Sprintf("%s_result.csv", loadTestKey)
 	loadGeneratorInstallVersion := loadGeneratorInstallInfo.InstallVersion
 
 	utils.LogInfof("Running load test with key: %s", loadTestKey)
@@ -203,42 +421,119 @@ func (l *LoadService) executeLoadTest(param RunLoadTestParam, loadGeneratorInsta
 	executionDuration := "0"
 	start := time.Now()
 
-	if installLocation == constant.Remote {
-		utils.LogInfo("Remote execute detected.")
-		var buf bytes.Buffer
-		err := parseTestPlanStructToString(&buf, param, loadGeneratorInstallInfo)
+	engine, err := getLoadGeneratorEngine(param.Engine)
+	if err != nil {
+		return compileDuration, executionDuration, err
+	}
+	testPlanName := engine.PlanFileName(loadTestKey)
+
+	if err := l.ensureEngineInstalled(run.ctx, engine, loadGeneratorInstallInfo); err != nil {
+		return compileDuration, executionDuration, err
+	}
+
+	if run.isStopped() {
+		return compileDuration, executionDuration, errLoadTestStopped
+	}
+
+	var storedPlan *LoadTestPlan
+	if param.TestPlanId != uint(0) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		plan, err := l.loadRepo.GetLoadTestPlanTx(ctx, param.TestPlanId)
+		cancel()
 		if err != nil {
-			return compileDuration, executionDuration, err
+			return compileDuration, executionDuration, fmt.Errorf("load test plan %d is not found; %w", param.TestPlanId, err)
 		}
+		storedPlan = &plan
+	}
+	dataDir := testPlanDataDir(loadGeneratorInstallPath, loadTestKey)
+
+	if installLocation == constant.Remote || installLocation == constant.Ssh {
+		utils.LogInfo("Remote execute detected.")
 
-		testPlan := buf.String()
+		var master LoadGeneratorServer
+		var workers []LoadGeneratorServer
+		for _, s := range loadGeneratorInstallInfo.LoadGeneratorServers {
+			if s.IsMaster {
+				master = s
+			} else if loadGeneratorInstallInfo.IsCluster && engine.SupportsDistributed() {
+				workers = append(workers, s)
+			}
+		}
 
-		createFileCmd := fmt.Sprintf("cat << 'EOF' > %s/test_plan/%s \n%s\nEOF", loadGeneratorInstallPath, testPlanName, testPlan)
+		renderParam := param
+		if len(workers) > 1 {
+			if storedPlan != nil {
+				utils.LogWarnf("Stored test plan of load test %s is run by each of %d workers with its own load", loadTestKey, len(workers))
+			} else {
+				renderParam = param.splitAcross(len(workers))
+				utils.LogInfof("Load of load test %s is split across %d workers", loadTestKey, len(workers))
+			}
+		}
 
-		commandReq := tumblebug.SendCommandReq{
-			Command: []string{createFileCmd},
+		var buf bytes.Buffer
+		err := engine.RenderPlan(&buf, renderParam, loadGeneratorInstallInfo, storedPlan)
+		if err != nil {
+			return compileDuration, executionDuration, err
 		}
 
+		planPath := fmt.Sprintf("%s/test_plan/%s", loadGeneratorInstallPath, testPlanName)
+		files := append([]loadTestFile{{Path: planPath, Content: buf.Bytes()}}, dataFilesOf(dataDir, storedPlan)...)
+
 		compileDuration = utils.DurationString(start)
-		_, err = l.tumblebugClient.CommandToMciWithContext(context.Background(), antNsId, antMciId, commandReq)
+		err = l.uploadLoadTestFiles(run.ctx, loadGeneratorInstallInfo, files)
 		if err != nil {
 			return compileDuration, executionDuration, err
 		}
 
-		jmeterTestCommand := generateJmeterExecutionCmd(loadGeneratorInstallPath, loadGeneratorInstallVersion, testPlanName, resultFileName)
+		var remoteHosts []string
+		if len(workers) > 0 {
+			// the jmeter servers only live for the load test, so they are stopped whether it succeeds or not
+			defer l.stopJmeterServers(loadGeneratorInstallInfo, master, workers, loadTestKey)
+
+			err = l.prepareRmiSsl(run.ctx, loadGeneratorInstallInfo, master, loadTestKey)
+			if err != nil {
+				return compileDuration, executionDuration, err
+			}
+
+			for _, s := range workers {
+				err = l.startJmeterServer(loadGeneratorInstallInfo.mciId(), loadGeneratorInstallPath, loadGeneratorInstallVersion, s, loadTestKey)
+				if err != nil {
+					return compileDuration, executionDuration, err
+				}
+				remoteHosts = append(remoteHosts, s.PrivateIp)
+			}
+		}
+
+		testCommand := engine.ExecutionCmd(loadGeneratorInstallInfo, loadTestKey, remoteHosts)
+		if storedPlan != nil && len(storedPlan.DataFiles) > 0 {
+			testCommand += fmt.Sprintf(" && sudo rm -rf %s", dataDir)
+		}
 
-		commandReq = tumblebug.SendCommandReq{
-			Command: []string{jmeterTestCommand},
+		if !l.startLoadTestRun(run) {
+			return compileDuration, executionDuration, errLoadTestStopped
 		}
 
-		stdout, err := l.tumblebugClient.CommandToMciWithContext(context.Background(), antNsId, antMciId, commandReq)
+		// the command is not canceled by the stop, it returns when the engine is killed
+		var stdout string
+		if len(remoteHosts) > 0 {
+			// only the master drives the test in distributed mode, workers receive the plan through rmi
+			utils.LogInfof("Distributed execute detected. master: %s, workers: %v", master.VmId, remoteHosts)
+			stdout, err = l.commandToLoadGenerator(context.Background(), loadGeneratorInstallInfo, master.VmId, []string{testCommand})
+		} else if loadGeneratorInstallInfo.IsCluster {
+			// the engine can not distribute the load, so the test is run on the master only
+			utils.LogInfof("%s does not support distributed mode. run on master: %s", engine.Type(), master.VmId)
+			stdout, err = l.commandToLoadGenerator(context.Background(), loadGeneratorInstallInfo, master.VmId, []string{testCommand})
+		} else {
+			// the mci may have the idle workers of the former distributed install, so only the master runs the test
+			stdout, err = l.commandToLoadGenerator(context.Background(), loadGeneratorInstallInfo, master.VmId, []string{testCommand})
+		}
 		if err != nil {
 			return compileDuration, executionDuration, err
 		}
 		executionDuration = utils.DurationString(start)
 
 		if strings.Contains(stdout, "exited with status 1") {
-			return compileDuration, executionDuration, errors.New("jmeter test stopped unexpectedly")
+			return compileDuration, executionDuration, fmt.Errorf("%s test stopped unexpectedly", engine.Type())
 		}
 
 	} else if installLocation == constant.Local {
@@ -250,24 +545,52 @@ func (l *LoadService) executeLoadTest(param RunLoadTestParam, loadGeneratorInsta
 			return compileDuration, executionDuration, errors.New("load generator installaion is not validated")
 		}
 
-		outputFile, err := os.Create(fmt.Sprintf("%s/test_plan/%s.jmx", loadGeneratorInstallPath, loadTestKey))
+		if runner, ok := engine.(inProcessEngine); ok {
+			resultPath := fmt.Sprintf("%s/result/%s", loadGeneratorInstallPath, resultFileNameOf(loadTestKey))
+			compileDuration = utils.DurationString(start)
+
+			if !l.startLoadTestRun(run) {
+				return compileDuration, executionDuration, errLoadTestStopped
+			}
+
+			err := runner.Run(run.ctx, param, resultPath)
+			executionDuration = utils.DurationString(start)
+			if err != nil {
+				return compileDuration, executionDuration, fmt.Errorf("%s test stopped unexpectedly; %w", engine.Type(), err)
+			}
+
+			return compileDuration, executionDuration, nil
+		}
+
+		outputFile, err := os.Create(fmt.Sprintf("%s/test_plan/%s", loadGeneratorInstallPath, testPlanName))
 		if err != nil {
 			return compileDuration, executionDuration, err
 		}
 
-		err = parseTestPlanStructToString(outputFile, param, loadGeneratorInstallInfo)
+		err = engine.RenderPlan(outputFile, param, loadGeneratorInstallInfo, storedPlan)
 
 		if err != nil {
 			return compileDuration, executionDuration, err
 		}
 
-		jmeterTestCommand := generateJmeterExecutionCmd(loadGeneratorInstallPath, loadGeneratorInstallVersion, testPlanName, resultFileName)
+		if storedPlan != nil && len(storedPlan.DataFiles) > 0 {
+			if err := writeLoadTestFiles(localFileTarget{}, dataFilesOf(dataDir, storedPlan)); err != nil {
+				return compileDuration, executionDuration, err
+			}
+			defer os.RemoveAll(dataDir)
+		}
+
+		testCommand := engine.ExecutionCmd(loadGeneratorInstallInfo, loadTestKey, nil)
 		compileDuration = utils.DurationString(start)
 
-		err = utils.InlineCmd(jmeterTestCommand)
+		if !l.startLoadTestRun(run) {
+			return compileDuration, executionDuration, errLoadTestStopped
+		}
+
+		err = utils.InlineCmd(testCommand)
 		executionDuration = utils.DurationString(start)
 		if err != nil {
-			return compileDuration, executionDuration, fmt.Errorf("jmeter test stopped unexpectedly; %w", err)
+			return compileDuration, executionDuration, fmt.Errorf("%s test stopped unexpectedly; %w", engine.Type(), err)
 		}
 	}
 
@@ -290,9 +613,127 @@ func (l *LoadService) updateLoadTestExecution(loadTestExecutionState *LoadTestEx
 	return nil
 }
 
+// rmiKeystorePath is the keystore of the rmi over ssl which the master and the workers share for the load test.
+func rmiKeystorePath(loadGeneratorInstallPath, loadTestKey string) string {
+	return fmt.Sprintf("%s/test_plan/%s_rmi_keystore.jks", loadGeneratorInstallPath, loadTestKey)
+}
+
+// rmiPropertiesPath is the jmeter properties of the rmi over ssl, which keeps the password of the keystore off the command line.
+func rmiPropertiesPath(loadGeneratorInstallPath, loadTestKey string) string {
+	return fmt.Sprintf("%s/test_plan/%s_rmi.properties", loadGeneratorInstallPath, loadTestKey)
+}
+
+func rmiSslProperties(keystorePath, password string) []byte {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("server.rmi.ssl.keystore.file=%s\n", keystorePath))
+	builder.WriteString(fmt.Sprintf("server.rmi.ssl.keystore.password=%s\n", password))
+	builder.WriteString("server.rmi.ssl.keystore.alias=rmi\n")
+	builder.WriteString(fmt.Sprintf("server.rmi.ssl.truststore.file=%s\n", keystorePath))
+	builder.WriteString(fmt.Sprintf("server.rmi.ssl.truststore.password=%s\n", password))
+	return []byte(builder.String())
+}
+
+// generateRmiKeystoreCmd generates the command which creates the key pair of the rmi over ssl with keytool of the jre of jmeter.
+func generateRmiKeystoreCmd(keystorePath, password string) string {
+	return fmt.Sprintf("mkdir -p %s && rm -f %s && keytool -genkeypair -alias rmi -keyalg RSA -keysize 2048 -validity 1 -dname CN=jmeter-rmi -storetype JKS -keystore %s -storepass %s -keypass %s",
+		path.Dir(keystorePath), keystorePath, keystorePath, password, password)
+}
+
+// prepareRmiSsl creates the keystore of the load test on the master and copies it with the properties to every server,
+// so only the servers of the load test trust each other over rmi.
+func (l *LoadService) prepareRmiSsl(ctx context.Context, info *LoadGeneratorInstallInfo, master LoadGeneratorServer, loadTestKey string) error {
+	secret := make([]byte, 16)
+	if _, err := rand.Read(secret); err != nil {
+		return err
+	}
+	password := hex.EncodeToString(secret)
+	keystorePath := rmiKeystorePath(info.InstallPath, loadTestKey)
+
+	_, err := l.commandToLoadGenerator(ctx, info, master.VmId, []string{generateRmiKeystoreCmd(keystorePath, password)})
+	if err != nil {
+		return fmt.Errorf("failed to create rmi keystore on master %s; %w", master.VmId, err)
+	}
+
+	privateKey, err := l.loadGeneratorPrivateKey(ctx, info.ID, info.PrivateKeyName)
+	if err != nil {
+		return err
+	}
+
+	keystore, err := sftpDownload(ctx, master, privateKey, keystorePath)
+	if err != nil {
+		return fmt.Errorf("failed to read rmi keystore from master %s; %w", master.VmId, err)
+	}
+
+	return l.uploadLoadTestFiles(ctx, info, []loadTestFile{
+		{Path: keystorePath, Content: keystore},
+		{Path: rmiPropertiesPath(info.InstallPath, loadTestKey), Content: rmiSslProperties(keystorePath, password)},
+	})
+}
+
+// stopJmeterServers stops the jmeter servers of the load test and removes the files of the rmi over ssl.
+func (l *LoadService) stopJmeterServers(info *LoadGeneratorInstallInfo, master LoadGeneratorServer, workers []LoadGeneratorServer, loadTestKey string) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	removeCmd := fmt.Sprintf("rm -f %s %s", rmiKeystorePath(info.InstallPath, loadTestKey), rmiPropertiesPath(info.InstallPath, loadTestKey))
+	for _, w := range workers {
+		if _, err := l.commandToLoadGenerator(ctx, info, w.VmId, []string{generateJmeterServerStopCmd(), removeCmd}); err != nil {
+			utils.LogErrorf("Error stopping jmeter server on worker %s: %v", w.VmId, err)
+		}
+	}
+
+	if _, err := l.commandToLoadGenerator(ctx, info, master.VmId, []string{removeCmd}); err != nil {
+		utils.LogErrorf("Error removing rmi keystore on master %s: %v", master.VmId, err)
+	}
+}
+
+// startJmeterServer starts the jmeter server of the load test on the worker vm.
+func (l *LoadService) startJmeterServer(mciId, loadGeneratorInstallPath, loadGeneratorInstallVersion string, worker LoadGeneratorServer, loadTestKey string) error {
+	commandReq := tumblebug.SendCommandReq{
+		Command: []string{generateJmeterServerStartCmd(loadGeneratorInstallPath, loadGeneratorInstallVersion, worker.PrivateIp, rmiPropertiesPath(loadGeneratorInstallPath, loadTestKey))},
+	}
+
+	utils.LogInfof("Starting jmeter server on worker vm: %s", worker.VmId)
+	_, err := l.tumblebugClient.CommandToVmWithContext(context.Background(), antNsId, mciId, worker.VmId, commandReq)
+	if err != nil {
+		return fmt.Errorf("failed to start jmeter server on worker %s; %w", worker.VmId, err)
+	}
+
+	return nil
+}
+
+// jmeterServerProcessRegex matches the jmeter server, whose escaped dot does not match the command itself.
+const jmeterServerProcessRegex = "'ApacheJMeter\\.jar.* -s'"
+
+// generateJmeterServerStartCmd generates the command which runs jmeter-server in background and waits until it listens.
+// The server left by the former load test is stopped, since it trusts the keystore of that load test.
+// RMI ports are fixed so that the master can reach the worker over the private network,
+// and the server is bound to the private ip with the rmi over ssl of the properties.
+func generateJmeterServerStartCmd(loadGeneratorInstallPath, loadGeneratorInstallVersion, hostname, rmiPropertiesPath string) string {
+	jmeterBinPath := fmt.Sprintf("%s/apache-jmeter-%s/bin", loadGeneratorInstallPath, loadGeneratorInstallVersion)
+
+	var builder strings.Builder
+	builder.WriteString(generateJmeterServerStopCmd() + "; ")
+	builder.WriteString(fmt.Sprintf("(cd %s && nohup ./jmeter-server", jmeterBinPath))
+	builder.WriteString(fmt.Sprintf(" -q %s", rmiPropertiesPath))
+	builder.WriteString(fmt.Sprintf(" -Dserver_port=%s", jmeterServerPort))
+	builder.WriteString(fmt.Sprintf(" -Jserver.rmi.localport=%s", jmeterServerRmiLocalPort))
+	builder.WriteString(fmt.Sprintf(" -Djava.rmi.server.hostname=%s", hostname))
+	builder.WriteString(fmt.Sprintf(" > %s/jmeter-server.log 2>&1 &)", loadGeneratorInstallPath))
+	builder.WriteString(fmt.Sprintf("; for i in $(seq 1 30); do ss -ltn | grep -q ':%s ' && break; sleep 1; done", jmeterServerPort))
+	return builder.String()
+}
+
+// generateJmeterServerStopCmd generates the command which stops the jmeter server.
+func generateJmeterServerStopCmd() string {
+	return fmt.Sprintf("pkill -f %s || true", jmeterServerProcessRegex)
+}
+
 // generateJmeterExecutionCmd generates the JMeter execution command.
 // Constructs a JMeter command string that includes the test plan path and result file path.
-func generateJmeterExecutionCmd(loadGeneratorInstallPath, loadGeneratorInstallVersion, testPlanName, resultFileName string) string {
+// If remoteHosts is not empty, the test is distributed to the jmeter servers running on those hosts over rmi over ssl,
+// which call back to the master at its hostname and the fixed client rmi port.
+func generateJmeterExecutionCmd(loadGeneratorInstallPath, loadGeneratorInstallVersion, testPlanName, resultFileName, masterHostname, rmiPropertiesPath string, remoteHosts []string) string {
 	utils.LogInfof("Generating JMeter execution command for test plan: %s, result file: %s", testPlanName, resultFileName)
 
 	var builder strings.Builder
@@ -304,11 +745,115 @@ func generateJmeterExecutionCmd(loadGeneratorInstallPath, loadGeneratorInstallVe
 	builder.WriteString(fmt.Sprintf(" -t=%s", testPath))
 	builder.WriteString(fmt.Sprintf(" -l=%s", resultPath))
 
+	if len(remoteHosts) > 0 {
+		builder.WriteString(fmt.Sprintf(" -q %s", rmiPropertiesPath))
+		builder.WriteString(fmt.Sprintf(" -Jclient.rmi.localport=%s", jmeterClientRmiLocalPort))
+		if masterHostname != "" {
+			builder.WriteString(fmt.Sprintf(" -Djava.rmi.server.hostname=%s", masterHostname))
+		}
+		builder.WriteString(fmt.Sprintf(" -R %s", strings.Join(remoteHosts, ",")))
+	}
+
 	builder.WriteString(fmt.Sprintf(" && sudo rm %s", testPath))
 	utils.LogInfof("JMeter execution command generated: %s", builder.String())
 	return builder.String()
 }
 
+// errLoadTestStopped is returned when the load test is stopped before or while the engine runs.
+var errLoadTestStopped = errors.New("load test is stopped")
+
+// loadTestRunMap holds the runs of the load tests which are processed on this server by load test key.
+var loadTestRunMap sync.Map
+
+// loadTestRun guards the stop of the load test against the start of the engine,
+// so the stop either prevents the engine from starting or kills the started engine.
+type loadTestRun struct {
+	loadTestKey string
+	ctx         context.Context
+	cancel      context.CancelFunc
+
+	mu      sync.Mutex
+	started bool
+	stopped bool
+}
+
+func newLoadTestRun(loadTestKey string) *loadTestRun {
+	ctx, cancel := context.WithCancel(context.Background())
+	run := &loadTestRun{
+		loadTestKey: loadTestKey,
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+
+	loadTestRunMap.Store(loadTestKey, run)
+	return run
+}
+
+// start marks the engine as started. It returns false when the load test is already stopped.
+func (r *loadTestRun) start() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.stopped {
+		return false
+	}
+
+	r.started = true
+	return true
+}
+
+// stop marks the load test as stopped and cancels the preparation.
+// It returns true when the engine is already started and has to be killed.
+func (r *loadTestRun) stop() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.stopped = true
+	r.cancel()
+	return r.started
+}
+
+func (r *loadTestRun) isStarted() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.started
+}
+
+func (r *loadTestRun) isStopped() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.stopped
+}
+
+func (r *loadTestRun) done() {
+	loadTestRunMap.Delete(r.loadTestKey)
+	progressAggregators.Delete(r.loadTestKey)
+	r.cancel()
+}
+
+// startLoadTestRun marks the engine of the run as started and records the running status,
+// so the recovery after the restart of the server can tell the load test reached the engine.
+func (l *LoadService) startLoadTestRun(run *loadTestRun) bool {
+	if !run.start() {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := l.loadRepo.UpdateLoadTestExecutionStatusTx(ctx, run.loadTestKey, constant.OnRunning); err != nil {
+		utils.LogErrorf("Error updating status of load test %s: %v", run.loadTestKey, err)
+	}
+
+	return true
+}
+
+// StopLoadTest stops the load test which is not finished yet.
+// The queued load test is removed from the queue, the load test in preparation is canceled before the engine starts,
+// and the running engine is killed.
+// The process of the load test records the stopped state after the partial result is fetched.
 func (l *LoadService) StopLoadTest(param StopLoadTestParam) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -321,20 +866,56 @@ func (l *LoadService) StopLoadTest(param StopLoadTestParam) error {
 		return err
 	}
 
-	if state.ExecutionStatus == constant.Successed {
+	if !slices.Contains(activeExecutionStatuses, state.ExecutionStatus) {
+		utils.LogInfof("Load test %s is already finished with status %s", param.LoadTestKey, state.ExecutionStatus)
 		return nil
 	}
 
-	installInfo := state.LoadGeneratorInstallInfo
-
-	killCmd := killCmdGen(param.LoadTestKey)
+	if state.ExecutionStatus == constant.Queued && l.cancelQueuedLoadTest(param.LoadTestKey) {
+		return nil
+	}
 
-	if installInfo.InstallLocation == constant.Remote {
+	engine, err := l.loadTestEngine(param.LoadTestKey)
+	if err != nil {
+		return err
+	}
 
-		commandReq := tumblebug.SendCommandReq{
-			Command: []string{killCmd},
+	r, ok := loadTestRunMap.Load(param.LoadTestKey)
+	if !ok {
+		// no process of this server runs the load test, so the engine is killed and the state is closed here
+		utils.LogWarnf("Load test %s is not processed by this server. kill the engine and mark it stopped", param.LoadTestKey)
+		if err := l.killLoadTestEngine(ctx, engine, param.LoadTestKey, state.LoadGeneratorInstallInfo); err != nil {
+			utils.LogWarnf("Error killing load test %s: %v", param.LoadTestKey, err)
 		}
-		_, err := l.tumblebugClient.CommandToMciWithContext(ctx, antNsId, antMciId, commandReq)
+
+		finishAt := time.Now()
+		state.ExecutionStatus = constant.Stopped
+		state.FinishAt = &finishAt
+		return l.loadRepo.UpdateLoadTestExecutionStateTx(ctx, &state)
+	}
+
+	run := r.(*loadTestRun)
+	if !run.stop() {
+		utils.LogInfof("Load test %s is stopped before the engine starts", param.LoadTestKey)
+		return nil
+	}
+
+	if _, ok := engine.(inProcessEngine); ok {
+		// the engine in process is stopped by the canceled context of the run
+		return nil
+	}
+
+	return l.killLoadTestEngine(ctx, engine, param.LoadTestKey, state.LoadGeneratorInstallInfo)
+}
+
+func (l *LoadService) killLoadTestEngine(ctx context.Context, engine LoadGeneratorEngine, loadTestKey string, installInfo LoadGeneratorInstallInfo) error {
+	killCmd := engine.KillCmd(loadTestKey)
+	if killCmd == "" {
+		return nil
+	}
+
+	if installInfo.InstallLocation == constant.Remote || installInfo.InstallLocation == constant.Ssh {
+		_, err := l.commandToLoadGenerator(ctx, &installInfo, "", []string{killCmd})
 
 		if err != nil {
 			return err
@@ -350,11 +931,14 @@ func (l *LoadService) StopLoadTest(param StopLoadTestParam) error {
 	}
 
 	return nil
+}
 
+func jmeterProcessRegex(loadTestKey string) string {
+	return fmt.Sprintf("'\\/bin\\/ApacheJMeter\\.jar.*%s'", loadTestKey)
 }
 
 func killCmdGen(loadTestKey string) string {
-	grepRegex := fmt.Sprintf("'\\/bin\\/ApacheJMeter\\.jar.*%s'", loadTestKey)
+	grepRegex := jmeterProcessRegex(loadTestKey)
 	utils.LogInfof("Generating kill command for load test key: %s", loadTestKey)
-	return fmt.Sprintf("kill -15 $(ps -ef | grep -E %s | awk '{print $2}')", grepRegex)
+	return fmt.Sprintf("ps -ef | grep -E %s | grep -v grep | awk '{print $2}' | xargs -r kill -15", grepRegex)
 }
@@ -0,0 +1,77 @@
+package load
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTestRunStop(t *testing.T) {
+	// stopped in preparation, the engine never starts
+	run := newLoadTestRun("preparing")
+	require.False(t, run.stop())
+	require.False(t, run.start())
+	require.True(t, run.isStopped())
+	require.Error(t, run.ctx.Err())
+
+	run.done()
+	_, ok := loadTestRunMap.Load("preparing")
+	require.False(t, ok)
+
+	// stopped after the start, the engine has to be killed
+	run = newLoadTestRun("running")
+	defer run.done()
+
+	require.True(t, run.start())
+	require.False(t, run.isStopped())
+	require.True(t, run.stop())
+	require.True(t, run.isStopped())
+}
+
+func TestGenerateJmeterDistributedCmd(t *testing.T) {
+	properties := rmiPropertiesPath("/opt/ant/jmeter", "key")
+
+	server := generateJmeterServerStartCmd("/opt/ant/jmeter", "5.6", "10.0.0.6", properties)
+	require.Contains(t, server, "-q /opt/ant/jmeter/test_plan/key_rmi.properties")
+	require.Contains(t, server, "-Jserver.rmi.localport=50000")
+	require.Contains(t, server, "-Djava.rmi.server.hostname=10.0.0.6")
+	require.NotContains(t, server, "ssl.disable")
+
+	master := generateJmeterExecutionCmd("/opt/ant/jmeter", "5.6", "key.jmx", "key_result.csv", "10.0.0.5", properties, []string{"10.0.0.6", "10.0.0.7"})
+	require.Contains(t, master, "-q /opt/ant/jmeter/test_plan/key_rmi.properties")
+	require.Contains(t, master, "-Jclient.rmi.localport=50010")
+	require.Contains(t, master, "-Djava.rmi.server.hostname=10.0.0.5")
+	require.Contains(t, master, "-R 10.0.0.6,10.0.0.7")
+	require.NotContains(t, master, "ssl.disable")
+
+	single := generateJmeterExecutionCmd("/opt/ant/jmeter", "5.6", "key.jmx", "key_result.csv", "10.0.0.5", properties, nil)
+	require.NotContains(t, single, "rmi")
+
+	// the password of the keystore is only in the properties
+	keystore := rmiKeystorePath("/opt/ant/jmeter", "key")
+	require.Equal(t, "server.rmi.ssl.keystore.file=/opt/ant/jmeter/test_plan/key_rmi_keystore.jks\n"+
+		"server.rmi.ssl.keystore.password=secret\n"+
+		"server.rmi.ssl.keystore.alias=rmi\n"+
+		"server.rmi.ssl.truststore.file=/opt/ant/jmeter/test_plan/key_rmi_keystore.jks\n"+
+		"server.rmi.ssl.truststore.password=secret\n", string(rmiSslProperties(keystore, "secret")))
+}
+
+func TestRedactedHeadersAndCookies(t *testing.T) {
+	headers := []RunLoadTestHttpHeaderParam{
+		{Name: "Authorization", Value: "Bearer abc"},
+		{Name: "X-Api-Key", Value: "key"},
+		{Name: "X-Session-Id", Value: "sid"},
+		{Name: "Accept", Value: "application/json"},
+	}
+
+	require.Equal(t, []RunLoadTestHttpHeaderParam{
+		{Name: "Authorization", Value: redactedSecret},
+		{Name: "X-Api-Key", Value: redactedSecret},
+		{Name: "X-Session-Id", Value: redactedSecret},
+		{Name: "Accept", Value: "application/json"},
+	}, redactedHeaders(headers))
+	require.Equal(t, "Bearer abc", headers[0].Value)
+
+	require.Equal(t, []RunLoadTestHttpCookieParam{{Name: "JSESSIONID", Value: redactedSecret}},
+		redactedCookies([]RunLoadTestHttpCookieParam{{Name: "JSESSIONID", Value: "s1"}}))
+}
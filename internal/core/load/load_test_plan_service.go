@@ -0,0 +1,311 @@
+package load
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/cloud-barista/cm-ant/internal/utils"
+	"github.com/pkg/sftp"
+)
+
+const (
+	maxLoadTestPlanFileSize     = 5 << 20
+	maxLoadTestPlanDataFileSize = 10 << 20
+)
+
+var dataFileNameRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// UploadLoadTestPlan validates the uploaded jmx file and its data files and stores them into the plan library.
+// Every data file referenced by the csv data set configs of the plan must be uploaded together.
+func (l *LoadService) UploadLoadTestPlan(param UploadLoadTestPlanParam) (LoadTestPlanResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var res LoadTestPlanResult
+
+	if !strings.EqualFold(filepath.Ext(param.FileName), ".jmx") {
+		return res, fmt.Errorf("test plan file %s must be a jmx file", param.FileName)
+	}
+
+	if len(param.Content) > maxLoadTestPlanFileSize {
+		return res, fmt.Errorf("test plan file must not be larger than %d bytes", maxLoadTestPlanFileSize)
+	}
+
+	info, err := inspectJmxTestPlan(param.Content)
+	if err != nil {
+		return res, err
+	}
+
+	plan := LoadTestPlan{
+		Name:        strings.TrimSpace(param.Name),
+		Description: param.Description,
+		FileName:    filepath.Base(param.FileName),
+		Content:     string(param.Content),
+	}
+
+	if plan.Name == "" {
+		plan.Name = strings.TrimSuffix(plan.FileName, filepath.Ext(plan.FileName))
+	}
+
+	uploaded := make(map[string]bool)
+	for _, f := range param.DataFiles {
+		name := filepath.Base(f.FileName)
+		if !dataFileNameRegex.MatchString(name) {
+			return res, fmt.Errorf("data file name %s is not allowed", f.FileName)
+		}
+
+		if uploaded[name] {
+			return res, fmt.Errorf("data file %s is duplicated", name)
+		}
+
+		if len(f.Content) > maxLoadTestPlanDataFileSize {
+			return res, fmt.Errorf("data file %s must not be larger than %d bytes", name, maxLoadTestPlanDataFileSize)
+		}
+
+		uploaded[name] = true
+		plan.DataFiles = append(plan.DataFiles, LoadTestPlanDataFile{
+			FileName: name,
+			Size:     len(f.Content),
+			Content:  string(f.Content),
+		})
+	}
+
+	for _, n := range info.DataFileNames {
+		name := dataFileBaseName(n)
+		if name == "" {
+			utils.LogWarnf("Data file %s of test plan %s can not be resolved and is used as is", n, plan.FileName)
+			continue
+		}
+
+		if !uploaded[name] {
+			return res, fmt.Errorf("data file %s referenced by the test plan is not uploaded", name)
+		}
+	}
+
+	err = l.loadRepo.InsertLoadTestPlanTx(ctx, &plan)
+	if err != nil {
+		utils.LogErrorf("Error inserting load test plan: %v", err)
+		return res, err
+	}
+
+	utils.LogInfof("Load test plan %d uploaded with %d thread groups and %d data files", plan.ID, info.ThreadGroupCount, len(plan.DataFiles))
+	return mapLoadTestPlanResult(plan), nil
+}
+
+func (l *LoadService) GetAllLoadTestPlans(param GetAllLoadTestPlansParam) (GetAllLoadTestPlansResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var res GetAllLoadTestPlansResult
+
+	plans, totalRows, err := l.loadRepo.GetPagingLoadTestPlansTx(ctx, param)
+	if err != nil {
+		utils.LogErrorf("Error fetching load test plans: %v", err)
+		return res, err
+	}
+
+	for _, p := range plans {
+		res.LoadTestPlans = append(res.LoadTestPlans, mapLoadTestPlanResult(p))
+	}
+	res.TotalRow = totalRows
+
+	return res, nil
+}
+
+func (l *LoadService) GetLoadTestPlan(id uint) (LoadTestPlanResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	plan, err := l.loadRepo.GetLoadTestPlanTx(ctx, id)
+	if err != nil {
+		utils.LogErrorf("Error fetching load test plan %d: %v", id, err)
+		return LoadTestPlanResult{}, err
+	}
+
+	return mapLoadTestPlanResult(plan), nil
+}
+
+func (l *LoadService) DeleteLoadTestPlan(id uint) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := l.loadRepo.GetLoadTestPlanTx(ctx, id); err != nil {
+		utils.LogErrorf("Error fetching load test plan %d: %v", id, err)
+		return err
+	}
+
+	err := l.loadRepo.DeleteLoadTestPlanTx(ctx, id)
+	if err != nil {
+		utils.LogErrorf("Error deleting load test plan %d: %v", id, err)
+		return err
+	}
+
+	utils.LogInfof("Load test plan %d deleted", id)
+	return nil
+}
+
+// loadTestFile is the file which is placed on the load generator before the load test runs.
+type loadTestFile struct {
+	Path    string
+	Content []byte
+}
+
+// dataFilesOf returns the data files of the stored test plan to place on the load generator.
+func dataFilesOf(dataDir string, storedPlan *LoadTestPlan) []loadTestFile {
+	if storedPlan == nil {
+		return nil
+	}
+
+	var files []loadTestFile
+	for _, f := range storedPlan.DataFiles {
+		files = append(files, loadTestFile{Path: path.Join(dataDir, f.FileName), Content: []byte(f.Content)})
+	}
+
+	return files
+}
+
+// loadTestFileTarget is the file system of the load generator which the files of the load test are written to.
+type loadTestFileTarget interface {
+	MkdirAll(path string) error
+	Create(path string) (io.WriteCloser, error)
+}
+
+type localFileTarget struct{}
+
+func (localFileTarget) MkdirAll(path string) error {
+	return os.MkdirAll(path, 0755)
+}
+
+func (localFileTarget) Create(path string) (io.WriteCloser, error) {
+	return os.Create(path)
+}
+
+type sftpFileTarget struct {
+	client *sftp.Client
+}
+
+func (s sftpFileTarget) MkdirAll(path string) error {
+	return s.client.MkdirAll(path)
+}
+
+func (s sftpFileTarget) Create(path string) (io.WriteCloser, error) {
+	return s.client.Create(path)
+}
+
+// writeLoadTestFiles writes the files to the target as they are.
+// The contents are not passed through the shell, so the plan is never run as commands whatever lines it has.
+func writeLoadTestFiles(dst loadTestFileTarget, files []loadTestFile) error {
+	for _, f := range files {
+		if err := dst.MkdirAll(path.Dir(f.Path)); err != nil {
+			return fmt.Errorf("failed to create directory of %s; %w", f.Path, err)
+		}
+
+		w, err := dst.Create(f.Path)
+		if err != nil {
+			return fmt.Errorf("failed to create %s; %w", f.Path, err)
+		}
+
+		_, err = io.Copy(w, bytes.NewReader(f.Content))
+		if cerr := w.Close(); err == nil {
+			err = cerr
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to write %s; %w", f.Path, err)
+		}
+	}
+
+	return nil
+}
+
+// uploadLoadTestFiles writes the files on every server of the remote load generator over sftp,
+// so the files of any size are streamed rather than inlined into the commands.
+func (l *LoadService) uploadLoadTestFiles(ctx context.Context, info *LoadGeneratorInstallInfo, files []loadTestFile) error {
+	privateKey, err := l.loadGeneratorPrivateKey(ctx, info.ID, info.PrivateKeyName)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range info.LoadGeneratorServers {
+		if err := sftpUpload(ctx, s, privateKey, files); err != nil {
+			return fmt.Errorf("failed to upload the files of the load test to %s; %w", s.PublicIp, err)
+		}
+	}
+
+	return nil
+}
+
+func sftpUpload(ctx context.Context, server LoadGeneratorServer, privateKey []byte, files []loadTestFile) error {
+	sshClient, err := sshClientOf(server, privateKey)
+	if err != nil {
+		return err
+	}
+	defer sshClient.Close()
+
+	// closing the connection makes the running upload return when the context is done
+	stop := context.AfterFunc(ctx, func() { sshClient.Close() })
+	defer stop()
+
+	client, err := sftp.NewClient(sshClient)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	return writeLoadTestFiles(sftpFileTarget{client}, files)
+}
+
+// sftpDownload reads the file of the server over sftp.
+func sftpDownload(ctx context.Context, server LoadGeneratorServer, privateKey []byte, filePath string) ([]byte, error) {
+	sshClient, err := sshClientOf(server, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	defer sshClient.Close()
+
+	stop := context.AfterFunc(ctx, func() { sshClient.Close() })
+	defer stop()
+
+	client, err := sftp.NewClient(sshClient)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	f, err := client.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return io.ReadAll(f)
+}
+
+func mapLoadTestPlanResult(p LoadTestPlan) LoadTestPlanResult {
+	var dataFiles []LoadTestPlanDataFileResult
+	for _, f := range p.DataFiles {
+		dataFiles = append(dataFiles, LoadTestPlanDataFileResult{
+			ID:       f.ID,
+			FileName: f.FileName,
+			Size:     f.Size,
+		})
+	}
+
+	return LoadTestPlanResult{
+		ID:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		FileName:    p.FileName,
+		DataFiles:   dataFiles,
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+	}
+}
@@ -0,0 +1,40 @@
+package load
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteLoadTestFiles(t *testing.T) {
+	dir := t.TempDir()
+	marker := filepath.Join(dir, "injected")
+
+	// the lines of the plan which end a heredoc are written as they are rather than run
+	plan := []byte(fmt.Sprintf("<jmeterTestPlan>\nEOF\ntouch %s\n'EOF'\n</jmeterTestPlan>\n", marker))
+	data := bytes.Repeat([]byte("user,password\n"), maxLoadTestPlanDataFileSize/14)
+
+	dataDir := filepath.Join(dir, "data", "key")
+	storedPlan := &LoadTestPlan{DataFiles: []LoadTestPlanDataFile{{FileName: "users.csv", Content: string(data)}}}
+	planPath := filepath.Join(dir, "test_plan", "key.jmx")
+
+	files := append([]loadTestFile{{Path: planPath, Content: plan}}, dataFilesOf(dataDir, storedPlan)...)
+	require.NoError(t, writeLoadTestFiles(localFileTarget{}, files))
+
+	written, err := os.ReadFile(planPath)
+	require.NoError(t, err)
+	require.Equal(t, plan, written)
+
+	written, err = os.ReadFile(filepath.Join(dataDir, "users.csv"))
+	require.NoError(t, err)
+	require.Equal(t, data, written)
+
+	_, err = os.Stat(marker)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	require.Empty(t, dataFilesOf(dataDir, nil))
+}
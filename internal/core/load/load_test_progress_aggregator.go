@@ -0,0 +1,183 @@
+package load
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// MaxProgressWindow is the longest rolling window of the progress, which the samples are kept for.
+const MaxProgressWindow = 10 * time.Minute
+
+// progressAggregators holds the progress aggregator of each running load test, shared by all subscribers of its progress.
+var progressAggregators sync.Map
+
+type progressLabel struct {
+	totalRequestCount int
+	totalErrorCount   int
+	lastSampleAt      time.Time
+
+	// samples are the samples within the max window before the last sample of the load test.
+	samples []*ResultRawData
+}
+
+// progressAggregator keeps the progress of the running load test. It is fed with the complete lines
+// appended to the fetched result file after the offset read last, so the result file is not read again from the start.
+type progressAggregator struct {
+	mu       sync.Mutex
+	engine   LoadGeneratorEngine
+	filePath string
+	offset   int64
+	header   []string
+	last     time.Time
+	labels   map[string]*progressLabel
+}
+
+func newProgressAggregator(engine LoadGeneratorEngine, filePath string) *progressAggregator {
+	return &progressAggregator{
+		engine:   engine,
+		filePath: filePath,
+		labels:   make(map[string]*progressLabel),
+	}
+}
+
+// progressAggregatorOf returns the progress aggregator of the load test, which is created by the first subscriber.
+func progressAggregatorOf(loadTestKey string, engine LoadGeneratorEngine, filePath string) *progressAggregator {
+	a, _ := progressAggregators.LoadOrStore(loadTestKey, newProgressAggregator(engine, filePath))
+	return a.(*progressAggregator)
+}
+
+// update reads the lines appended to the result file since the last update and adds their samples.
+// The progress is aggregated again from the start when the result file is shorter than the offset, which means it is rewritten.
+func (a *progressAggregator) update() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	f, err := os.Open(a.filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	if info.Size() < a.offset {
+		a.offset, a.header, a.last = 0, nil, time.Time{}
+		a.labels = make(map[string]*progressLabel)
+	}
+
+	if info.Size() == a.offset {
+		return nil
+	}
+
+	b := make([]byte, info.Size()-a.offset)
+	if _, err := f.ReadAt(b, a.offset); err != nil && err != io.EOF {
+		return err
+	}
+
+	// the last line may be written halfway, so it is read by the next update
+	end := bytes.LastIndexByte(b, '\n')
+	if end < 0 {
+		return nil
+	}
+
+	reader := csv.NewReader(bytes.NewReader(b[:end+1]))
+	reader.FieldsPerRecord = -1
+
+	var rows [][]string
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// the broken line is skipped like the result file is parsed
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	a.offset += int64(end + 1)
+
+	if a.header == nil {
+		if len(rows) == 0 {
+			return nil
+		}
+		a.header, rows = rows[0], rows[1:]
+	}
+
+	if len(rows) == 0 {
+		return nil
+	}
+
+	resultMap, err := a.engine.ParseResultRows(append([][]string{a.header}, rows...))
+	if err != nil {
+		return err
+	}
+
+	a.add(resultMap)
+	return nil
+}
+
+func (a *progressAggregator) add(resultMap map[string][]*ResultRawData) {
+	for label, results := range resultMap {
+		l, ok := a.labels[label]
+		if !ok {
+			l = &progressLabel{}
+			a.labels[label] = l
+		}
+
+		for _, r := range results {
+			l.totalRequestCount++
+			if r.IsError {
+				l.totalErrorCount++
+			}
+			if r.Timestamp.After(l.lastSampleAt) {
+				l.lastSampleAt = r.Timestamp
+			}
+			if r.Timestamp.After(a.last) {
+				a.last = r.Timestamp
+			}
+			l.samples = append(l.samples, r)
+		}
+	}
+
+	keepFrom := a.last.Add(-MaxProgressWindow)
+	for _, l := range a.labels {
+		// the samples of the workers are not in the order of the timestamp
+		kept := l.samples[:0]
+		for _, r := range l.samples {
+			if !r.Timestamp.Before(keepFrom) {
+				kept = append(kept, r)
+			}
+		}
+		l.samples = kept
+	}
+}
+
+// statistics returns the totals of each label and the rolling statistics of the samples within the window.
+func (a *progressAggregator) statistics(window time.Duration) []LoadTestProgressStatistics {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	resultSummaries := make([]ResultSummary, 0, len(a.labels))
+	for label, l := range a.labels {
+		resultSummaries = append(resultSummaries, ResultSummary{Label: label, Results: l.samples})
+	}
+
+	statistics := progressStatistics(resultSummaries, window)
+	for i := range statistics {
+		l := a.labels[statistics[i].Label]
+		statistics[i].TotalRequestCount = l.totalRequestCount
+		statistics[i].TotalErrorCount = l.totalErrorCount
+		statistics[i].LastSampleAt = l.lastSampleAt
+	}
+
+	return statistics
+}
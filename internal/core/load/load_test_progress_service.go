@@ -0,0 +1,181 @@
+package load
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/cloud-barista/cm-ant/internal/utils"
+)
+
+// GetLoadTestProgress computes the current progress of the load test from the result files
+// which are fetched into the result folder while the load test is running.
+// The progress of the running load test is kept by its aggregator, which reads only the lines fetched since the last call.
+func (l *LoadService) GetLoadTestProgress(loadTestKey string, window time.Duration) (LoadTestProgress, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res := LoadTestProgress{
+		LoadTestKey: loadTestKey,
+		Window:      window.String(),
+		UpdatedAt:   time.Now(),
+	}
+
+	state, err := l.loadRepo.GetLoadTestExecutionStateTx(ctx, GetLoadTestExecutionStateParam{LoadTestKey: loadTestKey})
+	if err != nil {
+		utils.LogErrorf("Error fetching load test execution state: %v", err)
+		return res, err
+	}
+
+	res.ExecutionStatus = state.ExecutionStatus
+	res.Finished = !slices.Contains(activeExecutionStatuses, state.ExecutionStatus)
+	res.Metrics = latestMetrics(loadTestKey)
+
+	if res.Finished {
+		progressAggregators.Delete(loadTestKey)
+
+		resultSummaries, err := l.loadResultSummaries(loadTestKey)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			utils.LogWarnf("Result of load test %s is not readable: %v", loadTestKey, err)
+		}
+
+		res.Statistics = progressStatistics(resultSummaries, window)
+		return res, nil
+	}
+
+	engine, err := l.loadTestEngine(loadTestKey)
+	if err != nil {
+		return res, err
+	}
+
+	resultFilePath := fmt.Sprintf("%s/%s", utils.JoinRootPathWith("/result/"+loadTestKey), resultFileNameOf(loadTestKey))
+	aggregator := progressAggregatorOf(loadTestKey, engine, resultFilePath)
+
+	if err := aggregator.update(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		utils.LogWarnf("Result of load test %s is not readable yet: %v", loadTestKey, err)
+	}
+
+	res.Statistics = aggregator.statistics(window)
+	return res, nil
+}
+
+// progressStatistics calculates the totals of each label and the rolling statistics of the samples
+// within the window which ends at the last sample of all labels.
+func progressStatistics(resultSummaries []ResultSummary, window time.Duration) []LoadTestProgressStatistics {
+	var last time.Time
+	for _, summary := range resultSummaries {
+		for _, r := range summary.Results {
+			if r.Timestamp.After(last) {
+				last = r.Timestamp
+			}
+		}
+	}
+
+	windowStart := last.Add(-window)
+	statistics := make([]LoadTestProgressStatistics, 0, len(resultSummaries))
+
+	for _, summary := range resultSummaries {
+		stat := LoadTestProgressStatistics{
+			Label: summary.Label,
+		}
+
+		var totalElapsed, errorCount int
+		var elapsedList []int
+
+		for _, r := range summary.Results {
+			stat.TotalRequestCount++
+			if r.IsError {
+				stat.TotalErrorCount++
+			}
+
+			if r.Timestamp.After(stat.LastSampleAt) {
+				stat.LastSampleAt = r.Timestamp
+			}
+
+			if r.Timestamp.Before(windowStart) {
+				continue
+			}
+
+			stat.RequestCount++
+			totalElapsed += r.Elapsed
+			elapsedList = append(elapsedList, r.Elapsed)
+			if r.IsError {
+				errorCount++
+			}
+		}
+
+		if stat.RequestCount > 0 {
+			sort.Ints(elapsedList)
+
+			stat.Rps = float64(stat.RequestCount) / window.Seconds()
+			stat.ErrorPercent = calculateErrorPercent(errorCount, stat.RequestCount)
+			stat.Average = float64(totalElapsed) / float64(stat.RequestCount)
+			stat.Median = calculateMedian(elapsedList)
+			stat.NinetyPercent = calculatePercentile(elapsedList, 0.9)
+			stat.NinetyFive = calculatePercentile(elapsedList, 0.95)
+			stat.NinetyNine = calculatePercentile(elapsedList, 0.99)
+		}
+
+		statistics = append(statistics, stat)
+	}
+
+	sort.Slice(statistics, func(i, j int) bool {
+		return statistics[i].Label < statistics[j].Label
+	})
+
+	return statistics
+}
+
+// latestMetrics returns the last fetched value of each perfmon metric.
+// Metrics files which are not fetched yet are ignored.
+func latestMetrics(loadTestKey string) []LoadTestProgressMetric {
+	resultFolderPath := utils.JoinRootPathWith("/result/" + loadTestKey)
+	metricsMap := make(map[string][]*MetricsRawData)
+
+	for _, v := range []string{"cpu", "disk", "memory", "network"} {
+		toPath := fmt.Sprintf("%s/%s_%s_result.csv", resultFolderPath, loadTestKey, v)
+		if !utils.ExistCheck(toPath) {
+			continue
+		}
+
+		m, err := appendMetricsRawData(metricsMap, toPath)
+		if err != nil {
+			continue
+		}
+		metricsMap = m
+	}
+
+	var metrics []LoadTestProgressMetric
+	for label, values := range metricsMap {
+		var latest *MetricsRawData
+		for _, v := range values {
+			if v.IsError {
+				continue
+			}
+			if latest == nil || v.Timestamp.After(latest.Timestamp) {
+				latest = v
+			}
+		}
+
+		if latest == nil {
+			continue
+		}
+
+		metrics = append(metrics, LoadTestProgressMetric{
+			Label:     label,
+			Value:     latest.Value,
+			Unit:      latest.Unit,
+			Timestamp: latest.Timestamp,
+		})
+	}
+
+	sort.Slice(metrics, func(i, j int) bool {
+		return metrics[i].Label < metrics[j].Label
+	})
+
+	return metrics
+}
@@ -0,0 +1,94 @@
+package load
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressStatistics(t *testing.T) {
+	base := time.Date(2024, 7, 10, 13, 0, 0, 0, time.UTC)
+	at := func(sec int) time.Time { return base.Add(time.Duration(sec) * time.Second) }
+
+	summaries := []ResultSummary{
+		{
+			Label: "login",
+			Results: []*ResultRawData{
+				{Timestamp: at(0), Elapsed: 1000},
+				{Timestamp: at(5), Elapsed: 100, IsError: true},
+			},
+		},
+		{
+			Label: "home",
+			Results: []*ResultRawData{
+				{Timestamp: at(0), Elapsed: 900},
+				{Timestamp: at(21), Elapsed: 100},
+				{Timestamp: at(25), Elapsed: 200, IsError: true},
+				{Timestamp: at(30), Elapsed: 300},
+			},
+		},
+	}
+
+	statistics := progressStatistics(summaries, 10*time.Second)
+	require.Len(t, statistics, 2)
+
+	home := statistics[0]
+	require.Equal(t, "home", home.Label)
+	require.Equal(t, 4, home.TotalRequestCount)
+	require.Equal(t, 1, home.TotalErrorCount)
+	require.Equal(t, 3, home.RequestCount)
+	require.InDelta(t, 0.3, home.Rps, 0.0001)
+	require.InDelta(t, 100.0/3, home.ErrorPercent, 0.0001)
+	require.InDelta(t, 200, home.Average, 0.0001)
+	require.Equal(t, float64(300), home.NinetyNine)
+	require.Equal(t, at(30), home.LastSampleAt)
+
+	login := statistics[1]
+	require.Equal(t, 2, login.TotalRequestCount)
+	require.Equal(t, 0, login.RequestCount)
+	require.Zero(t, login.Rps)
+
+	require.Empty(t, progressStatistics(nil, 10*time.Second))
+}
+
+func TestProgressAggregator(t *testing.T) {
+	filePath := t.TempDir() + "/result.csv"
+	row := func(ms int64, elapsed int, label string, success bool) string {
+		return fmt.Sprintf("%d,%d,%s,200,OK,tg 1-1,text,%t,,100,50,1,1,http://ant/%s,10,0,5\n", ms, elapsed, label, success, label)
+	}
+	base := time.Date(2024, 7, 10, 13, 0, 0, 0, time.UTC).UnixMilli()
+
+	header := "timeStamp,elapsed,label,responseCode,responseMessage,threadName,dataType,success,failureMessage,bytes,sentBytes,grpThreads,allThreads,URL,Latency,IdleTime,Connect\n"
+	// the last line is written halfway
+	require.NoError(t, os.WriteFile(filePath, []byte(header+row(base, 100, "home", true)+"1720"), 0644))
+
+	a := newProgressAggregator(jmeterEngine{}, filePath)
+	require.NoError(t, a.update())
+	statistics := a.statistics(10 * time.Second)
+	require.Len(t, statistics, 1)
+	require.Equal(t, 1, statistics[0].TotalRequestCount)
+
+	f, err := os.OpenFile(filePath, os.O_WRONLY|os.O_TRUNC, 0644)
+	require.NoError(t, err)
+	_, err = f.WriteString(header + row(base, 100, "home", true) + row(base+20000, 300, "home", false) + row(base+25000, 200, "login", true))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	require.NoError(t, a.update())
+	statistics = a.statistics(10 * time.Second)
+	require.Len(t, statistics, 2)
+
+	home := statistics[0]
+	require.Equal(t, 2, home.TotalRequestCount)
+	require.Equal(t, 1, home.TotalErrorCount)
+	require.Equal(t, 1, home.RequestCount)
+	require.Equal(t, float64(300), home.Average)
+	require.Equal(t, 1, statistics[1].TotalRequestCount)
+
+	// nothing is appended
+	require.NoError(t, a.update())
+	require.Equal(t, 2, a.statistics(10 * time.Second)[0].TotalRequestCount)
+}
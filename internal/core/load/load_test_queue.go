@@ -0,0 +1,181 @@
+package load
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// queuedLoadTest is a load test submitted to the run queue of its load generator.
+type queuedLoadTest struct {
+	loadTestKey string
+	testName    string
+	generatorId uint
+	queuedAt    time.Time
+	start       func()
+}
+
+type generatorQueue struct {
+	running int
+	waiting []*queuedLoadTest
+}
+
+// loadTestQueue admits the load tests of each load generator up to the limit and keeps the others waiting in order,
+// so the load tests on the same load generator do not compete for its resources.
+type loadTestQueue struct {
+	mu         sync.Mutex
+	generators map[uint]*generatorQueue
+}
+
+func newLoadTestQueue() *loadTestQueue {
+	return &loadTestQueue{generators: make(map[uint]*generatorQueue)}
+}
+
+func (q *loadTestQueue) generator(generatorId uint) *generatorQueue {
+	g, ok := q.generators[generatorId]
+	if !ok {
+		g = &generatorQueue{}
+		q.generators[generatorId] = g
+	}
+	return g
+}
+
+// submit admits the load test when the load generator has room and nobody is waiting, and returns 0.
+// Otherwise the load test waits at the end of the queue and its position is returned with the waiting keys.
+func (q *loadTestQueue) submit(t *queuedLoadTest, limit int) (int, []string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	g := q.generator(t.generatorId)
+	if g.running < limit && len(g.waiting) == 0 {
+		g.running++
+		return 0, nil
+	}
+
+	g.waiting = append(g.waiting, t)
+	return len(g.waiting), g.waitingKeys()
+}
+
+// occupy takes a slot for the load test which is already running on the load generator, such as the one resumed
+// after the restart of the server. The slot is taken even over the limit, since the running load test can not wait.
+func (q *loadTestQueue) occupy(generatorId uint) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.generator(generatorId).running++
+}
+
+// release frees the slot of the finished load test and admits the waiting load tests into the free slots.
+// The admitted load tests are returned with the keys left waiting.
+func (q *loadTestQueue) release(generatorId uint, limit int) ([]*queuedLoadTest, []string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	g := q.generator(generatorId)
+	if g.running > 0 {
+		g.running--
+	}
+
+	var admitted []*queuedLoadTest
+	for g.running < limit && len(g.waiting) > 0 {
+		admitted = append(admitted, g.waiting[0])
+		g.waiting = g.waiting[1:]
+		g.running++
+	}
+
+	if g.running == 0 && len(g.waiting) == 0 {
+		delete(q.generators, generatorId)
+	}
+
+	return admitted, g.waitingKeys()
+}
+
+// cancel removes the waiting load test from the queue. It returns false when the load test is not waiting.
+func (q *loadTestQueue) cancel(loadTestKey string) (uint, []string, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for id, g := range q.generators {
+		if i := g.indexOf(loadTestKey); i >= 0 {
+			g.waiting = append(g.waiting[:i], g.waiting[i+1:]...)
+			return id, g.waitingKeys(), true
+		}
+	}
+
+	return 0, nil, false
+}
+
+// move changes the position of the waiting load test. The position starts from 1 and is limited to the queue length.
+func (q *loadTestQueue) move(loadTestKey string, position int) (uint, []string, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if position < 1 {
+		return 0, nil, fmt.Errorf("queue position must be positive")
+	}
+
+	for id, g := range q.generators {
+		i := g.indexOf(loadTestKey)
+		if i < 0 {
+			continue
+		}
+
+		t := g.waiting[i]
+		g.waiting = append(g.waiting[:i], g.waiting[i+1:]...)
+
+		to := min(position, len(g.waiting)+1) - 1
+		g.waiting = append(g.waiting[:to], append([]*queuedLoadTest{t}, g.waiting[to:]...)...)
+		return id, g.waitingKeys(), nil
+	}
+
+	return 0, nil, fmt.Errorf("load test %s is not queued", loadTestKey)
+}
+
+// snapshot returns the running count and the copy of the waiting load tests of every load generator.
+func (q *loadTestQueue) snapshot() []LoadGeneratorQueueResult {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var results []LoadGeneratorQueueResult
+	for id, g := range q.generators {
+		r := LoadGeneratorQueueResult{
+			LoadGeneratorInstallInfoId: id,
+			RunningCount:               g.running,
+		}
+
+		for i, t := range g.waiting {
+			r.QueuedLoadTests = append(r.QueuedLoadTests, QueuedLoadTestResult{
+				LoadTestKey:   t.loadTestKey,
+				TestName:      t.testName,
+				QueuePosition: i + 1,
+				QueuedAt:      t.queuedAt,
+			})
+		}
+
+		results = append(results, r)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].LoadGeneratorInstallInfoId < results[j].LoadGeneratorInstallInfoId
+	})
+
+	return results
+}
+
+func (g *generatorQueue) indexOf(loadTestKey string) int {
+	for i, t := range g.waiting {
+		if t.loadTestKey == loadTestKey {
+			return i
+		}
+	}
+	return -1
+}
+
+func (g *generatorQueue) waitingKeys() []string {
+	keys := make([]string, 0, len(g.waiting))
+	for _, t := range g.waiting {
+		keys = append(keys, t.loadTestKey)
+	}
+	return keys
+}
@@ -0,0 +1,127 @@
+package load
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloud-barista/cm-ant/internal/config"
+	"github.com/cloud-barista/cm-ant/internal/core/common/constant"
+	"github.com/cloud-barista/cm-ant/internal/utils"
+)
+
+const (
+	defaultMaxConcurrentLoadTests = 1
+)
+
+// loadTestQueues holds the run queues of the load generators which are used on this server.
+var loadTestQueues = newLoadTestQueue()
+
+func maxConcurrentLoadTests() int {
+	limit := config.AppConfig.Load.Queue.MaxConcurrentTests
+	if limit <= 0 {
+		limit = defaultMaxConcurrentLoadTests
+	}
+	return limit
+}
+
+// submitLoadTest starts the load test when its load generator is free, otherwise the load test is queued
+// and started when the load tests before it are finished. The queue position is returned, 0 when started.
+func (l *LoadService) submitLoadTest(t *queuedLoadTest) int {
+	position, waiting := loadTestQueues.submit(t, maxConcurrentLoadTests())
+	if position == 0 {
+		go l.runAdmittedLoadTest(t)
+		return 0
+	}
+
+	utils.LogInfof("Load test %s is queued at position %d on load generator %d", t.loadTestKey, position, t.generatorId)
+	l.saveQueuePositions(waiting)
+	return position
+}
+
+// runAdmittedLoadTest runs the load test and admits the next queued load tests of the load generator after it.
+func (l *LoadService) runAdmittedLoadTest(t *queuedLoadTest) {
+	defer func() {
+		l.touchLoadGenerator(t.generatorId)
+
+		admitted, waiting := loadTestQueues.release(t.generatorId, maxConcurrentLoadTests())
+		for _, a := range admitted {
+			utils.LogInfof("Queued load test %s is admitted on load generator %d", a.loadTestKey, a.generatorId)
+			go l.runAdmittedLoadTest(a)
+		}
+		l.saveQueuePositions(waiting)
+	}()
+
+	t.start()
+}
+
+func (l *LoadService) saveQueuePositions(waiting []string) {
+	if len(waiting) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := l.loadRepo.UpdateLoadTestQueuePositionsTx(ctx, waiting); err != nil {
+		utils.LogErrorf("Error updating queue positions of load tests: %v", err)
+	}
+}
+
+// GetLoadTestQueue returns the running count and the queued load tests of each load generator.
+func (l *LoadService) GetLoadTestQueue() []LoadGeneratorQueueResult {
+	return loadTestQueues.snapshot()
+}
+
+// MoveQueuedLoadTest changes the position of the queued load test in the queue of its load generator.
+func (l *LoadService) MoveQueuedLoadTest(param MoveQueuedLoadTestParam) error {
+	_, waiting, err := loadTestQueues.move(param.LoadTestKey, param.QueuePosition)
+	if err != nil {
+		return err
+	}
+
+	l.saveQueuePositions(waiting)
+	return nil
+}
+
+// CancelQueuedLoadTest removes the queued load test from the queue and marks it stopped.
+func (l *LoadService) CancelQueuedLoadTest(loadTestKey string) error {
+	if !l.cancelQueuedLoadTest(loadTestKey) {
+		return fmt.Errorf("load test %s is not queued", loadTestKey)
+	}
+
+	return nil
+}
+
+// cancelQueuedLoadTest returns false when the load test is not waiting in the queue of this server.
+func (l *LoadService) cancelQueuedLoadTest(loadTestKey string) bool {
+	_, waiting, ok := loadTestQueues.cancel(loadTestKey)
+	if !ok {
+		return false
+	}
+
+	if r, ok := loadTestRunMap.Load(loadTestKey); ok {
+		r.(*loadTestRun).done()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	state, err := l.loadRepo.GetLoadTestExecutionStateTx(ctx, GetLoadTestExecutionStateParam{LoadTestKey: loadTestKey})
+	if err != nil {
+		utils.LogErrorf("Error fetching state of canceled load test %s: %v", loadTestKey, err)
+	} else {
+		finishAt := time.Now()
+		state.ExecutionStatus = constant.Stopped
+		state.QueuePosition = 0
+		state.FinishAt = &finishAt
+
+		if err := l.loadRepo.UpdateLoadTestExecutionStateTx(ctx, &state); err != nil {
+			utils.LogErrorf("Error updating state of canceled load test %s: %v", loadTestKey, err)
+		}
+	}
+
+	utils.LogInfof("Queued load test %s is canceled", loadTestKey)
+	l.saveQueuePositions(waiting)
+	return true
+}
@@ -0,0 +1,81 @@
+package load
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTestQueue(t *testing.T) {
+	q := newLoadTestQueue()
+	newTest := func(key string, generatorId uint) *queuedLoadTest {
+		return &queuedLoadTest{loadTestKey: key, generatorId: generatorId}
+	}
+
+	position, _ := q.submit(newTest("a", 1), 1)
+	require.Equal(t, 0, position)
+
+	// the other load generator has its own slot
+	position, _ = q.submit(newTest("x", 2), 1)
+	require.Equal(t, 0, position)
+
+	position, _ = q.submit(newTest("b", 1), 1)
+	require.Equal(t, 1, position)
+	position, _ = q.submit(newTest("c", 1), 1)
+	require.Equal(t, 2, position)
+	position, waiting := q.submit(newTest("d", 1), 1)
+	require.Equal(t, 3, position)
+	require.Equal(t, []string{"b", "c", "d"}, waiting)
+
+	_, waiting, err := q.move("d", 1)
+	require.NoError(t, err)
+	require.Equal(t, []string{"d", "b", "c"}, waiting)
+
+	_, waiting, err = q.move("d", 10)
+	require.NoError(t, err)
+	require.Equal(t, []string{"b", "c", "d"}, waiting)
+
+	_, _, err = q.move("a", 1)
+	require.Error(t, err)
+
+	generatorId, waiting, ok := q.cancel("c")
+	require.True(t, ok)
+	require.Equal(t, uint(1), generatorId)
+	require.Equal(t, []string{"b", "d"}, waiting)
+
+	_, _, ok = q.cancel("c")
+	require.False(t, ok)
+
+	admitted, waiting := q.release(1, 1)
+	require.Len(t, admitted, 1)
+	require.Equal(t, "b", admitted[0].loadTestKey)
+	require.Equal(t, []string{"d"}, waiting)
+
+	// the larger limit admits the rest at once
+	admitted, waiting = q.release(1, 3)
+	require.Len(t, admitted, 1)
+	require.Equal(t, "d", admitted[0].loadTestKey)
+	require.Empty(t, waiting)
+
+	snapshot := q.snapshot()
+	require.Len(t, snapshot, 2)
+	require.Equal(t, uint(1), snapshot[0].LoadGeneratorInstallInfoId)
+	require.Equal(t, 1, snapshot[0].RunningCount)
+	require.Empty(t, snapshot[0].QueuedLoadTests)
+
+	q.release(1, 1)
+	q.release(2, 1)
+	require.Empty(t, q.snapshot())
+
+	// the recovered load test takes the slot even over the limit, and the new one waits for it
+	q.occupy(1)
+	q.occupy(1)
+	position, _ = q.submit(newTest("e", 1), 1)
+	require.Equal(t, 1, position)
+
+	admitted, _ = q.release(1, 1)
+	require.Empty(t, admitted)
+	admitted, _ = q.release(1, 1)
+	require.Len(t, admitted, 1)
+	require.Equal(t, "e", admitted[0].loadTestKey)
+}
@@ -0,0 +1,305 @@
+package load
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloud-barista/cm-ant/internal/core/common/constant"
+	"github.com/cloud-barista/cm-ant/internal/utils"
+)
+
+const (
+	recoveryCheckInterval = 10 * time.Second
+	// the resumed load test is finished when the running check keeps failing this many times in a row.
+	maxRecoveryCheckFailures = 6
+)
+
+type recoveryAction int
+
+const (
+	// the engine still runs the load test, so the result is fetched until it ends.
+	recoveryResume recoveryAction = iota
+	// the engine is not running anymore, so the result is fetched once and the load test is finished.
+	recoveryFetch
+	// the load test has nothing to recover.
+	recoveryFail
+)
+
+// RecoverLoadTests finishes the load tests which were not finished when the server stopped.
+// The fetch of the load test is resumed when its engine is still running on the load generator,
+// otherwise the result left on the load generator is fetched and the state is finished with the reason.
+func (l *LoadService) RecoverLoadTests(ctx context.Context) {
+	c, cancel := context.WithTimeout(ctx, 10*time.Second)
+	infos, err := l.loadRepo.GetActiveLoadTestExecutionInfosTx(c)
+	cancel()
+
+	if err != nil {
+		utils.LogErrorf("Error fetching unfinished load tests: %v", err)
+		return
+	}
+
+	utils.LogInfof("Found %d unfinished load tests to recover", len(infos))
+
+	for i := range infos {
+		info := infos[i]
+
+		// the load test started after this server is up is processed already
+		if _, ok := loadTestRunMap.Load(info.LoadTestKey); ok {
+			continue
+		}
+
+		l.recoverLoadTest(ctx, &info)
+	}
+
+	l.failInterruptedCapacitySearches()
+	l.failInterruptedMultiRegionLoadTests()
+}
+
+func (l *LoadService) recoverLoadTest(ctx context.Context, info *LoadTestExecutionInfo) {
+	state := &info.LoadTestExecutionState
+	installInfo := &info.LoadGeneratorInstallInfo
+
+	engine, err := getLoadGeneratorEngine(info.Engine)
+	if err != nil {
+		l.finishRecoveredLoadTest(info, constant.TestFailed, err.Error())
+		return
+	}
+
+	if state.ExecutionStatus == constant.Queued {
+		l.finishRecoveredLoadTest(info, constant.TestFailed, "the queued load test is dropped by the restart of the server")
+		return
+	}
+
+	if installInfo.ID == 0 {
+		l.finishRecoveredLoadTest(info, constant.TestFailed, "the load generator of the load test is removed while the server was down")
+		return
+	}
+
+	c, cancel := context.WithTimeout(ctx, time.Minute)
+	running, err := l.engineRunning(c, engine, info.LoadTestKey, installInfo)
+	cancel()
+
+	if err != nil {
+		utils.LogWarnf("Error checking the engine of load test %s: %v", info.LoadTestKey, err)
+		l.finishRecoveredLoadTest(info, constant.TestFailed, fmt.Sprintf("failed to check the engine after the server restarted; %s", err))
+		return
+	}
+
+	expectedEnd := state.StartAt.Add(time.Duration(state.TotalExpectedExcutionSecond) * time.Second)
+	action, reason := recoveryActionOf(state.ExecutionStatus, running, expectedEnd, time.Now())
+
+	utils.LogInfof("Recovering load test %s in %s; engine running: %t", info.LoadTestKey, state.ExecutionStatus, running)
+
+	switch action {
+	case recoveryResume:
+		l.runRecoveredLoadTest(info, func() { l.resumeLoadTest(info, engine) })
+	case recoveryFetch:
+		l.runRecoveredLoadTest(info, func() { l.fetchRecoveredLoadTest(info, engine, reason) })
+	default:
+		l.finishRecoveredLoadTest(info, constant.TestFailed, reason)
+	}
+}
+
+// runRecoveredLoadTest runs the recovery of the load test in a slot of its load generator,
+// so the load tests submitted after the restart wait until the recovered one is finished.
+func (l *LoadService) runRecoveredLoadTest(info *LoadTestExecutionInfo, recovery func()) {
+	loadTestQueues.occupy(info.LoadGeneratorInstallInfoId)
+
+	go l.runAdmittedLoadTest(&queuedLoadTest{
+		loadTestKey: info.LoadTestKey,
+		testName:    info.TestName,
+		generatorId: info.LoadGeneratorInstallInfoId,
+		queuedAt:    time.Now(),
+		start:       recovery,
+	})
+}
+
+// recoveryActionOf decides how the unfinished load test is recovered.
+// The load test which is not running anymore is regarded as finished when its expected end has passed,
+// otherwise the partial result is kept and the load test is failed with the reason.
+func recoveryActionOf(status constant.ExecutionStatus, running bool, expectedEnd, now time.Time) (recoveryAction, string) {
+	if running {
+		return recoveryResume, ""
+	}
+
+	switch status {
+	case constant.OnPreparing:
+		return recoveryFail, "the server restarted before the load test engine started"
+	case constant.OnFetching:
+		return recoveryFetch, ""
+	}
+
+	if now.Before(expectedEnd) {
+		return recoveryFetch, "the load test engine stopped before the expected end while the server restarted"
+	}
+
+	return recoveryFetch, ""
+}
+
+// engineRunning checks whether the engine still runs the load test on the load generator.
+func (l *LoadService) engineRunning(ctx context.Context, engine LoadGeneratorEngine, loadTestKey string, installInfo *LoadGeneratorInstallInfo) (bool, error) {
+	checkCmd := engine.RunningCheckCmd(loadTestKey)
+	if checkCmd == "" {
+		return false, nil
+	}
+
+	// the engine of the cluster only runs on the master
+	out, err := l.loadGeneratorCommandOutput(ctx, installInfo, checkCmd, true)
+	if err != nil {
+		return false, err
+	}
+
+	return strings.Contains(out, engineRunningMarker), nil
+}
+
+// loadGeneratorCommandOutput runs the command on the load generator and returns the output.
+// The remote command runs on the master of the cluster when masterOnly is set, otherwise on every vm of the load generator.
+func (l *LoadService) loadGeneratorCommandOutput(ctx context.Context, installInfo *LoadGeneratorInstallInfo, cmd string, masterOnly bool) (string, error) {
+	if installInfo.InstallLocation == constant.Local {
+		return utils.InlineCmdOutput(cmd)
+	}
+
+	var master LoadGeneratorServer
+	for _, s := range installInfo.LoadGeneratorServers {
+		if s.IsMaster {
+			master = s
+		}
+	}
+
+	vmId := ""
+	if masterOnly && installInfo.IsCluster && master.VmId != "" {
+		vmId = master.VmId
+	}
+
+	return l.commandToLoadGenerator(ctx, installInfo, vmId, []string{cmd})
+}
+
+// resumeLoadTest fetches the result of the running load test until the engine ends and finishes the load test.
+// The resumed load test can be stopped like the load test started by this server.
+func (l *LoadService) resumeLoadTest(info *LoadTestExecutionInfo, engine LoadGeneratorEngine) {
+	run := newLoadTestRun(info.LoadTestKey)
+	run.start()
+	defer run.done()
+
+	loadTestDone := make(chan bool)
+
+	dataParam := newFetchDataParam(info.LoadTestKey, info.AgentInstalled, &info.LoadGeneratorInstallInfo, engine, loadTestDone)
+
+	if err := l.loadRepo.UpdateLoadTestExecutionStatusTx(context.Background(), info.LoadTestKey, constant.OnRunning); err != nil {
+		utils.LogErrorf("Error updating status of load test %s: %v", info.LoadTestKey, err)
+	}
+
+	go l.fetchData(dataParam)
+
+	ticker := time.NewTicker(recoveryCheckInterval)
+	defer ticker.Stop()
+
+	failures := 0
+	for range ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		running, err := l.engineRunning(ctx, engine, info.LoadTestKey, &info.LoadGeneratorInstallInfo)
+		cancel()
+
+		if err != nil {
+			failures++
+			utils.LogWarnf("Error checking the engine of load test %s (%d/%d): %v", info.LoadTestKey, failures, maxRecoveryCheckFailures, err)
+			if failures < maxRecoveryCheckFailures {
+				continue
+			}
+		} else if running {
+			failures = 0
+			continue
+		}
+
+		break
+	}
+
+	loadTestDone <- true
+	close(loadTestDone)
+	<-dataParam.FetchDone
+
+	status, reason := constant.Successed, ""
+	if run.isStopped() {
+		status = constant.Stopped
+	} else if failures >= maxRecoveryCheckFailures {
+		status, reason = constant.TestFailed, "lost the load test engine after the server restarted"
+	}
+
+	utils.LogInfof("Resumed load test %s is finished with status %s", info.LoadTestKey, status)
+	l.finishRecoveredLoadTest(info, status, reason)
+}
+
+// fetchRecoveredLoadTest fetches the result left on the load generator once and finishes the load test.
+// The load test is failed with the reason when it is given, but the fetched partial result is kept.
+func (l *LoadService) fetchRecoveredLoadTest(info *LoadTestExecutionInfo, engine LoadGeneratorEngine, reason string) {
+	dataParam := newFetchDataParam(info.LoadTestKey, info.AgentInstalled, &info.LoadGeneratorInstallInfo, engine, nil)
+
+	if err := l.fetchFiles(dataParam, true); err != nil {
+		utils.LogWarnf("Error fetching result of recovered load test %s: %v", info.LoadTestKey, err)
+	}
+
+	if err := l.ingestLoadTestResult(engine, info.LoadTestKey); err != nil {
+		utils.LogErrorf("Error ingesting result of recovered load test %s: %v", info.LoadTestKey, err)
+		if reason == "" {
+			l.finishRecoveredLoadTest(info, constant.ResultFailed, fmt.Sprintf("failed to fetch the result after the server restarted; %s", err))
+			return
+		}
+	}
+
+	if reason != "" {
+		l.finishRecoveredLoadTest(info, constant.TestFailed, reason)
+		return
+	}
+
+	l.finishRecoveredLoadTest(info, constant.Successed, "")
+}
+
+// finishRecoveredLoadTest finishes the recovered load test with the status.
+// The slo rules of the load test are evaluated against the fetched result when it is succeeded.
+func (l *LoadService) finishRecoveredLoadTest(info *LoadTestExecutionInfo, status constant.ExecutionStatus, reason string) {
+	state := &info.LoadTestExecutionState
+	if reason != "" {
+		utils.LogWarnf("Load test %s is finished with status %s; %s", state.LoadTestKey, status, reason)
+	}
+
+	finishAt := time.Now()
+	state.ExecutionStatus = status
+	state.FailureMessage = reason
+	state.FinishAt = &finishAt
+
+	if rules := sloRulesOf(*info); len(rules) > 0 {
+		if status == constant.Successed {
+			l.evaluateSlo(RunLoadTestParam{LoadTestKey: info.LoadTestKey, SloRules: rules}, state)
+		} else {
+			state.Verdict = constant.VerdictError
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := l.loadRepo.UpdateLoadTestExecutionStateTx(ctx, state); err != nil {
+		utils.LogErrorf("Error updating recovered load test %s: %v", state.LoadTestKey, err)
+	}
+
+	if state.LoadGeneratorInstallInfoId != 0 {
+		l.touchLoadGenerator(state.LoadGeneratorInstallInfoId)
+	}
+}
+
+// sloRulesOf returns the slo rules stored with the load test.
+func sloRulesOf(info LoadTestExecutionInfo) []SloRuleParam {
+	if info.SloRules == "" {
+		return nil
+	}
+
+	var rules []SloRuleParam
+	if err := json.Unmarshal([]byte(info.SloRules), &rules); err != nil {
+		utils.LogErrorf("Error unmarshaling slo rules of load test %s: %v", info.LoadTestKey, err)
+		return nil
+	}
+	return rules
+}
@@ -0,0 +1,50 @@
+package load
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloud-barista/cm-ant/internal/core/common/constant"
+	"github.com/cloud-barista/cm-ant/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoveryActionOf(t *testing.T) {
+	now := time.Now()
+	past, future := now.Add(-time.Minute), now.Add(time.Minute)
+
+	cases := []struct {
+		status      constant.ExecutionStatus
+		running     bool
+		expectedEnd time.Time
+		action      recoveryAction
+		failed      bool
+	}{
+		{constant.OnRunning, true, future, recoveryResume, false},
+		{constant.OnPreparing, true, future, recoveryResume, false},
+		{constant.OnPreparing, false, past, recoveryFail, true},
+		{constant.OnRunning, false, past, recoveryFetch, false},
+		{constant.OnRunning, false, future, recoveryFetch, true},
+		{constant.OnFetching, false, future, recoveryFetch, false},
+	}
+
+	for _, c := range cases {
+		action, reason := recoveryActionOf(c.status, c.running, c.expectedEnd, now)
+		require.Equal(t, c.action, action, c)
+		require.Equal(t, c.failed, reason != "", c)
+	}
+}
+
+func TestEngineRunningCheckCmd(t *testing.T) {
+	cmd := engineRunningCheckCmd("'no-such-process-of-ant-test'")
+	require.NotContains(t, cmd, engineRunningMarker)
+
+	out, err := utils.InlineCmdOutput(cmd)
+	require.NoError(t, err)
+	require.NotContains(t, out, engineRunningMarker)
+
+	// the check command itself must not be found as the engine
+	out, err = utils.InlineCmdOutput(jmeterEngine{}.RunningCheckCmd("1700000000000"))
+	require.NoError(t, err)
+	require.NotContains(t, out, engineRunningMarker)
+}
@@ -0,0 +1,551 @@
+package load
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloud-barista/cm-ant/internal/config"
+	"github.com/cloud-barista/cm-ant/internal/core/common/constant"
+	"github.com/cloud-barista/cm-ant/internal/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultScheduleCheckInterval = 30 * time.Second
+
+	// redactedSecret replaces the credentials in the stored parameter and the responses.
+	// The credential given as it is in the update is kept as stored.
+	redactedSecret = "******"
+)
+
+// ErrInvalidLoadTestSchedule is wrapped by the errors of the schedule which are caused by the request.
+var ErrInvalidLoadTestSchedule = errors.New("load test schedule is not valid")
+
+// scheduleSecretsAdditionalData binds the encrypted credentials to the schedules.
+var scheduleSecretsAdditionalData = []byte("load-test-schedule")
+
+// activeExecutionStatuses are the statuses of load test which is not finished yet.
+var activeExecutionStatuses = []constant.ExecutionStatus{
+	constant.Queued,
+	constant.OnPreparing,
+	constant.OnRunning,
+	constant.OnFetching,
+}
+
+// scheduleGeneratorLockMap guards the overlap check and the run of schedules which share a load generator.
+var scheduleGeneratorLockMap sync.Map
+
+// scheduleRunningMap marks the schedules whose run is in progress on this server.
+var scheduleRunningMap sync.Map
+
+// CreateLoadTestSchedule validates the cron expression and stores a new load test schedule.
+func (l *LoadService) CreateLoadTestSchedule(param CreateLoadTestScheduleParam) (LoadTestScheduleResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var res LoadTestScheduleResult
+
+	nextRunAt, err := nextScheduleTime(param.CronExpression, time.Now())
+	if err != nil {
+		return res, fmt.Errorf("%w; %s", ErrInvalidLoadTestSchedule, err)
+	}
+
+	if err := validateRunLoadTestParam(param.RunLoadTestParam); err != nil {
+		return res, fmt.Errorf("%w; %s", ErrInvalidLoadTestSchedule, err)
+	}
+
+	schedule := LoadTestSchedule{
+		Name:                       param.Name,
+		CronExpression:             param.CronExpression,
+		Enabled:                    param.Enabled,
+		LoadGeneratorInstallInfoId: param.RunLoadTestParam.LoadGeneratorInstallInfoId,
+		NextRunAt:                  &nextRunAt,
+	}
+
+	if err := setScheduleRunLoadTestParam(&schedule, param.RunLoadTestParam, nil); err != nil {
+		return res, err
+	}
+
+	err = l.loadRepo.InsertLoadTestScheduleTx(ctx, &schedule)
+	if err != nil {
+		utils.LogErrorf("Error inserting load test schedule: %v", err)
+		return res, err
+	}
+
+	utils.LogInfof("Load test schedule %d created. next run at: %s", schedule.ID, nextRunAt)
+	return mapLoadTestScheduleResult(schedule), nil
+}
+
+// UpdateLoadTestSchedule replaces the cron expression and load test parameter of the schedule.
+// The next run time is recalculated from now.
+func (l *LoadService) UpdateLoadTestSchedule(param UpdateLoadTestScheduleParam) (LoadTestScheduleResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var res LoadTestScheduleResult
+
+	schedule, err := l.loadRepo.GetLoadTestScheduleTx(ctx, param.ID)
+	if err != nil {
+		utils.LogErrorf("Error fetching load test schedule: %v", err)
+		return res, err
+	}
+
+	nextRunAt, err := nextScheduleTime(param.CronExpression, time.Now())
+	if err != nil {
+		return res, fmt.Errorf("%w; %s", ErrInvalidLoadTestSchedule, err)
+	}
+
+	if err := validateRunLoadTestParam(param.RunLoadTestParam); err != nil {
+		return res, fmt.Errorf("%w; %s", ErrInvalidLoadTestSchedule, err)
+	}
+
+	stored, err := scheduleSecrets(schedule)
+	if err != nil {
+		return res, err
+	}
+
+	schedule.Name = param.Name
+	schedule.CronExpression = param.CronExpression
+	schedule.Enabled = param.Enabled
+	schedule.LoadGeneratorInstallInfoId = param.RunLoadTestParam.LoadGeneratorInstallInfoId
+	schedule.NextRunAt = &nextRunAt
+
+	if err := setScheduleRunLoadTestParam(&schedule, param.RunLoadTestParam, stored); err != nil {
+		return res, err
+	}
+
+	err = l.loadRepo.UpdateLoadTestScheduleTx(ctx, &schedule)
+	if err != nil {
+		utils.LogErrorf("Error updating load test schedule: %v", err)
+		return res, err
+	}
+
+	return mapLoadTestScheduleResult(schedule), nil
+}
+
+func (l *LoadService) DeleteLoadTestSchedule(id uint) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := l.loadRepo.GetLoadTestScheduleTx(ctx, id)
+	if err != nil {
+		utils.LogErrorf("Error fetching load test schedule: %v", err)
+		return err
+	}
+
+	return l.loadRepo.DeleteLoadTestScheduleTx(ctx, id)
+}
+
+func (l *LoadService) GetLoadTestSchedule(id uint) (LoadTestScheduleResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	schedule, err := l.loadRepo.GetLoadTestScheduleTx(ctx, id)
+	if err != nil {
+		utils.LogErrorf("Error fetching load test schedule: %v", err)
+		return LoadTestScheduleResult{}, err
+	}
+
+	return mapLoadTestScheduleResult(schedule), nil
+}
+
+func (l *LoadService) GetAllLoadTestSchedules(param GetAllLoadTestSchedulesParam) (GetAllLoadTestSchedulesResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var res GetAllLoadTestSchedulesResult
+
+	schedules, totalRows, err := l.loadRepo.GetPagingLoadTestSchedulesTx(ctx, param)
+	if err != nil {
+		utils.LogErrorf("Error fetching load test schedules: %v", err)
+		return res, err
+	}
+
+	for _, s := range schedules {
+		res.LoadTestSchedules = append(res.LoadTestSchedules, mapLoadTestScheduleResult(s))
+	}
+	res.TotalRow = totalRows
+
+	return res, nil
+}
+
+// RunLoadTestScheduler checks the due schedules periodically and runs them until the context is done.
+func (l *LoadService) RunLoadTestScheduler(ctx context.Context) {
+	interval := config.AppConfig.Load.Schedule.CheckInterval
+	if interval <= 0 {
+		interval = defaultScheduleCheckInterval
+	}
+
+	utils.LogInfof("Load test scheduler started with check interval %s", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			utils.LogInfo("Load test scheduler stopped")
+			return
+		case now := <-ticker.C:
+			l.runDueLoadTestSchedules(ctx, now)
+		}
+	}
+}
+
+func (l *LoadService) runDueLoadTestSchedules(ctx context.Context, now time.Time) {
+	c, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	schedules, err := l.loadRepo.GetDueLoadTestSchedulesTx(c, now)
+	if err != nil {
+		utils.LogErrorf("Error fetching due load test schedules: %v", err)
+		return
+	}
+
+	for i := range schedules {
+		schedule := schedules[i]
+
+		if _, running := scheduleRunningMap.LoadOrStore(schedule.ID, true); running {
+			utils.LogWarnf("Load test schedule %d is still running. skip this turn", schedule.ID)
+			continue
+		}
+
+		go func() {
+			defer scheduleRunningMap.Delete(schedule.ID)
+			l.runLoadTestSchedule(&schedule, now)
+		}()
+	}
+}
+
+// runLoadTestSchedule runs the stored load test of the schedule unless another load test
+// is already using the same load generator, and moves the schedule to its next run time.
+func (l *LoadService) runLoadTestSchedule(schedule *LoadTestSchedule, now time.Time) {
+	cronExpression := schedule.CronExpression
+	nextRunAt, err := nextScheduleTime(schedule.CronExpression, now)
+	if err != nil {
+		schedule.Enabled = false
+		schedule.NextRunAt = nil
+		schedule.LastRunMessage = err.Error()
+	} else {
+		schedule.NextRunAt = &nextRunAt
+		schedule.LastRunAt = &now
+
+		loadTestKey, err := l.runScheduledLoadTest(schedule)
+		if err != nil {
+			utils.LogErrorf("Error running load test schedule %d: %v", schedule.ID, err)
+			schedule.LastRunMessage = err.Error()
+		} else {
+			schedule.LastLoadTestKey = loadTestKey
+			schedule.LastRunMessage = fmt.Sprintf("load test %s started", loadTestKey)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	err = l.loadRepo.UpdateLoadTestScheduleRunTx(ctx, schedule, cronExpression)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.LogWarnf("Load test schedule %d is deleted while it ran", schedule.ID)
+	} else if err != nil {
+		utils.LogErrorf("Error updating load test schedule %d: %v", schedule.ID, err)
+	}
+}
+
+func (l *LoadService) runScheduledLoadTest(schedule *LoadTestSchedule) (string, error) {
+	var param RunLoadTestParam
+	err := json.Unmarshal([]byte(schedule.RunLoadTestParam), &param)
+	if err != nil {
+		return "", fmt.Errorf("stored load test param is broken; %w", err)
+	}
+
+	secrets, err := scheduleSecrets(*schedule)
+	if err != nil {
+		return "", err
+	}
+	param = withHttpSecrets(param, secrets)
+
+	if schedule.LoadGeneratorInstallInfoId != 0 {
+		rl, _ := scheduleGeneratorLockMap.LoadOrStore(schedule.LoadGeneratorInstallInfoId, &sync.Mutex{})
+		lock := rl.(*sync.Mutex)
+
+		lock.Lock()
+		defer lock.Unlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		activeCount, err := l.loadRepo.CountActiveLoadTestExecutionStateTx(ctx, schedule.LoadGeneratorInstallInfoId)
+		if err != nil {
+			return "", err
+		}
+
+		if activeCount > 0 {
+			return "", fmt.Errorf("skipped; load generator %d is already running another load test", schedule.LoadGeneratorInstallInfoId)
+		}
+	}
+
+	utils.LogInfof("Running load test schedule %d (%s)", schedule.ID, schedule.Name)
+	return l.RunLoadTest(param)
+}
+
+// nextScheduleTime returns the next activation time of the cron expression after the given time.
+func nextScheduleTime(cronExpression string, from time.Time) (time.Time, error) {
+	if strings.TrimSpace(cronExpression) == "" {
+		return time.Time{}, errors.New("cron expression must be set")
+	}
+
+	c, err := utils.ParseCron(cronExpression)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	next := c.Next(from)
+	if next.IsZero() {
+		return next, fmt.Errorf("cron expression %s never runs", cronExpression)
+	}
+
+	return next, nil
+}
+
+func mapLoadTestScheduleResult(s LoadTestSchedule) LoadTestScheduleResult {
+	var param RunLoadTestParam
+	if err := json.Unmarshal([]byte(s.RunLoadTestParam), &param); err != nil {
+		utils.LogErrorf("Error unmarshaling load test param of schedule %d: %v", s.ID, err)
+	}
+
+	return LoadTestScheduleResult{
+		ID:                         s.ID,
+		Name:                       s.Name,
+		CronExpression:             s.CronExpression,
+		Enabled:                    s.Enabled,
+		LoadGeneratorInstallInfoId: s.LoadGeneratorInstallInfoId,
+		RunLoadTestParam:           param,
+		NextRunAt:                  s.NextRunAt,
+		LastRunAt:                  s.LastRunAt,
+		LastLoadTestKey:            s.LastLoadTestKey,
+		LastRunMessage:             s.LastRunMessage,
+		CreatedAt:                  s.CreatedAt,
+		UpdatedAt:                  s.UpdatedAt,
+	}
+}
+
+// httpRequestSecret is the credentials of a http request of the schedule, which are stored encrypted.
+// The values of the headers and the cookies are kept by their names.
+type httpRequestSecret struct {
+	Password string            `json:"password,omitempty"`
+	Token    string            `json:"token,omitempty"`
+	Headers  map[string]string `json:"headers,omitempty"`
+	Cookies  map[string]string `json:"cookies,omitempty"`
+}
+
+// httpRequestKeys returns the keys of the http requests which their credentials are kept by.
+// The key is the method, the url and the username of the request, so the credential follows its request
+// when the requests are reordered. The same requests are told apart by their order.
+func httpRequestKeys(reqs []RunLoadTestHttpParam) []string {
+	keys := make([]string, len(reqs))
+	seen := make(map[string]int)
+	for i, h := range reqs {
+		var username string
+		if h.Auth != nil {
+			username = h.Auth.Username
+		}
+
+		key := fmt.Sprintf("%s %s://%s:%s%s %s", strings.ToUpper(h.Method), strings.ToLower(h.Protocol), strings.ToLower(h.Hostname), h.Port, h.Path, username)
+		seen[key]++
+		keys[i] = fmt.Sprintf("%s #%d", key, seen[key])
+	}
+	return keys
+}
+
+// setScheduleRunLoadTestParam stores the load test parameter of the schedule with the credentials redacted,
+// and the credentials encrypted by the keystore. The redacted credential of the parameter is taken from the stored one
+// of the same http request.
+func setScheduleRunLoadTestParam(schedule *LoadTestSchedule, param RunLoadTestParam, stored map[string]httpRequestSecret) error {
+	redacted, secrets := withoutHttpSecrets(param)
+
+	for key, secret := range secrets {
+		prev := stored[key]
+		restore := func(value, storedValue, name string) (string, error) {
+			if value != redactedSecret {
+				return value, nil
+			}
+			if storedValue == "" {
+				return "", fmt.Errorf("%w; %s of http request %s must be given again", ErrInvalidLoadTestSchedule, name, key)
+			}
+			return storedValue, nil
+		}
+
+		var err error
+		if secret.Password, err = restore(secret.Password, prev.Password, "password"); err != nil {
+			return err
+		}
+		if secret.Token, err = restore(secret.Token, prev.Token, "token"); err != nil {
+			return err
+		}
+		for name, v := range secret.Headers {
+			if secret.Headers[name], err = restore(v, prev.Headers[name], "header "+name); err != nil {
+				return err
+			}
+		}
+		for name, v := range secret.Cookies {
+			if secret.Cookies[name], err = restore(v, prev.Cookies[name], "cookie "+name); err != nil {
+				return err
+			}
+		}
+		secrets[key] = secret
+	}
+
+	p, err := json.Marshal(redacted)
+	if err != nil {
+		return err
+	}
+
+	schedule.RunLoadTestParam = string(p)
+	schedule.EncryptedSecrets = ""
+
+	if len(secrets) == 0 {
+		return nil
+	}
+
+	aead, err := keystoreCipher()
+	if errors.Is(err, errKeystoreDisabled) {
+		return fmt.Errorf("%w; credentials of the http requests are kept only in the keystore; %s", ErrInvalidLoadTestSchedule, err)
+	}
+	if err != nil {
+		return err
+	}
+
+	s, err := json.Marshal(secrets)
+	if err != nil {
+		return err
+	}
+
+	schedule.EncryptedSecrets, err = sealSecret(aead, scheduleSecretsAdditionalData, s)
+	return err
+}
+
+// scheduleSecrets returns the decrypted credentials of the schedule by the key of the http request.
+func scheduleSecrets(schedule LoadTestSchedule) (map[string]httpRequestSecret, error) {
+	if schedule.EncryptedSecrets == "" {
+		return nil, nil
+	}
+
+	aead, err := keystoreCipher()
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := openSecret(aead, scheduleSecretsAdditionalData, schedule.EncryptedSecrets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt the credentials of load test schedule %d. the master key may be changed; %w", schedule.ID, err)
+	}
+
+	var secrets map[string]httpRequestSecret
+	if err := json.Unmarshal(s, &secrets); err != nil {
+		return nil, err
+	}
+
+	return secrets, nil
+}
+
+// withoutHttpSecrets returns the copy of the parameter whose credentials are redacted, the password and the token of the auth,
+// the values of the sensitive headers and the cookies, and the credentials by the key of the http request.
+// Every parameter stored by the load service is redacted by it.
+func withoutHttpSecrets(param RunLoadTestParam) (RunLoadTestParam, map[string]httpRequestSecret) {
+	secrets := make(map[string]httpRequestSecret)
+	reqs := make([]RunLoadTestHttpParam, len(param.HttpReqs))
+	copy(reqs, param.HttpReqs)
+
+	for i, key := range httpRequestKeys(reqs) {
+		h := reqs[i]
+		var secret httpRequestSecret
+		found := false
+
+		if h.Auth != nil && (h.Auth.Password != "" || h.Auth.Token != "") {
+			auth := *h.Auth
+			secret.Password, secret.Token = auth.Password, auth.Token
+			if auth.Password != "" {
+				auth.Password = redactedSecret
+			}
+			if auth.Token != "" {
+				auth.Token = redactedSecret
+			}
+			reqs[i].Auth = &auth
+			found = true
+		}
+
+		for _, header := range h.Headers {
+			if header.Value != "" && isSensitiveHeader(header.Name) {
+				if secret.Headers == nil {
+					secret.Headers = make(map[string]string)
+				}
+				secret.Headers[header.Name] = header.Value
+				found = true
+			}
+		}
+		reqs[i].Headers = redactedHeaders(h.Headers)
+
+		for _, c := range h.Cookies {
+			if c.Value != "" {
+				if secret.Cookies == nil {
+					secret.Cookies = make(map[string]string)
+				}
+				secret.Cookies[c.Name] = c.Value
+				found = true
+			}
+		}
+		reqs[i].Cookies = redactedCookies(h.Cookies)
+
+		if found {
+			secrets[key] = secret
+		}
+	}
+
+	param.HttpReqs = reqs
+	return param, secrets
+}
+
+// withHttpSecrets returns the copy of the parameter whose redacted credentials are put back.
+func withHttpSecrets(param RunLoadTestParam, secrets map[string]httpRequestSecret) RunLoadTestParam {
+	reqs := make([]RunLoadTestHttpParam, len(param.HttpReqs))
+	copy(reqs, param.HttpReqs)
+
+	for i, key := range httpRequestKeys(reqs) {
+		secret, ok := secrets[key]
+		if !ok {
+			continue
+		}
+
+		if reqs[i].Auth != nil {
+			auth := *reqs[i].Auth
+			auth.Password = secret.Password
+			auth.Token = secret.Token
+			reqs[i].Auth = &auth
+		}
+
+		headers := make([]RunLoadTestHttpHeaderParam, len(reqs[i].Headers))
+		for j, header := range reqs[i].Headers {
+			headers[j] = header
+			if v, ok := secret.Headers[header.Name]; ok && header.Value == redactedSecret {
+				headers[j].Value = v
+			}
+		}
+		reqs[i].Headers = headers
+
+		cookies := make([]RunLoadTestHttpCookieParam, len(reqs[i].Cookies))
+		for j, c := range reqs[i].Cookies {
+			cookies[j] = c
+			if v, ok := secret.Cookies[c.Name]; ok && c.Value == redactedSecret {
+				cookies[j].Value = v
+			}
+		}
+		reqs[i].Cookies = cookies
+	}
+
+	param.HttpReqs = reqs
+	return param
+}
@@ -0,0 +1,76 @@
+package load
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cloud-barista/cm-ant/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleSecrets(t *testing.T) {
+	masterKey := config.AppConfig.Load.Keystore.MasterKey
+	t.Cleanup(func() { config.AppConfig.Load.Keystore.MasterKey = masterKey })
+
+	param := RunLoadTestParam{HttpReqs: []RunLoadTestHttpParam{
+		{Method: "GET"},
+		{
+			Method: "POST", Hostname: "ant", Path: "/login",
+			Auth:    &RunLoadTestHttpAuthParam{Type: "basic", Username: "ant", Password: "secret"},
+			Headers: []RunLoadTestHttpHeaderParam{{Name: "X-Api-Key", Value: "secret-key"}, {Name: "Accept", Value: "*/*"}},
+			Cookies: []RunLoadTestHttpCookieParam{{Name: "session", Value: "secret-session"}},
+		},
+	}}
+
+	// the credentials are not stored without the keystore
+	config.AppConfig.Load.Keystore.MasterKey = ""
+	var schedule LoadTestSchedule
+	require.ErrorIs(t, setScheduleRunLoadTestParam(&schedule, param, nil), ErrInvalidLoadTestSchedule)
+	require.NoError(t, setScheduleRunLoadTestParam(&schedule, RunLoadTestParam{HttpReqs: param.HttpReqs[:1]}, nil))
+
+	config.AppConfig.Load.Keystore.MasterKey = "master"
+	require.NoError(t, setScheduleRunLoadTestParam(&schedule, param, nil))
+	require.NotContains(t, schedule.RunLoadTestParam, "secret")
+	require.NotContains(t, schedule.EncryptedSecrets, "secret")
+	require.Equal(t, "secret", param.HttpReqs[1].Auth.Password)
+
+	var stored RunLoadTestParam
+	require.NoError(t, json.Unmarshal([]byte(schedule.RunLoadTestParam), &stored))
+	require.Equal(t, redactedSecret, stored.HttpReqs[1].Auth.Password)
+	require.Equal(t, redactedSecret, stored.HttpReqs[1].Headers[0].Value)
+	require.Equal(t, "*/*", stored.HttpReqs[1].Headers[1].Value)
+	require.Equal(t, redactedSecret, stored.HttpReqs[1].Cookies[0].Value)
+
+	secrets, err := scheduleSecrets(schedule)
+	require.NoError(t, err)
+	restored := withHttpSecrets(stored, secrets)
+	require.Equal(t, "secret", restored.HttpReqs[1].Auth.Password)
+	require.Equal(t, "secret-key", restored.HttpReqs[1].Headers[0].Value)
+	require.Equal(t, "secret-session", restored.HttpReqs[1].Cookies[0].Value)
+
+	// the redacted credential of the update keeps the stored one
+	require.NoError(t, setScheduleRunLoadTestParam(&schedule, stored, secrets))
+	secrets, err = scheduleSecrets(schedule)
+	require.NoError(t, err)
+	require.Len(t, secrets, 1)
+
+	// the credential follows its http request when the requests are reordered
+	stored.HttpReqs[0], stored.HttpReqs[1] = stored.HttpReqs[1], stored.HttpReqs[0]
+	require.NoError(t, setScheduleRunLoadTestParam(&schedule, stored, secrets))
+	secrets, err = scheduleSecrets(schedule)
+	require.NoError(t, err)
+	restored = withHttpSecrets(stored, secrets)
+	require.Equal(t, "secret", restored.HttpReqs[0].Auth.Password)
+	require.Equal(t, "secret-session", restored.HttpReqs[0].Cookies[0].Value)
+	require.Empty(t, restored.HttpReqs[1].Cookies)
+
+	// the redacted credential of the changed http request must be given again
+	changed := stored
+	changed.HttpReqs = append([]RunLoadTestHttpParam{}, stored.HttpReqs...)
+	changed.HttpReqs[0].Hostname = "other"
+	require.ErrorIs(t, setScheduleRunLoadTestParam(&schedule, changed, secrets), ErrInvalidLoadTestSchedule)
+
+	// the redacted credential which is not stored must be given again
+	stored.HttpReqs = append(stored.HttpReqs, RunLoadTestHttpParam{Method: "GET", Auth: &RunLoadTestHttpAuthParam{Type: "bearer", Token: redactedSecret}})
+	require.ErrorIs(t, setScheduleRunLoadTestParam(&schedule, stored, secrets), ErrInvalidLoadTestSchedule)
+}
@@ -41,6 +41,7 @@ type LoadGeneratorServer struct {
 	StartTime       string
 	AdditionalVmKey string
 	Label           string
+	HostKey         string `gorm:"type:text"` // host key pinned at the registration or the install in authorized_keys format
 
 	IsCluster   bool
 	IsMaster    bool
@@ -53,11 +54,25 @@ type LoadGeneratorServer struct {
 type LoadGeneratorInstallInfo struct {
 	gorm.Model
 	InstallLocation constant.InstallLocation
-	InstallType     string
+	InstallType     string // engines installed on the load generator such as jmeter,k6
 	InstallPath     string
 	InstallVersion  string
 	Status          string
 
+	// RegionName is the name of the regional load generator such as seoul, which runs on its own mci.
+	// The default load generator has no region name.
+	RegionName string
+	MciId      string
+
+	// LifecycleStatus is whether the vms of the remote load generator are running or suspended.
+	// LastUsedAt is when the last load test on the load generator is finished, which the idle reaper looks at.
+	LifecycleStatus string
+	LastUsedAt      *time.Time
+
+	// HealthMessage is the problems found by the last health check, which is empty when the load generator is healthy.
+	HealthMessage   string
+	HealthCheckedAt *time.Time
+
 	IsCluster   bool
 	MasterId    uint
 	ClusterSize uint64
@@ -67,6 +82,27 @@ type LoadGeneratorInstallInfo struct {
 	LoadGeneratorServers []LoadGeneratorServer `gorm:"foreignKey:LoadGeneratorInstallInfoId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 }
 
+// mciId returns the mci of the remote load generator. The load generator installed before the regions has the default mci.
+func (i *LoadGeneratorInstallInfo) mciId() string {
+	if i.MciId == "" {
+		return antMciId
+	}
+	return i.MciId
+}
+
+// LoadGeneratorKey is the ssh key pair of the load generator, whose private key is encrypted by the master key of the keystore.
+// The active key reaches the servers of the load generator, and the revoked key is removed from their authorized keys.
+type LoadGeneratorKey struct {
+	gorm.Model
+	LoadGeneratorInstallInfoId uint `gorm:"index"`
+	KeyType                    string
+	PublicKey                  string
+	Fingerprint                string
+	EncryptedPrivateKey        string
+	Status                     string
+	RevokedAt                  *time.Time
+}
+
 type LoadTestExecutionState struct {
 	gorm.Model
 	LoadTestKey                 string `gorm:"index:idx_state_load_test_key,unique"`
@@ -74,9 +110,15 @@ type LoadTestExecutionState struct {
 	StartAt                     time.Time
 	FinishAt                    *time.Time
 	TotalExpectedExcutionSecond uint64
+	QueuePosition               int
+	GeneratorSaturated          bool
+	GeneratorSaturationMessage  string
 	FailureMessage              string
 	CompileDuration             string
 	ExecutionDuration           string
+	Verdict                     constant.Verdict
+	SloResults                  []LoadTestSloResult
+	FileTransfers               []LoadTestFileTransfer
 
 	LoadTestExecutionInfoId uint
 
@@ -84,6 +126,32 @@ type LoadTestExecutionState struct {
 	LoadGeneratorInstallInfo   LoadGeneratorInstallInfo
 }
 
+// LoadTestSloResult is the evaluation result of a slo rule against a label of the load test result.
+type LoadTestSloResult struct {
+	gorm.Model
+	LoadTestExecutionStateId uint `gorm:"index"`
+	Label                    string
+	Metric                   string
+	Operator                 string
+	Threshold                float64
+	Actual                   float64
+	Passed                   bool
+	Message                  string
+}
+
+// LoadTestFileTransfer is the transfer status of a result file of the load test fetched from a host of the load generator.
+// Bytes is how much of the file is fetched, where the next fetch resumes.
+type LoadTestFileTransfer struct {
+	gorm.Model
+	LoadTestExecutionStateId uint `gorm:"index"`
+	Host                     string
+	FileName                 string
+	Bytes                    int64
+	Status                   string
+	Message                  string
+	TransferredAt            *time.Time
+}
+
 type LoadTestExecutionInfo struct {
 	gorm.Model
 	LoadTestKey                string `gorm:"index:idx_info_load_test_key,unique"`
@@ -98,6 +166,10 @@ type LoadTestExecutionInfo struct {
 	AgentInstalled             bool
 	CompileDuration            string
 	ExecutionDuration          string
+	TestPlanId                 uint
+	Engine                     constant.LoadGeneratorType
+	LoadProfile                string `gorm:"type:text"`
+	SloRules                   string `gorm:"type:text"` // evaluated again when the load test is recovered
 	LoadTestExecutionHttpInfos []LoadTestExecutionHttpInfo
 
 	LoadTestExecutionState LoadTestExecutionState
@@ -115,5 +187,150 @@ type LoadTestExecutionHttpInfo struct {
 	Path     string
 	BodyData string
 
+	// headers, cookies and extractors are stored as json. credentials of the auth are not stored,
+	// and the values of the cookies and the sensitive headers such as authorization are redacted.
+	Headers         string `gorm:"type:text"`
+	Cookies         string `gorm:"type:text"`
+	ContentType     string
+	AuthType        string
+	ConnectTimeout  int
+	ResponseTimeout int
+	RedirectPolicy  string
+	Name            string
+	Transaction     string
+	ThinkTime       int
+	Extractors      string `gorm:"type:text"`
+
 	LoadTestExecutionInfoId uint
 }
+
+type LoadTestSchedule struct {
+	gorm.Model
+	Name                       string
+	CronExpression             string
+	Enabled                    bool
+	LoadGeneratorInstallInfoId uint
+	RunLoadTestParam           string `gorm:"type:text"` // credentials of the http requests are redacted
+	EncryptedSecrets           string `gorm:"type:text"` // credentials of the http requests encrypted by the keystore
+	NextRunAt                  *time.Time
+	LastRunAt                  *time.Time
+	LastLoadTestKey            string
+	LastRunMessage             string
+}
+
+// LoadTestCapacitySearch runs the load tests of increasing load step by step until the limits are exceeded.
+type LoadTestCapacitySearch struct {
+	gorm.Model
+	TestName                 string
+	Status                   constant.ExecutionStatus
+	RunCapacitySearchParam   string `gorm:"type:text"`
+	MaxSustainableLoad       int
+	MaxSustainableThroughput float64
+	BreakingStep             int
+	BreakingLoad             int
+	BreakingReason           string
+	FailureMessage           string
+	StartAt                  time.Time
+	FinishAt                 *time.Time
+	Steps                    []LoadTestCapacitySearchStep
+}
+
+type LoadTestCapacitySearchStep struct {
+	gorm.Model
+	LoadTestCapacitySearchId uint `gorm:"index"`
+	Step                     int
+	Load                     int
+	LoadTestKey              string
+	RequestCount             int
+	Throughput               float64
+	ErrorPercent             float64
+	NinetyFive               float64
+	Passed                   bool
+	Message                  string
+}
+
+// MultiRegionLoadTest runs the load test from the regional load generators at once.
+// Each region runs its own load test with its share of the load.
+type MultiRegionLoadTest struct {
+	gorm.Model
+	TestName                    string
+	Status                      constant.ExecutionStatus
+	RunMultiRegionLoadTestParam string `gorm:"type:text"`
+	FailureMessage              string
+	StartAt                     time.Time
+	FinishAt                    *time.Time
+	Regions                     []MultiRegionLoadTestRegion
+}
+
+type MultiRegionLoadTestRegion struct {
+	gorm.Model
+	MultiRegionLoadTestId      uint `gorm:"index"`
+	RegionName                 string
+	Coordinates                string
+	Weight                     int
+	LoadTestKey                string
+	LoadGeneratorInstallInfoId uint
+	FailureMessage             string
+}
+
+// LoadTestResultRawData is a sample of the load test result which is ingested from the result csv file
+// after the load test is finished.
+type LoadTestResultRawData struct {
+	ID          uint   `gorm:"primarykey"`
+	LoadTestKey string `gorm:"index:idx_result_raw_load_test_key"`
+	Label       string
+	No          int
+	Elapsed     int
+	Bytes       int
+	SentBytes   int
+	URL         string
+	Latency     int
+	IdleTime    int
+	Connection  int
+	IsError     bool
+	Timestamp   time.Time
+	CreatedAt   time.Time
+}
+
+// LoadTestMetricsRawData is a perfmon metric of the load test which is ingested from the metrics csv files
+// after the load test is finished.
+type LoadTestMetricsRawData struct {
+	ID          uint   `gorm:"primarykey"`
+	LoadTestKey string `gorm:"index:idx_metrics_raw_load_test_key"`
+	Label       string
+	Value       string
+	Unit        string
+	IsError     bool
+	Timestamp   time.Time
+	CreatedAt   time.Time
+}
+
+// LoadGeneratorMetricsRawData is a resource usage sample of the load generator taken while the load test runs.
+type LoadGeneratorMetricsRawData struct {
+	ID          uint   `gorm:"primarykey"`
+	LoadTestKey string `gorm:"index:idx_generator_metrics_load_test_key"`
+	Label       string
+	Value       float64
+	Unit        string
+	Timestamp   time.Time
+	CreatedAt   time.Time
+}
+
+// LoadTestPlan is a user supplied jmeter test plan which is stored in the plan library.
+type LoadTestPlan struct {
+	gorm.Model
+	Name        string
+	Description string
+	FileName    string
+	Content     string `gorm:"type:text"`
+	DataFiles   []LoadTestPlanDataFile
+}
+
+// LoadTestPlanDataFile is a csv data file which is referenced by the csv data set config of the test plan.
+type LoadTestPlanDataFile struct {
+	gorm.Model
+	LoadTestPlanId uint `gorm:"index"`
+	FileName       string
+	Size           int
+	Content        string `gorm:"type:text"`
+}
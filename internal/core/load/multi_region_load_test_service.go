@@ -0,0 +1,476 @@
+package load
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloud-barista/cm-ant/internal/core/common/constant"
+	"github.com/cloud-barista/cm-ant/internal/utils"
+)
+
+const (
+	maxLoadTestRegions = 10
+)
+
+// RunMultiRegionLoadTest validates the param, stores a new multi region load test and runs it asynchronously.
+// The load generators of the regions are installed first, so the load tests of the regions start together.
+func (l *LoadService) RunMultiRegionLoadTest(param RunMultiRegionLoadTestParam) (MultiRegionLoadTestResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var res MultiRegionLoadTestResult
+
+	regionParams, err := regionLoadTestParams(param)
+	if err != nil {
+		return res, err
+	}
+
+	// the parameter is stored only to be returned, so the credentials of the http requests are not kept
+	stored := param
+	stored.RunLoadTestParam, _ = withoutHttpSecrets(param.RunLoadTestParam)
+	p, err := json.Marshal(stored)
+	if err != nil {
+		return res, err
+	}
+
+	test := MultiRegionLoadTest{
+		TestName:                    param.RunLoadTestParam.TestName,
+		Status:                      constant.OnRunning,
+		RunMultiRegionLoadTestParam: string(p),
+		StartAt:                     time.Now(),
+	}
+
+	for _, r := range param.Regions {
+		test.Regions = append(test.Regions, MultiRegionLoadTestRegion{
+			RegionName:  r.RegionName,
+			Coordinates: strings.Join(r.Coordinates, ","),
+			Weight:      regionWeight(r),
+		})
+	}
+
+	err = l.loadRepo.InsertMultiRegionLoadTestTx(ctx, &test)
+	if err != nil {
+		utils.LogErrorf("Error inserting multi region load test: %v", err)
+		return res, err
+	}
+
+	go l.runMultiRegionLoadTest(&test, regionParams)
+
+	utils.LogInfof("Multi region load test %d started in %d regions", test.ID, len(test.Regions))
+	return l.mapMultiRegionLoadTestResult(test), nil
+}
+
+func (l *LoadService) GetMultiRegionLoadTest(id uint) (MultiRegionLoadTestResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	test, err := l.loadRepo.GetMultiRegionLoadTestTx(ctx, id)
+	if err != nil {
+		utils.LogErrorf("Error fetching multi region load test: %v", err)
+		return MultiRegionLoadTestResult{}, err
+	}
+
+	return l.mapMultiRegionLoadTestResult(test), nil
+}
+
+func (l *LoadService) GetAllMultiRegionLoadTests(param GetAllMultiRegionLoadTestsParam) (GetAllMultiRegionLoadTestsResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var res GetAllMultiRegionLoadTestsResult
+
+	tests, totalRows, err := l.loadRepo.GetPagingMultiRegionLoadTestsTx(ctx, param)
+	if err != nil {
+		utils.LogErrorf("Error fetching multi region load tests: %v", err)
+		return res, err
+	}
+
+	for _, t := range tests {
+		res.MultiRegionLoadTests = append(res.MultiRegionLoadTests, l.mapMultiRegionLoadTestResult(t))
+	}
+	res.TotalRow = totalRows
+
+	return res, nil
+}
+
+// GetMultiRegionLoadTestResult returns the statistics of each label measured from each region,
+// so the latency of the same request can be compared between the regions.
+func (l *LoadService) GetMultiRegionLoadTestResult(id uint) ([]RegionLoadTestStatistics, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	test, err := l.loadRepo.GetMultiRegionLoadTestTx(ctx, id)
+	cancel()
+
+	if err != nil {
+		utils.LogErrorf("Error fetching multi region load test: %v", err)
+		return nil, err
+	}
+
+	var res []RegionLoadTestStatistics
+	for _, r := range test.Regions {
+		if r.LoadTestKey == "" {
+			continue
+		}
+
+		statistics, err := l.loadResultStatistics(r.LoadTestKey, nil)
+		if err != nil {
+			utils.LogWarnf("Result of region %s of multi region load test %d is not ready: %v", r.RegionName, id, err)
+			continue
+		}
+
+		for _, s := range statistics {
+			res = append(res, RegionLoadTestStatistics{
+				RegionName:         r.RegionName,
+				LoadTestKey:        r.LoadTestKey,
+				LoadTestStatistics: *s,
+			})
+		}
+	}
+
+	if len(res) == 0 {
+		return nil, errors.New("no result of the multi region load test yet")
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Label < res[j].Label
+	})
+
+	return res, nil
+}
+
+// failInterruptedMultiRegionLoadTests fails the multi region load tests which were running when the server stopped.
+// The load tests of the regions are recovered by themselves.
+func (l *LoadService) failInterruptedMultiRegionLoadTests() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	count, err := l.loadRepo.FailRunningMultiRegionLoadTestsTx(ctx, "the multi region load test is interrupted by the restart of the server", time.Now())
+	if err != nil {
+		utils.LogErrorf("Error failing interrupted multi region load tests: %v", err)
+		return
+	}
+
+	if count > 0 {
+		utils.LogWarnf("%d multi region load tests are failed since they were interrupted by the restart", count)
+	}
+}
+
+func regionWeight(r LoadTestRegionParam) int {
+	if r.Weight == 0 {
+		return 1
+	}
+	return r.Weight
+}
+
+// regionLoadTestParams validates the regions and returns the load test of each region
+// which runs on the regional load generator with the share of the load by its weight.
+func regionLoadTestParams(param RunMultiRegionLoadTestParam) ([]RunLoadTestParam, error) {
+	if len(param.Regions) == 0 || len(param.Regions) > maxLoadTestRegions {
+		return nil, fmt.Errorf("multi region load test must have 1 to %d regions", maxLoadTestRegions)
+	}
+
+	base := param.RunLoadTestParam
+	if base.TestPlanId != uint(0) {
+		return nil, errors.New("multi region load test can not be run with the stored test plan")
+	}
+
+	names := make(map[string]bool)
+	weights := make([]int, 0, len(param.Regions))
+	for _, r := range param.Regions {
+		if !regionNameRegex.MatchString(r.RegionName) {
+			return nil, fmt.Errorf("region name %q must be lowercase letters, digits and hyphens up to 20 characters", r.RegionName)
+		}
+
+		if names[r.RegionName] {
+			return nil, fmt.Errorf("region %s is duplicated", r.RegionName)
+		}
+		names[r.RegionName] = true
+
+		if len(r.Coordinates) == 0 {
+			return nil, fmt.Errorf("coordinates of region %s are required", r.RegionName)
+		}
+
+		if r.Weight < 0 {
+			return nil, fmt.Errorf("weight of region %s must not be negative", r.RegionName)
+		}
+
+		weights = append(weights, regionWeight(r))
+	}
+
+	params := make([]RunLoadTestParam, len(param.Regions))
+	for i, r := range param.Regions {
+		p := base
+		p.TestName = fmt.Sprintf("%s (%s)", base.TestName, r.RegionName)
+		p.LoadGeneratorInstallInfoId = 0
+		p.InstallLoadGenerator = InstallLoadGeneratorParam{
+			InstallLocation: constant.Remote,
+			Coordinates:     r.Coordinates,
+			WorkerCount:     base.InstallLoadGenerator.WorkerCount,
+			RegionName:      r.RegionName,
+
+			LoadGeneratorVmParam: base.InstallLoadGenerator.LoadGeneratorVmParam,
+		}
+		params[i] = p
+	}
+
+	if base.LoadProfile != nil {
+		profiles := make([]LoadProfileParam, len(params))
+		for i := range profiles {
+			profiles[i] = LoadProfileParam{
+				Model:  base.LoadProfile.Model,
+				Stages: make([]LoadStageParam, len(base.LoadProfile.Stages)),
+			}
+		}
+
+		for j, s := range base.LoadProfile.Stages {
+			for i, target := range apportion(s.Target, weights) {
+				profiles[i].Stages[j] = LoadStageParam{Duration: s.Duration, Target: target}
+			}
+		}
+
+		if base.LoadProfile.MaxVirtualUsers > 0 {
+			for i, users := range apportion(base.LoadProfile.MaxVirtualUsers, weights) {
+				if users == 0 {
+					return nil, fmt.Errorf("max virtual users %d are too few to divide among %d regions", base.LoadProfile.MaxVirtualUsers, len(params))
+				}
+				profiles[i].MaxVirtualUsers = users
+			}
+		}
+
+		for i := range params {
+			peak := 0
+			for _, s := range profiles[i].Stages {
+				peak = max(peak, s.Target)
+			}
+
+			if peak == 0 {
+				return nil, fmt.Errorf("region %s has no load with the weight %d; raise the targets or the weight", param.Regions[i].RegionName, weights[i])
+			}
+
+			params[i].LoadProfile = &profiles[i]
+		}
+	} else {
+		virtualUsers, err := strconv.Atoi(base.VirtualUsers)
+		if err != nil {
+			return nil, fmt.Errorf("virtual users %q must be a number; %w", base.VirtualUsers, err)
+		}
+
+		for i, users := range apportion(virtualUsers, weights) {
+			if users == 0 {
+				return nil, fmt.Errorf("region %s has no virtual users with the weight %d; raise the virtual users or the weight", param.Regions[i].RegionName, weights[i])
+			}
+			params[i].VirtualUsers = strconv.Itoa(users)
+		}
+	}
+
+	for i, p := range params {
+		if err := validateRunLoadTestParam(p); err != nil {
+			return nil, fmt.Errorf("load test of region %s is not valid; %w", param.Regions[i].RegionName, err)
+		}
+	}
+
+	return params, nil
+}
+
+// apportion divides the total by the weights with the largest remainder method, so the shares add up to the total.
+func apportion(total int, weights []int) []int {
+	sum := 0
+	for _, w := range weights {
+		sum += w
+	}
+
+	shares := make([]int, len(weights))
+	if sum == 0 {
+		return shares
+	}
+
+	assigned := 0
+	order := make([]int, len(weights))
+	for i, w := range weights {
+		shares[i] = total * w / sum
+		assigned += shares[i]
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(a, b int) bool {
+		return total*weights[order[a]]%sum > total*weights[order[b]]%sum
+	})
+
+	for k := 0; assigned < total; k++ {
+		shares[order[k%len(order)]]++
+		assigned++
+	}
+
+	return shares
+}
+
+// runMultiRegionLoadTest installs the load generators of the regions, starts the load test of every region
+// and waits until all of them are finished.
+func (l *LoadService) runMultiRegionLoadTest(test *MultiRegionLoadTest, params []RunLoadTestParam) {
+	err := func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		err := l.validDefaultNs(ctx, antNsId)
+		cancel()
+
+		if err != nil {
+			return fmt.Errorf("failed to prepare the namespace; %w", err)
+		}
+
+		var wg sync.WaitGroup
+		for i := range params {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+
+				region := &test.Regions[i]
+				utils.LogInfof("Installing load generator of region %s for multi region load test %d", region.RegionName, test.ID)
+
+				result, err := l.InstallLoadGenerator(params[i].InstallLoadGenerator)
+				if err != nil {
+					region.FailureMessage = fmt.Sprintf("failed to install the load generator; %s", err)
+					return
+				}
+
+				region.LoadGeneratorInstallInfoId = result.ID
+				params[i].LoadGeneratorInstallInfoId = result.ID
+			}(i)
+		}
+		wg.Wait()
+
+		var failed []string
+		for _, r := range test.Regions {
+			if r.FailureMessage != "" {
+				failed = append(failed, r.RegionName)
+			}
+		}
+
+		if len(failed) > 0 {
+			return fmt.Errorf("load generators of regions %s are not installed", strings.Join(failed, ", "))
+		}
+
+		for i := range params {
+			region := &test.Regions[i]
+
+			loadTestKey, err := l.RunLoadTest(params[i])
+			if err != nil {
+				region.FailureMessage = fmt.Sprintf("failed to run the load test; %s", err)
+				continue
+			}
+			region.LoadTestKey = loadTestKey
+		}
+
+		l.saveMultiRegionLoadTest(test)
+
+		for i := range params {
+			region := &test.Regions[i]
+			if region.LoadTestKey == "" {
+				continue
+			}
+
+			expectedSecond, _ := expectedExecutionSecond(params[i])
+			state, err := l.waitLoadTestFinished(region.LoadTestKey, expectedSecond)
+			if err != nil {
+				region.FailureMessage = err.Error()
+				continue
+			}
+
+			if state.ExecutionStatus != constant.Successed {
+				region.FailureMessage = fmt.Sprintf("load test %s is %s; %s", region.LoadTestKey, state.ExecutionStatus, state.FailureMessage)
+			}
+		}
+
+		var messages []string
+		for _, r := range test.Regions {
+			if r.FailureMessage != "" {
+				messages = append(messages, fmt.Sprintf("%s: %s", r.RegionName, r.FailureMessage))
+			}
+		}
+
+		if len(messages) > 0 {
+			return errors.New(strings.Join(messages, ", "))
+		}
+
+		return nil
+	}()
+
+	test.Status = constant.Successed
+	if err != nil {
+		utils.LogErrorf("Error running multi region load test %d: %v", test.ID, err)
+		test.Status = constant.Failed
+		test.FailureMessage = err.Error()
+	}
+
+	finishAt := time.Now()
+	test.FinishAt = &finishAt
+
+	l.saveMultiRegionLoadTest(test)
+}
+
+func (l *LoadService) saveMultiRegionLoadTest(test *MultiRegionLoadTest) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	err := l.loadRepo.UpdateMultiRegionLoadTestTx(ctx, test)
+	if err != nil {
+		utils.LogErrorf("Error updating multi region load test %d: %v", test.ID, err)
+	}
+}
+
+func (l *LoadService) mapMultiRegionLoadTestResult(t MultiRegionLoadTest) MultiRegionLoadTestResult {
+	var param RunMultiRegionLoadTestParam
+	if err := json.Unmarshal([]byte(t.RunMultiRegionLoadTestParam), &param); err != nil {
+		utils.LogErrorf("Error unmarshaling param of multi region load test %d: %v", t.ID, err)
+	}
+
+	var regions []MultiRegionLoadTestRegionResult
+	for _, r := range t.Regions {
+		rr := MultiRegionLoadTestRegionResult{
+			RegionName:                 r.RegionName,
+			Coordinates:                strings.Split(r.Coordinates, ","),
+			Weight:                     r.Weight,
+			LoadTestKey:                r.LoadTestKey,
+			LoadGeneratorInstallInfoId: r.LoadGeneratorInstallInfoId,
+			FailureMessage:             r.FailureMessage,
+		}
+
+		if r.LoadTestKey != "" {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			state, err := l.loadRepo.GetLoadTestExecutionStateTx(ctx, GetLoadTestExecutionStateParam{LoadTestKey: r.LoadTestKey})
+			cancel()
+
+			if err != nil {
+				utils.LogWarnf("Error fetching state of load test %s: %v", r.LoadTestKey, err)
+			} else {
+				rr.ExecutionStatus = state.ExecutionStatus
+
+				for _, s := range state.LoadGeneratorInstallInfo.LoadGeneratorServers {
+					if s.IsMaster {
+						rr.Csp = s.Csp
+						rr.Region = s.Region
+						rr.Lat = s.Lat
+						rr.Lon = s.Lon
+					}
+				}
+			}
+		}
+
+		regions = append(regions, rr)
+	}
+
+	return MultiRegionLoadTestResult{
+		ID:                          t.ID,
+		TestName:                    t.TestName,
+		Status:                      t.Status,
+		RunMultiRegionLoadTestParam: param,
+		FailureMessage:              t.FailureMessage,
+		Regions:                     regions,
+		StartAt:                     t.StartAt,
+		FinishAt:                    t.FinishAt,
+	}
+}
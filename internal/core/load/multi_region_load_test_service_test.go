@@ -0,0 +1,63 @@
+package load
+
+import (
+	"testing"
+
+	"github.com/cloud-barista/cm-ant/internal/core/common/constant"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApportion(t *testing.T) {
+	require.Equal(t, []int{4, 3, 3}, apportion(10, []int{1, 1, 1}))
+	require.Equal(t, []int{6, 2, 2}, apportion(10, []int{3, 1, 1}))
+	require.Equal(t, []int{1, 0}, apportion(1, []int{1, 1}))
+	require.Equal(t, []int{0, 0}, apportion(0, []int{2, 1}))
+}
+
+func TestRegionLoadTestParams(t *testing.T) {
+	base := RunLoadTestParam{
+		TestName:     "checkout",
+		VirtualUsers: "10",
+		Duration:     "60",
+		RampUpTime:   "10",
+		RampUpSteps:  "1",
+		HttpReqs:     []RunLoadTestHttpParam{{Method: "GET", Protocol: "http", Hostname: "localhost"}},
+	}
+	regions := []LoadTestRegionParam{
+		{RegionName: "seoul", Coordinates: []string{"37.53/127.02"}, Weight: 3},
+		{RegionName: "frankfurt", Coordinates: []string{"50.11/8.68"}},
+	}
+
+	params, err := regionLoadTestParams(RunMultiRegionLoadTestParam{RunLoadTestParam: base, Regions: regions})
+	require.NoError(t, err)
+	require.Len(t, params, 2)
+	require.Equal(t, "8", params[0].VirtualUsers)
+	require.Equal(t, "2", params[1].VirtualUsers)
+	require.Equal(t, "checkout (frankfurt)", params[1].TestName)
+	require.Equal(t, constant.Remote, params[1].InstallLoadGenerator.InstallLocation)
+	require.Equal(t, "frankfurt", params[1].InstallLoadGenerator.RegionName)
+	require.Equal(t, []string{"50.11/8.68"}, params[1].InstallLoadGenerator.Coordinates)
+
+	// the stages of the load profile are divided with the same shape
+	profiled := base
+	profiled.LoadProfile = &LoadProfileParam{
+		Model:  loadModelOpen,
+		Stages: []LoadStageParam{{Duration: 10, Target: 40}, {Duration: 30, Target: 40}, {Duration: 10, Target: 0}},
+	}
+	params, err = regionLoadTestParams(RunMultiRegionLoadTestParam{RunLoadTestParam: profiled, Regions: regions})
+	require.NoError(t, err)
+	require.Equal(t, []LoadStageParam{{10, 30}, {30, 30}, {10, 0}}, params[0].LoadProfile.Stages)
+	require.Equal(t, []LoadStageParam{{10, 10}, {30, 10}, {10, 0}}, params[1].LoadProfile.Stages)
+	require.Equal(t, 40, profiled.LoadProfile.Stages[0].Target)
+
+	few := base
+	few.VirtualUsers = "1"
+	_, err = regionLoadTestParams(RunMultiRegionLoadTestParam{RunLoadTestParam: few, Regions: regions})
+	require.Error(t, err)
+
+	_, err = regionLoadTestParams(RunMultiRegionLoadTestParam{RunLoadTestParam: base, Regions: []LoadTestRegionParam{regions[0], regions[0]}})
+	require.Error(t, err)
+
+	_, err = regionLoadTestParams(RunMultiRegionLoadTestParam{RunLoadTestParam: base, Regions: []LoadTestRegionParam{{RegionName: "Seoul", Coordinates: []string{"37.53/127.02"}}}})
+	require.Error(t, err)
+}
@@ -0,0 +1,739 @@
+package load
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptrace"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloud-barista/cm-ant/internal/core/common/constant"
+	"github.com/cloud-barista/cm-ant/internal/utils"
+)
+
+const (
+	nativeFlushInterval    = time.Second
+	nativeScheduleInterval = 100 * time.Millisecond
+	nativeArrivalInterval  = 10 * time.Millisecond
+)
+
+// nativeResultHeader is the header of the jmeter csv result which appendResultRawData reads.
+var nativeResultHeader = []string{
+	"timeStamp", "elapsed", "label", "responseCode", "responseMessage", "threadName", "dataType", "success",
+	"failureMessage", "bytes", "sentBytes", "grpThreads", "allThreads", "URL", "Latency", "IdleTime", "Connect",
+}
+
+// inProcessEngine is the engine which generates the load inside the ant process
+// instead of running a command on the load generator.
+type inProcessEngine interface {
+	LoadGeneratorEngine
+
+	// Run generates the load until the test ends or the context is done and writes the samples to the result file.
+	// The samples sent before the context is done are kept in the result file.
+	Run(ctx context.Context, param RunLoadTestParam, resultPath string) error
+}
+
+// nativeEngine sends the http requests from the goroutines of the ant process, so the local load generator
+// runs the quick tests without installing jmeter. The samples are written in the csv schema of jmeter.
+// Only the local install location is supported.
+type nativeEngine struct{}
+
+type nativePlan struct {
+	testName        string
+	open            bool
+	maxVirtualUsers int
+	stages          []nativeStage
+	steps           []nativeStep
+	cookies         []nativeCookie
+}
+
+// nativeStage changes the level of the load linearly to the target for the duration.
+// The level is the number of virtual users in the closed model and the arrivals per second in the open model.
+type nativeStage struct {
+	duration time.Duration
+	target   int
+}
+
+type nativeUser struct {
+	threadName string
+	jar        http.CookieJar
+	vars       map[string]string
+}
+
+type nativeStep struct {
+	label       string
+	transaction string
+	method      string
+	url         string
+	body        string
+	hasBody     bool
+	headers     []RunLoadTestHttpHeaderParam
+	auth        *RunLoadTestHttpAuthParam
+	thinkTime   time.Duration
+	extractors  []RunLoadTestExtractorParam
+	transport   *http.Transport
+	redirect    func(req *http.Request, via []*http.Request) error
+}
+
+type nativeCookie struct {
+	url    *url.URL
+	cookie *http.Cookie
+}
+
+type nativeSample struct {
+	start      time.Time
+	elapsed    int64
+	label      string
+	code       string
+	message    string
+	success    bool
+	bytes      int64
+	sentBytes  int64
+	url        string
+	latency    int64
+	connection int64
+}
+
+func (nativeEngine) Type() constant.LoadGeneratorType {
+	return constant.Native
+}
+
+// InstallScript returns nothing because the native engine is built into ant.
+func (nativeEngine) InstallScript(string) (string, []string) {
+	return "", nil
+}
+
+func (nativeEngine) Installed(string) bool {
+	return true
+}
+
+// PlanFileName returns nothing because the native engine runs the load test parameter as it is.
+func (nativeEngine) PlanFileName(string) string {
+	return ""
+}
+
+func (nativeEngine) RenderPlan(io.Writer, RunLoadTestParam, *LoadGeneratorInstallInfo, *LoadTestPlan) error {
+	return errors.New("native engine does not render a test plan")
+}
+
+func (nativeEngine) ExecutionCmd(*LoadGeneratorInstallInfo, string, []string) string {
+	return ""
+}
+
+func (nativeEngine) KillCmd(string) string {
+	return ""
+}
+
+func (nativeEngine) RunningCheckCmd(string) string {
+	return ""
+}
+
+func (nativeEngine) ParseResult(filePath string) (map[string][]*ResultRawData, error) {
+	return appendResultRawData(filePath)
+}
+
+func (nativeEngine) ParseResultRows(csvRows [][]string) (map[string][]*ResultRawData, error) {
+	return resultRawDataOf(csvRows)
+}
+
+func (nativeEngine) SupportsDistributed() bool {
+	return false
+}
+
+func (nativeEngine) Run(ctx context.Context, param RunLoadTestParam, resultPath string) error {
+	plan, err := newNativePlan(param)
+	if err != nil {
+		return err
+	}
+
+	if param.AgentHostname != "" {
+		utils.LogWarnf("Perfmon metrics of load test %s are not collected by the native engine", param.LoadTestKey)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(resultPath), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(resultPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	rows := make(chan []string, 1024)
+	writeDone := make(chan error, 1)
+	go func() {
+		writeDone <- writeNativeResult(f, rows)
+	}()
+
+	utils.LogInfof("Native load test %s started with %d stages", param.LoadTestKey, len(plan.stages))
+	plan.run(ctx, rows)
+	close(rows)
+
+	if err := <-writeDone; err != nil {
+		return fmt.Errorf("failed to write the result of native load test; %w", err)
+	}
+
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return errLoadTestStopped
+	}
+
+	utils.LogInfof("Native load test %s finished", param.LoadTestKey)
+	return nil
+}
+
+// newNativePlan resolves the load test parameter into the steps which every virtual user sends in order.
+func newNativePlan(param RunLoadTestParam) (*nativePlan, error) {
+	if len(param.HttpReqs) == 0 {
+		return nil, errors.New("http requests are required for the native engine")
+	}
+
+	plan := &nativePlan{testName: param.TestName}
+
+	if p := param.LoadProfile; p != nil {
+		plan.open = p.isOpen()
+		plan.maxVirtualUsers = p.MaxVirtualUsers
+		for _, s := range p.Stages {
+			plan.stages = append(plan.stages, nativeStage{duration: time.Duration(s.Duration) * time.Second, target: s.Target})
+		}
+	} else {
+		stages, err := nativePlateauStages(param)
+		if err != nil {
+			return nil, err
+		}
+		plan.stages = stages
+	}
+
+	maxLevel := 1
+	for _, s := range plan.stages {
+		maxLevel = max(maxLevel, s.target)
+	}
+
+	for _, req := range param.HttpReqs {
+		method := strings.ToUpper(req.Method)
+		hasBody, ok := supportedHttpMethods[method]
+		if !ok {
+			return nil, fmt.Errorf("http method %q is not supported", req.Method)
+		}
+
+		h := req.Hostname
+		if h == "" {
+			h = param.Hostname
+		}
+
+		baseUrl := fmt.Sprintf("%s://%s", req.Protocol, h)
+		if p := req.Port; p != "" {
+			baseUrl += ":" + p
+		} else if param.Port != "" {
+			baseUrl += ":" + param.Port
+		}
+
+		path := req.Path
+		if path != "" && !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+
+		connectTimeout := time.Duration(defaultHttpTimeout) * time.Millisecond
+		if req.ConnectTimeout > 0 {
+			connectTimeout = time.Duration(req.ConnectTimeout) * time.Millisecond
+		}
+
+		responseTimeout := time.Duration(defaultHttpTimeout) * time.Millisecond
+		if req.ResponseTimeout > 0 {
+			responseTimeout = time.Duration(req.ResponseTimeout) * time.Millisecond
+		}
+
+		step := nativeStep{
+			label:       method + " Request",
+			transaction: req.Transaction,
+			method:      method,
+			url:         baseUrl + path,
+			body:        req.BodyData,
+			hasBody:     hasBody,
+			auth:        req.Auth,
+			thinkTime:   time.Duration(req.ThinkTime) * time.Millisecond,
+			extractors:  req.Extractors,
+			transport: &http.Transport{
+				Proxy:                 http.ProxyFromEnvironment,
+				DialContext:           (&net.Dialer{Timeout: connectTimeout}).DialContext,
+				TLSHandshakeTimeout:   connectTimeout,
+				ResponseHeaderTimeout: responseTimeout,
+				MaxIdleConnsPerHost:   maxLevel,
+				// jmeter trusts every certificate of the target by default
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			},
+		}
+
+		if req.Name != "" {
+			step.label = req.Name
+		}
+
+		if strings.EqualFold(req.RedirectPolicy, redirectNone) {
+			step.redirect = func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			}
+		}
+
+		step.headers = req.Headers
+		if req.ContentType != "" && !hasHeader(step.headers, "Content-Type") {
+			step.headers = append(step.headers, RunLoadTestHttpHeaderParam{Name: "Content-Type", Value: req.ContentType})
+		}
+
+		cookieUrl, err := url.Parse(baseUrl)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range req.Cookies {
+			plan.cookies = append(plan.cookies, nativeCookie{
+				url:    cookieUrl,
+				cookie: &http.Cookie{Name: c.Name, Value: c.Value, Path: "/"},
+			})
+		}
+
+		plan.steps = append(plan.steps, step)
+	}
+
+	return plan, nil
+}
+
+// nativePlateauStages converts the ramp up steps of the concurrency thread group into the stages,
+// where each step starts its virtual users at once and the last step holds for the duration.
+func nativePlateauStages(param RunLoadTestParam) ([]nativeStage, error) {
+	vus, err := strconv.Atoi(param.VirtualUsers)
+	if err != nil || vus < 1 {
+		return nil, errors.New("virtual users must be positive number")
+	}
+
+	duration, err := strconv.Atoi(param.Duration)
+	if err != nil {
+		return nil, fmt.Errorf("duration must be number; %w", err)
+	}
+
+	rampUpTime, err := strconv.Atoi(param.RampUpTime)
+	if err != nil {
+		return nil, fmt.Errorf("ramp up time must be number; %w", err)
+	}
+
+	rampUpSteps, err := strconv.Atoi(param.RampUpSteps)
+	if err != nil || rampUpSteps < 1 || rampUpTime == 0 {
+		rampUpSteps = 1
+	}
+
+	interval := time.Duration(rampUpTime) * time.Second / time.Duration(rampUpSteps)
+
+	var stages []nativeStage
+	for step := 1; step <= rampUpSteps; step++ {
+		target := vus * step / rampUpSteps
+		hold := interval
+		if step == rampUpSteps {
+			hold += time.Duration(duration) * time.Second
+		}
+		stages = append(stages, nativeStage{target: target}, nativeStage{duration: hold, target: target})
+	}
+
+	return stages, nil
+}
+
+func (p *nativePlan) totalDuration() time.Duration {
+	var total time.Duration
+	for _, s := range p.stages {
+		total += s.duration
+	}
+	return total
+}
+
+// levelAt returns the level of the load at the elapsed time of the test.
+func (p *nativePlan) levelAt(elapsed time.Duration) float64 {
+	level := 0.0
+	var at time.Duration
+	for _, s := range p.stages {
+		target := float64(s.target)
+		if s.duration > 0 && elapsed < at+s.duration {
+			return level + (target-level)*float64(elapsed-at)/float64(s.duration)
+		}
+		level = target
+		at += s.duration
+	}
+	return level
+}
+
+// run generates the load of the stages and waits until the stages are over or the context is canceled.
+func (p *nativePlan) run(ctx context.Context, rows chan<- []string) {
+	ctx, cancel := context.WithTimeout(ctx, p.totalDuration())
+	defer cancel()
+
+	if p.open {
+		p.runArrivals(ctx, rows)
+	} else {
+		p.runUsers(ctx, rows)
+	}
+}
+
+// runUsers keeps the number of the virtual users at the level like the concurrency thread group of jmeter.
+// The latest virtual users are stopped first when the level goes down.
+func (p *nativePlan) runUsers(ctx context.Context, rows chan<- []string) {
+	var wg sync.WaitGroup
+	var active atomic.Int64
+	var cancels []context.CancelFunc
+	id := 0
+	start := time.Now()
+
+	ticker := time.NewTicker(nativeScheduleInterval)
+	defer ticker.Stop()
+
+	for {
+		level := int(p.levelAt(time.Since(start)))
+
+		for len(cancels) < level {
+			id++
+			userCtx, userCancel := context.WithCancel(ctx)
+			cancels = append(cancels, userCancel)
+
+			wg.Add(1)
+			active.Add(1)
+			go func(u *nativeUser) {
+				defer wg.Done()
+				defer active.Add(-1)
+				for userCtx.Err() == nil {
+					if !p.runIteration(userCtx, u, &active, rows) {
+						return
+					}
+				}
+			}(p.newUser(id))
+		}
+
+		for len(cancels) > level {
+			cancels[len(cancels)-1]()
+			cancels = cancels[:len(cancels)-1]
+		}
+
+		select {
+		case <-ctx.Done():
+			for _, c := range cancels {
+				c()
+			}
+			wg.Wait()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runArrivals starts the iterations at the level per second like the arrivals thread group of jmeter.
+// Every iteration is run by a new virtual user, and the arrivals over the max virtual users are dropped.
+func (p *nativePlan) runArrivals(ctx context.Context, rows chan<- []string) {
+	var wg sync.WaitGroup
+	var active atomic.Int64
+	var last time.Duration
+	arrivals := 0.0
+	id, dropped := 0, 0
+	start := time.Now()
+
+	ticker := time.NewTicker(nativeArrivalInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			if dropped > 0 {
+				utils.LogWarnf("Native load test dropped %d arrivals over %d max virtual users", dropped, p.maxVirtualUsers)
+			}
+			return
+		case <-ticker.C:
+		}
+
+		now := time.Since(start)
+		arrivals += (p.levelAt(last) + p.levelAt(now)) / 2 * (now - last).Seconds()
+		last = now
+
+		for ; arrivals >= 1; arrivals-- {
+			if p.maxVirtualUsers > 0 && active.Load() >= int64(p.maxVirtualUsers) {
+				dropped++
+				continue
+			}
+
+			id++
+			wg.Add(1)
+			active.Add(1)
+			go func(u *nativeUser) {
+				defer wg.Done()
+				defer active.Add(-1)
+				p.runIteration(ctx, u, &active, rows)
+			}(p.newUser(id))
+		}
+	}
+}
+
+func (p *nativePlan) newUser(id int) *nativeUser {
+	jar, _ := cookiejar.New(nil)
+	for _, c := range p.cookies {
+		jar.SetCookies(c.url, []*http.Cookie{c.cookie})
+	}
+
+	return &nativeUser{
+		threadName: fmt.Sprintf("%s Thread Group 1-%d", p.testName, id),
+		jar:        jar,
+		vars:       make(map[string]string),
+	}
+}
+
+// runIteration sends the steps once. Consecutive steps of the same transaction are reported once more
+// as the transaction sample which does not include the think times.
+// It returns false when the iteration is canceled.
+func (p *nativePlan) runIteration(ctx context.Context, u *nativeUser, active *atomic.Int64, rows chan<- []string) bool {
+	emit := func(s *nativeSample) {
+		n := strconv.FormatInt(active.Load(), 10)
+		rows <- []string{
+			strconv.FormatInt(s.start.UnixMilli(), 10),
+			strconv.FormatInt(s.elapsed, 10),
+			s.label,
+			s.code,
+			s.message,
+			u.threadName,
+			"text",
+			strconv.FormatBool(s.success),
+			"",
+			strconv.FormatInt(s.bytes, 10),
+			strconv.FormatInt(s.sentBytes, 10),
+			n,
+			n,
+			s.url,
+			strconv.FormatInt(s.latency, 10),
+			"0",
+			strconv.FormatInt(s.connection, 10),
+		}
+	}
+
+	var transaction *nativeSample
+	var count, failed int
+
+	flush := func() {
+		if transaction == nil {
+			return
+		}
+		transaction.message = fmt.Sprintf("Number of samples in transaction : %d, number of failing samples : %d", count, failed)
+		emit(transaction)
+		transaction = nil
+		count, failed = 0, 0
+	}
+
+	for _, step := range p.steps {
+		if transaction != nil && transaction.label != step.transaction {
+			flush()
+		}
+
+		s, ok := step.send(ctx, u.jar, u.vars)
+		if !ok {
+			return false
+		}
+		emit(s)
+
+		if step.transaction != "" {
+			if transaction == nil {
+				transaction = &nativeSample{start: s.start, label: step.transaction, success: true, url: "null"}
+			}
+			transaction.elapsed += s.elapsed
+			transaction.bytes += s.bytes
+			transaction.sentBytes += s.sentBytes
+			transaction.latency += s.latency
+			transaction.connection += s.connection
+			transaction.success = transaction.success && s.success
+			count++
+			if !s.success {
+				failed++
+			}
+		}
+
+		if step.thinkTime > 0 {
+			select {
+			case <-ctx.Done():
+				return false
+			case <-time.After(step.thinkTime):
+			}
+		}
+	}
+
+	flush()
+	return true
+}
+
+// send sends the request of the step and applies the extractors to the response.
+// It returns false when the request is canceled because the load test is over.
+func (s *nativeStep) send(ctx context.Context, jar http.CookieJar, vars map[string]string) (*nativeSample, bool) {
+	sample := &nativeSample{label: s.label, start: time.Now()}
+
+	var body io.Reader
+	if s.hasBody {
+		body = strings.NewReader(substituteVars(s.body, vars))
+	}
+
+	req, err := http.NewRequest(s.method, substituteVars(s.url, vars), body)
+	if err != nil {
+		setNonHttpFailure(sample, err)
+		return sample, true
+	}
+
+	for _, h := range s.headers {
+		req.Header.Add(h.Name, substituteVars(h.Value, vars))
+	}
+
+	if s.auth != nil {
+		switch strings.ToLower(s.auth.Type) {
+		case httpAuthBasic:
+			req.SetBasicAuth(s.auth.Username, s.auth.Password)
+		case httpAuthBearer:
+			req.Header.Set("Authorization", "Bearer "+s.auth.Token)
+		}
+	}
+
+	sample.url = req.URL.String()
+	sample.sentBytes = requestSize(req)
+
+	var firstByte, gotConn time.Time
+	var reused bool
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			gotConn = time.Now()
+			reused = info.Reused
+		},
+		GotFirstResponseByte: func() {
+			firstByte = time.Now()
+		},
+	}
+	req = req.WithContext(httptrace.WithClientTrace(ctx, trace))
+
+	client := &http.Client{Transport: s.transport, Jar: jar, CheckRedirect: s.redirect}
+	resp, err := client.Do(req)
+
+	var respBody []byte
+	if err == nil {
+		respBody, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+	}
+
+	if ctx.Err() != nil {
+		return nil, false
+	}
+
+	sample.elapsed = time.Since(sample.start).Milliseconds()
+	if !firstByte.IsZero() {
+		sample.latency = firstByte.Sub(sample.start).Milliseconds()
+	}
+	if !gotConn.IsZero() && !reused {
+		sample.connection = gotConn.Sub(sample.start).Milliseconds()
+	}
+
+	if err != nil {
+		setNonHttpFailure(sample, err)
+		sample.bytes = int64(len(respBody))
+	} else {
+		sample.code = strconv.Itoa(resp.StatusCode)
+		sample.message = http.StatusText(resp.StatusCode)
+		sample.success = resp.StatusCode < 400
+		sample.bytes = responseHeaderSize(resp) + int64(len(respBody))
+	}
+
+	for _, e := range s.extractors {
+		var headers string
+		if resp != nil {
+			headers = responseHeaderString(resp)
+		}
+		vars[e.Variable] = extractValue(e, respBody, headers)
+	}
+
+	return sample, true
+}
+
+// setNonHttpFailure marks the sample failed without the response like the non http response of jmeter.
+func setNonHttpFailure(sample *nativeSample, err error) {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+	sample.code = fmt.Sprintf("Non HTTP response code: %T", err)
+	sample.message = "Non HTTP response message: " + err.Error()
+}
+
+var nativeVarRegex = regexp.MustCompile(`\$\{(\w+)\}`)
+
+// substituteVars replaces the ${name} references with the extracted variables of the virtual user.
+// The references which are not extracted yet are left as they are like jmeter.
+func substituteVars(v string, vars map[string]string) string {
+	if len(vars) == 0 || !strings.Contains(v, "${") {
+		return v
+	}
+
+	return nativeVarRegex.ReplaceAllStringFunc(v, func(m string) string {
+		if value, ok := vars[m[2:len(m)-1]]; ok {
+			return value
+		}
+		return m
+	})
+}
+
+func requestSize(req *http.Request) int64 {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s %s HTTP/1.1\r\nHost: %s\r\n", req.Method, req.URL.RequestURI(), req.URL.Host)
+	req.Header.Write(&buf)
+	buf.WriteString("\r\n")
+	return int64(buf.Len()) + req.ContentLength
+}
+
+func responseHeaderSize(resp *http.Response) int64 {
+	return int64(len(responseHeaderString(resp))) + 2
+}
+
+func responseHeaderString(resp *http.Response) string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s %s\r\n", resp.Proto, resp.Status)
+	resp.Header.Write(&buf)
+	return buf.String()
+}
+
+// writeNativeResult writes the rows in the csv schema of jmeter.
+// The rows are flushed periodically so the progress of the load test is read while the test is running.
+func writeNativeResult(w io.Writer, rows <-chan []string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(nativeResultHeader); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(nativeFlushInterval)
+	defer ticker.Stop()
+
+	var err error
+	for {
+		select {
+		case row, ok := <-rows:
+			if !ok {
+				cw.Flush()
+				if err != nil {
+					return err
+				}
+				return cw.Error()
+			}
+			if err == nil {
+				err = cw.Write(row)
+			}
+		case <-ticker.C:
+			cw.Flush()
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package load
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNativePlanRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"data": {"token": "abc"}}`))
+		case "/items":
+			if r.Header.Get("Authorization") != "Bearer abc" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte("ok"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	plan, err := newNativePlan(RunLoadTestParam{
+		TestName:     "native",
+		VirtualUsers: "2",
+		Duration:     "1",
+		RampUpTime:   "0",
+		RampUpSteps:  "1",
+		HttpReqs: []RunLoadTestHttpParam{
+			{
+				Method:      "POST",
+				Protocol:    "http",
+				Hostname:    server.Listener.Addr().String(),
+				Path:        "/login",
+				Name:        "login",
+				Transaction: "flow",
+				Extractors: []RunLoadTestExtractorParam{
+					{Type: "jsonpath", Variable: "token", Expression: "$.data.token"},
+				},
+			},
+			{
+				Method:      "GET",
+				Protocol:    "http",
+				Hostname:    server.Listener.Addr().String(),
+				Path:        "/items",
+				Name:        "items",
+				Transaction: "flow",
+				Headers:     []RunLoadTestHttpHeaderParam{{Name: "Authorization", Value: "Bearer ${token}"}},
+			},
+			{
+				Method:   "GET",
+				Protocol: "http",
+				Hostname: server.Listener.Addr().String(),
+				Path:     "/missing",
+			},
+		},
+	})
+	require.NoError(t, err)
+	plan.stages = []nativeStage{{target: 2}, {duration: 300 * time.Millisecond, target: 2}}
+
+	filePath := filepath.Join(t.TempDir(), "key_result.csv")
+	f, err := os.Create(filePath)
+	require.NoError(t, err)
+
+	rows := make(chan []string, 16)
+	writeDone := make(chan error, 1)
+	go func() {
+		writeDone <- writeNativeResult(f, rows)
+	}()
+
+	plan.run(context.Background(), rows)
+	close(rows)
+	require.NoError(t, <-writeDone)
+	require.NoError(t, f.Close())
+
+	resultMap, err := appendResultRawData(filePath)
+	require.NoError(t, err)
+	require.NotEmpty(t, resultMap["login"])
+	require.NotEmpty(t, resultMap["flow"])
+	require.NotEmpty(t, resultMap["GET Request"])
+
+	for _, r := range resultMap["items"] {
+		require.False(t, r.IsError)
+		require.Greater(t, r.Bytes, 0)
+	}
+
+	for _, r := range resultMap["GET Request"] {
+		require.True(t, r.IsError)
+	}
+
+	_, err = newNativePlan(RunLoadTestParam{VirtualUsers: "0", Duration: "1", RampUpTime: "0"})
+	require.Error(t, err)
+}
+
+func TestNativePlanStages(t *testing.T) {
+	stages, err := nativePlateauStages(RunLoadTestParam{VirtualUsers: "10", Duration: "60", RampUpTime: "10", RampUpSteps: "2"})
+	require.NoError(t, err)
+
+	plan := &nativePlan{stages: stages}
+	require.Equal(t, 70*time.Second, plan.totalDuration())
+	require.Equal(t, 5.0, plan.levelAt(0))
+	require.Equal(t, 5.0, plan.levelAt(4*time.Second))
+	require.Equal(t, 10.0, plan.levelAt(5*time.Second))
+	require.Equal(t, 10.0, plan.levelAt(70*time.Second))
+
+	plan, err = newNativePlan(RunLoadTestParam{
+		LoadProfile: &LoadProfileParam{
+			Model:  "open",
+			Stages: []LoadStageParam{{Duration: 10, Target: 100}, {Duration: 0, Target: 10}, {Duration: 10, Target: 0}},
+		},
+		HttpReqs: []RunLoadTestHttpParam{{Method: "GET", Protocol: "http", Hostname: "localhost"}},
+	})
+	require.NoError(t, err)
+	require.True(t, plan.open)
+	require.Equal(t, 50.0, plan.levelAt(5*time.Second))
+	require.Equal(t, 10.0, plan.levelAt(10*time.Second))
+	require.Equal(t, 5.0, plan.levelAt(15*time.Second))
+}
+
+func TestExtractValue(t *testing.T) {
+	body := []byte(`{"items": [{"id": 1, "name": "a"}, {"id": 2, "name": "b"}], "meta": {"next": "n1", "page": {"next": "n2"}}}`)
+
+	cases := []struct {
+		extractor RunLoadTestExtractorParam
+		expected  string
+	}{
+		{RunLoadTestExtractorParam{Type: "jsonpath", Expression: "$.items[1].name"}, "b"},
+		{RunLoadTestExtractorParam{Type: "jsonpath", Expression: "$.items[-1].id"}, "2"},
+		{RunLoadTestExtractorParam{Type: "jsonpath", Expression: "$['meta']['next']"}, "n1"},
+		{RunLoadTestExtractorParam{Type: "jsonpath", Expression: "$.items[*].id", MatchNumber: 2}, "2"},
+		{RunLoadTestExtractorParam{Type: "jsonpath", Expression: "$.items[*].id", MatchNumber: 0}, "1"},
+		{RunLoadTestExtractorParam{Type: "jsonpath", Expression: "$.items[0]"}, `{"id":1,"name":"a"}`},
+		{RunLoadTestExtractorParam{Type: "jsonpath", Expression: "$.items[*].id", MatchNumber: 3, DefaultValue: "none"}, "none"},
+		{RunLoadTestExtractorParam{Type: "jsonpath", Expression: "$.missing", DefaultValue: "none"}, "none"},
+		{RunLoadTestExtractorParam{Type: "regex", Expression: `"name": "(\w)"`, MatchNumber: 2}, "b"},
+		{RunLoadTestExtractorParam{Type: "regex", Expression: `"(id)": (\d)`, Template: "$1$=$2$"}, "id=1"},
+		{RunLoadTestExtractorParam{Type: "regex", Expression: `Location: (\S+)`, Field: "headers"}, "/next"},
+	}
+
+	for _, c := range cases {
+		require.Equal(t, c.expected, extractValue(c.extractor, body, "HTTP/1.1 302 Found\r\nLocation: /next\r\n"), c.extractor.Expression)
+	}
+
+	values, err := evalJsonPath(map[string]any{"a": map[string]any{"next": "n"}}, "$..next")
+	require.NoError(t, err)
+	require.Equal(t, []any{"n"}, values)
+
+	_, err = evalJsonPath(nil, "items")
+	require.Error(t, err)
+}
@@ -0,0 +1,224 @@
+package load
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var regexTemplateGroupRegex = regexp.MustCompile(`\$(\d+)\$`)
+
+// extractValue extracts the variable from the response with the jsonpath or regex extractor
+// the same way as the post processors of the jmx test plan, where the match number 0 is taken as the first match.
+func extractValue(e RunLoadTestExtractorParam, body []byte, headers string) string {
+	var values []string
+	var err error
+
+	switch strings.ToLower(e.Type) {
+	case extractorJsonPath:
+		values, err = extractJsonPath(body, e.Expression)
+	case extractorRegex:
+		source := string(body)
+		if strings.EqualFold(e.Field, "headers") {
+			source = headers
+		}
+		values, err = extractRegex(source, e.Expression, e.Template)
+	default:
+		err = fmt.Errorf("extractor type %q is not supported", e.Type)
+	}
+
+	if err != nil || len(values) == 0 {
+		return e.DefaultValue
+	}
+
+	matchNumber := e.MatchNumber
+	if matchNumber <= 0 {
+		matchNumber = 1
+	}
+
+	if matchNumber > len(values) {
+		return e.DefaultValue
+	}
+
+	return values[matchNumber-1]
+}
+
+func extractRegex(source, expression, tmpl string) ([]string, error) {
+	re, err := regexp.Compile(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	if tmpl == "" {
+		tmpl = "$1$"
+	}
+
+	var values []string
+	for _, m := range re.FindAllStringSubmatch(source, -1) {
+		values = append(values, regexTemplateGroupRegex.ReplaceAllStringFunc(tmpl, func(g string) string {
+			i, _ := strconv.Atoi(g[1 : len(g)-1])
+			if i < len(m) {
+				return m[i]
+			}
+			return ""
+		}))
+	}
+
+	return values, nil
+}
+
+func extractJsonPath(body []byte, expression string) ([]string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	nodes, err := evalJsonPath(doc, expression)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]string, 0, len(nodes))
+	for _, n := range nodes {
+		switch v := n.(type) {
+		case string:
+			values = append(values, v)
+		case json.Number:
+			values = append(values, v.String())
+		default:
+			b, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, string(b))
+		}
+	}
+
+	return values, nil
+}
+
+// evalJsonPath evaluates the subset of jsonpath which is used for the extractors of the scenarios.
+// The root `$`, child `.name` and `['name']`, index `[0]`, wildcard `*` and recursive descent `..` are supported.
+func evalJsonPath(doc any, path string) ([]any, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("jsonpath %q must start with $", path)
+	}
+
+	nodes := []any{doc}
+	rest := path[1:]
+
+	for rest != "" {
+		recursive := false
+
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			recursive = true
+			rest = rest[2:]
+		case strings.HasPrefix(rest, "."):
+			rest = rest[1:]
+		}
+
+		var selector string
+		var err error
+		if strings.HasPrefix(rest, "[") {
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("jsonpath %q has unclosed bracket", path)
+			}
+			selector = strings.TrimSpace(rest[1:end])
+			rest = rest[end+1:]
+
+			if unquoted, ok := unquoteJsonPathName(selector); ok {
+				selector = unquoted
+			} else if selector != "*" {
+				if _, err = strconv.Atoi(selector); err != nil {
+					return nil, fmt.Errorf("jsonpath %q has invalid selector %q", path, selector)
+				}
+				selector = "[" + selector + "]"
+			}
+		} else {
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			selector = rest[:end]
+			rest = rest[end:]
+		}
+
+		if selector == "" {
+			return nil, fmt.Errorf("jsonpath %q has empty selector", path)
+		}
+
+		if recursive {
+			nodes = descendants(nodes)
+		}
+
+		var next []any
+		for _, n := range nodes {
+			next = append(next, selectJsonPath(n, selector)...)
+		}
+		nodes = next
+	}
+
+	return nodes, nil
+}
+
+func unquoteJsonPathName(selector string) (string, bool) {
+	if len(selector) >= 2 && (selector[0] == '\'' || selector[0] == '"') && selector[len(selector)-1] == selector[0] {
+		return selector[1 : len(selector)-1], true
+	}
+	return "", false
+}
+
+// selectJsonPath selects the children of the node. The index selector is wrapped in brackets to tell it from the names.
+func selectJsonPath(node any, selector string) []any {
+	switch v := node.(type) {
+	case map[string]any:
+		if selector == "*" {
+			children := make([]any, 0, len(v))
+			for _, c := range v {
+				children = append(children, c)
+			}
+			return children
+		}
+		if c, ok := v[selector]; ok {
+			return []any{c}
+		}
+	case []any:
+		if selector == "*" {
+			return v
+		}
+		if strings.HasPrefix(selector, "[") {
+			i, _ := strconv.Atoi(selector[1 : len(selector)-1])
+			if i < 0 {
+				i += len(v)
+			}
+			if i >= 0 && i < len(v) {
+				return []any{v[i]}
+			}
+		}
+	}
+	return nil
+}
+
+func descendants(nodes []any) []any {
+	var result []any
+	for _, n := range nodes {
+		result = append(result, n)
+		switch v := n.(type) {
+		case map[string]any:
+			for _, c := range v {
+				result = append(result, descendants([]any{c})...)
+			}
+		case []any:
+			result = append(result, descendants(v)...)
+		}
+	}
+	return result
+}
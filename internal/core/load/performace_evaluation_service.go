@@ -2,6 +2,7 @@ package load
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/cloud-barista/cm-ant/internal/infra/outbound/tumblebug"
@@ -133,18 +134,75 @@ func (l *LoadService) GetLoadTestExecutionInfo(param GetLoadTestExecutionInfoPar
 }
 
 func mapLoadTestExecutionHttpInfoResult(h LoadTestExecutionHttpInfo) LoadTestExecutionHttpInfoResult {
+	var headers []RunLoadTestHttpHeaderParam
+	if h.Headers != "" {
+		if err := json.Unmarshal([]byte(h.Headers), &headers); err != nil {
+			utils.LogErrorf("Error unmarshaling headers of http info %d: %v", h.ID, err)
+		}
+	}
+
+	var cookies []RunLoadTestHttpCookieParam
+	if h.Cookies != "" {
+		if err := json.Unmarshal([]byte(h.Cookies), &cookies); err != nil {
+			utils.LogErrorf("Error unmarshaling cookies of http info %d: %v", h.ID, err)
+		}
+	}
+
+	var extractors []RunLoadTestExtractorParam
+	if h.Extractors != "" {
+		if err := json.Unmarshal([]byte(h.Extractors), &extractors); err != nil {
+			utils.LogErrorf("Error unmarshaling extractors of http info %d: %v", h.ID, err)
+		}
+	}
+
 	return LoadTestExecutionHttpInfoResult{
-		ID:       h.ID,
-		Method:   h.Method,
-		Protocol: h.Protocol,
-		Hostname: h.Hostname,
-		Port:     h.Port,
-		Path:     h.Path,
-		BodyData: h.BodyData,
+		ID:              h.ID,
+		Method:          h.Method,
+		Protocol:        h.Protocol,
+		Hostname:        h.Hostname,
+		Port:            h.Port,
+		Path:            h.Path,
+		BodyData:        h.BodyData,
+		Headers:         headers,
+		Cookies:         cookies,
+		ContentType:     h.ContentType,
+		AuthType:        h.AuthType,
+		ConnectTimeout:  h.ConnectTimeout,
+		ResponseTimeout: h.ResponseTimeout,
+		RedirectPolicy:  h.RedirectPolicy,
+		Name:            h.Name,
+		Transaction:     h.Transaction,
+		ThinkTime:       h.ThinkTime,
+		Extractors:      extractors,
 	}
 }
 
 func mapLoadTestExecutionStateResult(state LoadTestExecutionState) LoadTestExecutionStateResult {
+	var sloResults []LoadTestSloResultResult
+	for _, r := range state.SloResults {
+		sloResults = append(sloResults, LoadTestSloResultResult{
+			Label:     r.Label,
+			Metric:    r.Metric,
+			Operator:  r.Operator,
+			Threshold: r.Threshold,
+			Actual:    r.Actual,
+			Passed:    r.Passed,
+			Message:   r.Message,
+		})
+	}
+
+	var fileTransfers []LoadTestFileTransferResult
+	for _, t := range state.FileTransfers {
+		fileTransfers = append(fileTransfers, LoadTestFileTransferResult{
+			Host:          t.Host,
+			FileName:      t.FileName,
+			Bytes:         t.Bytes,
+			Status:        t.Status,
+			Message:       t.Message,
+			TransferredAt: t.TransferredAt,
+		})
+	}
+
 	return LoadTestExecutionStateResult{
 		ID:                          state.ID,
 		LoadTestKey:                 state.LoadTestKey,
@@ -152,9 +210,15 @@ func mapLoadTestExecutionStateResult(state LoadTestExecutionState) LoadTestExecu
 		StartAt:                     state.StartAt,
 		FinishAt:                    state.FinishAt,
 		TotalExpectedExcutionSecond: state.TotalExpectedExcutionSecond,
+		QueuePosition:               state.QueuePosition,
+		GeneratorSaturated:          state.GeneratorSaturated,
+		GeneratorSaturationMessage:  state.GeneratorSaturationMessage,
 		FailureMessage:              state.FailureMessage,
 		CompileDuration:             state.CompileDuration,
 		ExecutionDuration:           state.ExecutionDuration,
+		Verdict:                     state.Verdict,
+		SloResults:                  sloResults,
+		FileTransfers:               fileTransfers,
 		CreatedAt:                   state.CreatedAt,
 		UpdatedAt:                   state.UpdatedAt,
 	}
@@ -179,6 +243,8 @@ func mapLoadGeneratorServerResult(s LoadGeneratorServer) LoadGeneratorServerResu
 		StartTime:       s.StartTime,
 		AdditionalVmKey: s.AdditionalVmKey,
 		Label:           s.Label,
+		IsCluster:       s.IsCluster,
+		IsMaster:        s.IsMaster,
 		CreatedAt:       s.CreatedAt,
 	}
 }
@@ -200,6 +266,9 @@ func mapLoadGeneratorInstallInfoResult(install LoadGeneratorInstallInfo) LoadGen
 		UpdatedAt:            install.UpdatedAt,
 		PublicKeyName:        install.PublicKeyName,
 		PrivateKeyName:       install.PrivateKeyName,
+		IsCluster:            install.IsCluster,
+		MasterId:             install.MasterId,
+		ClusterSize:          install.ClusterSize,
 		LoadGeneratorServers: servers,
 	}
 }
@@ -213,6 +282,13 @@ func mapLoadTestExecutionInfoResult(executionInfo LoadTestExecutionInfo) LoadTes
 	executionState := mapLoadTestExecutionStateResult(executionInfo.LoadTestExecutionState)
 	installInfo := mapLoadGeneratorInstallInfoResult(executionInfo.LoadGeneratorInstallInfo)
 
+	var loadProfile *LoadProfileParam
+	if executionInfo.LoadProfile != "" {
+		if err := json.Unmarshal([]byte(executionInfo.LoadProfile), &loadProfile); err != nil {
+			utils.LogErrorf("Error unmarshaling load profile of load test %s: %v", executionInfo.LoadTestKey, err)
+		}
+	}
+
 	return LoadTestExecutionInfoResult{
 		ID:                         executionInfo.ID,
 		LoadTestKey:                executionInfo.LoadTestKey,
@@ -227,6 +303,10 @@ func mapLoadTestExecutionInfoResult(executionInfo LoadTestExecutionInfo) LoadTes
 		AgentInstalled:             executionInfo.AgentInstalled,
 		CompileDuration:            executionInfo.CompileDuration,
 		ExecutionDuration:          executionInfo.ExecutionDuration,
+		TestPlanId:                 executionInfo.TestPlanId,
+		Engine:                     executionInfo.Engine,
+		LoadProfile:                loadProfile,
+		SloRules:                   sloRulesOf(executionInfo),
 		LoadTestExecutionHttpInfos: httpResults,
 		LoadTestExecutionState:     executionState,
 		LoadGeneratorInstallInfo:   installInfo,
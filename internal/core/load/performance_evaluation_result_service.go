@@ -70,36 +70,75 @@ var tags = map[string]metricsUnits{
 	},
 }
 
+// GetLoadTestResult returns the result of the load test in the format.
+// The aggregate and the time series are calculated as the result is scanned, so the raw result is not held whole for them.
 func (l *LoadService) GetLoadTestResult(param GetLoadTestResultParam) (interface{}, error) {
-	loadTestKey := param.LoadTestKey
-	fileName := fmt.Sprintf("%s_result.csv", loadTestKey)
-	resultFolderPath := utils.JoinRootPathWith("/result/" + loadTestKey)
-	toFilePath := fmt.Sprintf("%s/%s", resultFolderPath, fileName)
-	resultMap, err := appendResultRawData(toFilePath)
+	switch param.Format {
+	case constant.Aggregate:
+		return l.loadResultStatistics(param.LoadTestKey, nil)
+	case constant.TimeSeries:
+		return l.loadResultTimeSeries(param.LoadTestKey, param.Interval)
+	}
+
+	return l.loadResultSummaries(param.LoadTestKey)
+}
+
+// CompareLoadTestResult aggregates the results of the base and target load tests
+// and compares them label by label to find out the regression after migration.
+func (l *LoadService) CompareLoadTestResult(param CompareLoadTestResultParam) (CompareLoadTestResult, error) {
+	res := CompareLoadTestResult{
+		BaseKey:    param.BaseKey,
+		TargetKey:  param.TargetKey,
+		Thresholds: param.Thresholds,
+	}
+
+	baseStatistics, err := l.loadResultStatistics(param.BaseKey, nil)
 	if err != nil {
-		return nil, err
+		utils.LogErrorf("Error reading base load test result %s: %v", param.BaseKey, err)
+		return res, err
 	}
 
-	var resultSummaries []ResultSummary
+	targetStatistics, err := l.loadResultStatistics(param.TargetKey, nil)
+	if err != nil {
+		utils.LogErrorf("Error reading target load test result %s: %v", param.TargetKey, err)
+		return res, err
+	}
 
-	for label, results := range resultMap {
-		resultSummaries = append(resultSummaries, ResultSummary{
-			Label:   label,
-			Results: results,
-		})
+	res.Comparisons = compareStatistics(baseStatistics, targetStatistics, param.Thresholds)
+
+	for _, c := range res.Comparisons {
+		if c.Regressed {
+			res.Regressed = true
+			break
+		}
 	}
 
-	formattedDate, err := resultFormat(param.Format, resultSummaries)
+	return res, nil
+}
+
+// GetLoadTestMetrics returns the metrics of the target servers collected by the monitoring agent
+// with the resource usage of the load generator sampled while the load test runs.
+func (l *LoadService) GetLoadTestMetrics(param GetLoadTestResultParam) ([]MetricsSummary, error) {
+	generatorSummaries, err := l.loadGeneratorMetricsSummaries(param.LoadTestKey)
+	if err != nil {
+		utils.LogErrorf("Error fetching load generator metrics of load test %s: %v", param.LoadTestKey, err)
+		return nil, err
+	}
 
+	metricsSummaries, err := l.loadMetricsSummaries(param.LoadTestKey)
 	if err != nil {
+		// the target metrics only exist when the monitoring agent is installed
+		if len(generatorSummaries) > 0 {
+			utils.LogWarnf("Metrics of target servers of load test %s are not found: %v", param.LoadTestKey, err)
+			return generatorSummaries, nil
+		}
 		return nil, err
 	}
 
-	return formattedDate, nil
+	return append(metricsSummaries, generatorSummaries...), nil
 }
 
-func (l *LoadService) GetLoadTestMetrics(param GetLoadTestResultParam) ([]MetricsSummary, error) {
-	loadTestKey := param.LoadTestKey
+func readMetricsSummaries(loadTestKey string) ([]MetricsSummary, error) {
 	metrics := []string{"cpu", "disk", "memory", "network"}
 	resultFolderPath := utils.JoinRootPathWith("/result/" + loadTestKey)
 
@@ -126,11 +165,28 @@ func (l *LoadService) GetLoadTestMetrics(param GetLoadTestResultParam) ([]Metric
 		})
 	}
 
+	return metricsSummaries, nil
+}
+
+func readResultSummaries(engine LoadGeneratorEngine, loadTestKey string) ([]ResultSummary, error) {
+	fileName := resultFileNameOf(loadTestKey)
+	resultFolderPath := utils.JoinRootPathWith("/result/" + loadTestKey)
+	toFilePath := fmt.Sprintf("%s/%s", resultFolderPath, fileName)
+	resultMap, err := engine.ParseResult(toFilePath)
 	if err != nil {
 		return nil, err
 	}
 
-	return metricsSummaries, nil
+	var resultSummaries []ResultSummary
+
+	for label, results := range resultMap {
+		resultSummaries = append(resultSummaries, ResultSummary{
+			Label:   label,
+			Results: results,
+		})
+	}
+
+	return resultSummaries, nil
 }
 
 func calculatePercentile(elapsedList []int, percentile float64) float64 {
@@ -184,18 +240,23 @@ func calculateSentKBPerSec(totalBytes int, totalMillTime int) float64 {
 }
 
 func appendResultRawData(filePath string) (map[string][]*ResultRawData, error) {
-	var resultMap = make(map[string][]*ResultRawData)
-
 	csvRows, err := utils.ReadCSV(filePath)
 	if err != nil || csvRows == nil {
 		return nil, err
 	}
 
-	if len(*csvRows) <= 1 {
+	return resultRawDataOf(*csvRows)
+}
+
+// resultRawDataOf groups the samples of the jmeter csv rows by label. The first row is the header.
+func resultRawDataOf(csvRows [][]string) (map[string][]*ResultRawData, error) {
+	var resultMap = make(map[string][]*ResultRawData)
+
+	if len(csvRows) <= 1 {
 		return nil, errors.New("result data file is empty")
 	}
 	// every time is basically millisecond
-	for i, row := range (*csvRows)[1:] {
+	for i, row := range csvRows[1:] {
 		label := row[2]
 
 		elapsed, err := strconv.Atoi(row[1])
@@ -324,91 +385,407 @@ func appendMetricsRawData(mrds map[string][]*MetricsRawData, filePath string) (m
 }
 
 func aggregate(resultRawDatas []ResultSummary) []*LoadTestStatistics {
-	var statistics []*LoadTestStatistics
+	return aggregateBy(resultRawDatas, nil)
+}
+
+// aggregateBy aggregates the samples under the labels returned by labelOf. See newResultStatistics.
+func aggregateBy(resultRawDatas []ResultSummary, labelOf func(string) string) []*LoadTestStatistics {
+	statistics := newResultStatistics(labelOf)
+	for _, summary := range resultRawDatas {
+		for _, r := range summary.Results {
+			statistics.add(summary.Label, r)
+		}
+	}
+
+	return statistics.statistics()
+}
 
-	for i := range resultRawDatas {
+// labelStatistics accumulates the samples of a label, keeping only the elapsed times of them for the percentiles.
+type labelStatistics struct {
+	label          string
+	requestCount   int
+	totalElapsed   int
+	totalBytes     int
+	totalSentBytes int
+	errorCount     int
+	startTime      time.Time
+	endTime        time.Time
+	elapsedList    []int
+}
 
-		record := resultRawDatas[i]
-		var requestCount, totalElapsed, totalBytes, totalSentBytes, errorCount int
-		var elapsedList []int
-		if len(record.Results) < 1 {
-			continue
+func (s *labelStatistics) add(r *ResultRawData) {
+	if s.requestCount == 0 || r.Timestamp.Before(s.startTime) {
+		s.startTime = r.Timestamp
+	}
+	if s.requestCount == 0 || r.Timestamp.After(s.endTime) {
+		s.endTime = r.Timestamp
+	}
+
+	s.requestCount++
+	if r.IsError {
+		s.errorCount++
+	} else {
+		s.totalElapsed += r.Elapsed
+	}
+
+	s.totalBytes += r.Bytes
+	s.totalSentBytes += r.SentBytes
+	s.elapsedList = append(s.elapsedList, r.Elapsed)
+}
+
+func (s *labelStatistics) statistics() *LoadTestStatistics {
+	// total Elapsed time and running time is different
+	runningTime := s.endTime.Sub(s.startTime).Milliseconds()
+
+	// for percentile calculation purpose
+	sort.Ints(s.elapsedList)
+
+	return &LoadTestStatistics{
+		Label:         s.label,
+		RequestCount:  s.requestCount,
+		Average:       float64(s.totalElapsed) / float64(s.requestCount),
+		Median:        calculateMedian(s.elapsedList),
+		NinetyPercent: calculatePercentile(s.elapsedList, 0.9),
+		NinetyFive:    calculatePercentile(s.elapsedList, 0.95),
+		NinetyNine:    calculatePercentile(s.elapsedList, 0.99),
+		MinTime:       findMin(s.elapsedList),
+		MaxTime:       findMax(s.elapsedList),
+		ErrorPercent:  calculateErrorPercent(s.errorCount, s.requestCount),
+		Throughput:    calculateThroughput(s.requestCount, int(runningTime)),
+		ReceivedKB:    calculateReceivedKBPerSec(s.totalBytes, int(runningTime)),
+		SentKB:        calculateSentKBPerSec(s.totalSentBytes, int(runningTime)),
+	}
+}
+
+// resultStatistics accumulates the samples as they are scanned, and returns the statistics of the labels in the order they are found.
+type resultStatistics struct {
+	labelOf func(string) string
+	labels  []*labelStatistics
+	indexes map[string]int
+}
+
+// newResultStatistics accumulates the samples under the label returned by labelOf, and leaves out the samples when it returns empty.
+// The samples are accumulated under their own labels when labelOf is nil.
+func newResultStatistics(labelOf func(string) string) *resultStatistics {
+	return &resultStatistics{labelOf: labelOf, indexes: make(map[string]int)}
+}
+
+func (s *resultStatistics) add(label string, r *ResultRawData) {
+	if s.labelOf != nil {
+		if label = s.labelOf(label); label == "" {
+			return
 		}
+	}
 
-		startTime := record.Results[0].Timestamp
-		endTime := record.Results[0].Timestamp
-		for _, record := range record.Results {
-			requestCount++
-			if !record.IsError {
-				totalElapsed += record.Elapsed
-			}
+	i, ok := s.indexes[label]
+	if !ok {
+		i = len(s.labels)
+		s.indexes[label] = i
+		s.labels = append(s.labels, &labelStatistics{label: label})
+	}
 
-			totalBytes += record.Bytes
-			totalSentBytes += record.SentBytes
+	s.labels[i].add(r)
+}
 
-			if record.IsError {
-				errorCount++
-			}
+func (s *resultStatistics) statistics() []*LoadTestStatistics {
+	var statistics []*LoadTestStatistics
+	for _, l := range s.labels {
+		statistics = append(statistics, l.statistics())
+	}
+	return statistics
+}
+
+const (
+	labelAdded   = "added"
+	labelRemoved = "removed"
+)
+
+// compareStatistics matches the statistics by label and calculates the deltas of the target from the base.
+// Labels which exist only in one of the results are returned without deltas, and the removed label is regressed
+// because the target did not run the request at all.
+func compareStatistics(base, target []*LoadTestStatistics, t CompareThresholds) []LabelComparison {
+	comparisonMap := make(map[string]*LabelComparison)
 
-			if record.Timestamp.Before(startTime) {
-				startTime = record.Timestamp
+	for _, s := range base {
+		comparisonMap[s.Label] = &LabelComparison{Label: s.Label, Base: s}
+	}
+
+	for _, s := range target {
+		c, ok := comparisonMap[s.Label]
+		if !ok {
+			c = &LabelComparison{Label: s.Label}
+			comparisonMap[s.Label] = c
+		}
+		c.Target = s
+	}
+
+	comparisons := make([]LabelComparison, 0, len(comparisonMap))
+
+	for _, c := range comparisonMap {
+		if c.Base != nil && c.Target != nil {
+			b, tg := c.Base, c.Target
+			c.Deltas = []MetricDelta{
+				latencyDelta("average", b.Average, tg.Average, t.LatencyThreshold),
+				latencyDelta("ninetyPercent", b.NinetyPercent, tg.NinetyPercent, t.LatencyThreshold),
+				latencyDelta("ninetyFive", b.NinetyFive, tg.NinetyFive, t.LatencyThreshold),
+				latencyDelta("ninetyNine", b.NinetyNine, tg.NinetyNine, t.LatencyThreshold),
+				errorPercentDelta(b.ErrorPercent, tg.ErrorPercent, t.ErrorPercentThreshold),
+				throughputDelta(b.Throughput, tg.Throughput, t.ThroughputThreshold),
 			}
-			if record.Timestamp.After(endTime) {
-				endTime = record.Timestamp
+
+			for _, d := range c.Deltas {
+				if d.Regressed {
+					c.Regressed = true
+					break
+				}
 			}
+		} else if c.Target == nil {
+			c.Change = labelRemoved
+			c.Regressed = true
+		} else {
+			c.Change = labelAdded
+		}
+
+		comparisons = append(comparisons, *c)
+	}
+
+	sort.Slice(comparisons, func(i, j int) bool {
+		return comparisons[i].Label < comparisons[j].Label
+	})
+
+	return comparisons
+}
+
+func newMetricDelta(metric string, base, target float64) MetricDelta {
+	d := MetricDelta{
+		Metric: metric,
+		Base:   base,
+		Target: target,
+		Delta:  target - base,
+	}
+
+	if base != 0 && !math.IsInf(base, 0) && !math.IsNaN(base) {
+		d.DeltaPercent = d.Delta / base * 100
+	} else if base == 0 && target != 0 {
+		d.FromZero = true
+	}
+
+	return d
+}
+
+// latencyDelta is regressed when the response time increased more than the threshold percent.
+// Any increase from zero exceeds the threshold.
+func latencyDelta(metric string, base, target, threshold float64) MetricDelta {
+	d := newMetricDelta(metric, base, target)
+	d.Regressed = d.DeltaPercent > threshold || (d.FromZero && d.Delta > 0)
+	return d
+}
+
+// errorPercentDelta is regressed when the error percent increased more than the threshold percentage points.
+func errorPercentDelta(base, target, threshold float64) MetricDelta {
+	d := newMetricDelta("errorPercent", base, target)
+	d.Regressed = d.Delta > threshold
+	return d
+}
+
+// throughputDelta is regressed when the throughput decreased more than the threshold percent.
+func throughputDelta(base, target, threshold float64) MetricDelta {
+	d := newMetricDelta("throughput", base, target)
+	d.Regressed = d.DeltaPercent < -threshold
+	return d
+}
+
+// maxTimeSeriesBuckets bounds the buckets of a label, which an outlier timestamp of the results would otherwise blow up.
+const maxTimeSeriesBuckets = 10000
+
+// timeSeries buckets the raw results of each label by the interval.
+// Buckets are aligned to the interval and empty buckets between the first and the last sample are kept for charting.
+// The interval is widened to its multiple when the results span more than the maximum buckets.
+func timeSeries(resultSummaries []ResultSummary, interval time.Duration) ([]ResultTimeSeries, error) {
+	builder, err := newTimeSeriesBuilder(interval)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, summary := range resultSummaries {
+		for _, r := range summary.Results {
+			builder.add(summary.Label, r)
+		}
+	}
+
+	return builder.series(), nil
+}
+
+// timeSeriesLabel is the samples of a label bucketed by the interval of the builder.
+type timeSeriesLabel struct {
+	first, last time.Time
+	buckets     map[int64][]*ResultRawData
+}
+
+// timeSeriesBuilder buckets the samples of each label by the interval as they are scanned.
+type timeSeriesBuilder struct {
+	interval    time.Duration
+	first, last time.Time
+	found       bool
+	labels      map[string]*timeSeriesLabel
+}
+
+func newTimeSeriesBuilder(interval time.Duration) (*timeSeriesBuilder, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("time series interval must be positive: %s", interval)
+	}
+
+	return &timeSeriesBuilder{
+		interval: interval,
+		labels:   make(map[string]*timeSeriesLabel),
+	}, nil
+}
 
-			elapsedList = append(elapsedList, record.Elapsed)
+func (b *timeSeriesBuilder) add(label string, r *ResultRawData) {
+	l, ok := b.labels[label]
+	if !ok {
+		l = &timeSeriesLabel{first: r.Timestamp, last: r.Timestamp, buckets: make(map[int64][]*ResultRawData)}
+		b.labels[label] = l
+	}
+
+	if !b.found {
+		b.first, b.last, b.found = r.Timestamp, r.Timestamp, true
+	}
+
+	if r.Timestamp.Before(l.first) {
+		l.first = r.Timestamp
+	}
+	if r.Timestamp.After(l.last) {
+		l.last = r.Timestamp
+	}
+	if r.Timestamp.Before(b.first) {
+		b.first = r.Timestamp
+	}
+	if r.Timestamp.After(b.last) {
+		b.last = r.Timestamp
+	}
+
+	key := r.Timestamp.Truncate(b.interval).UnixNano()
+	l.buckets[key] = append(l.buckets[key], r)
+}
+
+// series returns the series of the labels, whose buckets are merged into the widened interval when the samples span too long.
+func (b *timeSeriesBuilder) series() []ResultTimeSeries {
+	if len(b.labels) == 0 {
+		return nil
+	}
+
+	interval := timeSeriesInterval(b.first, b.last, b.interval)
+
+	series := make([]ResultTimeSeries, 0, len(b.labels))
+	for label, l := range b.labels {
+		start := l.first.Truncate(interval)
+		grouped := make([][]*ResultRawData, int(l.last.Sub(start)/interval)+1)
+
+		// the bucket of the interval is in a bucket of its multiple as a whole
+		for _, results := range l.buckets {
+			i := int(results[0].Timestamp.Sub(start) / interval)
+			grouped[i] = append(grouped[i], results...)
 		}
 
-		// total Elapsed time and running time is different
-		runningTime := endTime.Sub(startTime).Milliseconds()
-
-		// for percentile calculation purpose
-		sort.Ints(elapsedList)
-
-		average := float64(totalElapsed) / float64(requestCount)
-		median := calculateMedian(elapsedList)
-		ninetyPercent := calculatePercentile(elapsedList, 0.9)
-		ninetyFive := calculatePercentile(elapsedList, 0.95)
-		ninetyNine := calculatePercentile(elapsedList, 0.99)
-		calcMin := findMin(elapsedList)
-		calcMax := findMax(elapsedList)
-		errorPercent := calculateErrorPercent(errorCount, requestCount)
-		throughput := calculateThroughput(requestCount, int(runningTime))
-		receivedKB := calculateReceivedKBPerSec(totalBytes, int(runningTime))
-		sentKB := calculateSentKBPerSec(totalSentBytes, int(runningTime))
-
-		labelStat := LoadTestStatistics{
-			Label:         record.Label,
-			RequestCount:  requestCount,
-			Average:       average,
-			Median:        median,
-			NinetyPercent: ninetyPercent,
-			NinetyFive:    ninetyFive,
-			NinetyNine:    ninetyNine,
-			MinTime:       calcMin,
-			MaxTime:       calcMax,
-			ErrorPercent:  errorPercent,
-			Throughput:    throughput,
-			ReceivedKB:    receivedKB,
-			SentKB:        sentKB,
+		buckets := make([]*TimeSeriesBucket, 0, len(grouped))
+		for i, results := range grouped {
+			bucketStart := start.Add(time.Duration(i) * interval)
+			buckets = append(buckets, bucketOf(bucketStart, interval, results))
 		}
 
-		statistics = append(statistics, &labelStat)
+		series = append(series, ResultTimeSeries{
+			Label:    label,
+			Interval: interval.String(),
+			Buckets:  buckets,
+		})
 	}
 
-	return statistics
+	sort.Slice(series, func(i, j int) bool {
+		return series[i].Label < series[j].Label
+	})
+
+	return series
 }
 
-func resultFormat(format constant.ResultFormat, resultSummaries []ResultSummary) (any, error) {
-	if resultSummaries == nil {
-		return nil, nil
+// timeSeriesInterval returns the smallest multiple of the interval which buckets the results from the first to the last
+// within the maximum buckets, so the series of the labels share the same interval.
+func timeSeriesInterval(first, last time.Time, interval time.Duration) time.Duration {
+	buckets := int64(last.Sub(first.Truncate(interval))/interval) + 1
+	if buckets <= maxTimeSeriesBuckets {
+		return interval
 	}
 
-	switch format {
-	case constant.Aggregate:
-		return aggregate(resultSummaries), nil
+	// the start truncated to the widened interval and the last partial bucket may add two buckets
+	multiple := (buckets + maxTimeSeriesBuckets - 3) / (maxTimeSeriesBuckets - 2)
+	widened := interval * time.Duration(multiple)
+	utils.LogWarnf("Results span %s, so the time series interval %s is widened to %s", last.Sub(first), interval, widened)
+	return widened
+}
+
+func bucketOf(bucketStart time.Time, interval time.Duration, results []*ResultRawData) *TimeSeriesBucket {
+	bucket := &TimeSeriesBucket{
+		Timestamp:    bucketStart,
+		RequestCount: len(results),
 	}
 
-	return resultSummaries, nil
+	if len(results) == 0 {
+		return bucket
+	}
+
+	var totalElapsed int
+	elapsedList := make([]int, 0, len(results))
+
+	for _, r := range results {
+		totalElapsed += r.Elapsed
+		elapsedList = append(elapsedList, r.Elapsed)
+		if r.IsError {
+			bucket.ErrorCount++
+		}
+	}
+
+	sort.Ints(elapsedList)
+
+	bucket.Average = float64(totalElapsed) / float64(len(results))
+	bucket.NinetyFive = calculatePercentile(elapsedList, 0.95)
+	bucket.Throughput = float64(len(results)) / interval.Seconds()
+	bucket.ActiveTime = activeTime(bucketStart.Add(interval), results)
+
+	return bucket
+}
+
+// activeTime returns the milliseconds of the union of the sample running times clipped to the end of the bucket.
+func activeTime(to time.Time, results []*ResultRawData) int64 {
+	type span struct {
+		start, end time.Time
+	}
+
+	spans := make([]span, 0, len(results))
+	for _, r := range results {
+		end := r.Timestamp.Add(time.Duration(r.Elapsed) * time.Millisecond)
+		if end.After(to) {
+			end = to
+		}
+		spans = append(spans, span{start: r.Timestamp, end: end})
+	}
+
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i].start.Before(spans[j].start)
+	})
+
+	var total time.Duration
+	current := spans[0]
+	for _, s := range spans[1:] {
+		if !s.start.After(current.end) {
+			if s.end.After(current.end) {
+				current.end = s.end
+			}
+			continue
+		}
+		total += current.end.Sub(current.start)
+		current = s
+	}
+	total += current.end.Sub(current.start)
+
+	return total.Milliseconds()
 }
@@ -0,0 +1,152 @@
+package load
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareStatistics(t *testing.T) {
+	base := []*LoadTestStatistics{
+		{Label: "home", Average: 100, NinetyPercent: 200, NinetyFive: 250, NinetyNine: 300, ErrorPercent: 0, Throughput: 100},
+		{Label: "login", Average: 100, NinetyPercent: 200, NinetyFive: 250, NinetyNine: 300, ErrorPercent: 1, Throughput: 100},
+		{Label: "removed", Average: 100},
+		{Label: "cold", Average: 0, NinetyPercent: 0, NinetyFive: 0, NinetyNine: 0, Throughput: 0},
+	}
+	target := []*LoadTestStatistics{
+		{Label: "home", Average: 105, NinetyPercent: 210, NinetyFive: 260, NinetyNine: 320, ErrorPercent: 0.5, Throughput: 95},
+		{Label: "login", Average: 100, NinetyPercent: 200, NinetyFive: 300, NinetyNine: 300, ErrorPercent: 3, Throughput: 80},
+		{Label: "added", Average: 100},
+		{Label: "cold", Average: 30, NinetyPercent: 0, NinetyFive: 0, NinetyNine: 0, Throughput: 10},
+	}
+	thresholds := CompareThresholds{LatencyThreshold: 10, ErrorPercentThreshold: 1, ThroughputThreshold: 10}
+
+	comparisons := compareStatistics(base, target, thresholds)
+	require.Len(t, comparisons, 5)
+
+	byLabel := make(map[string]LabelComparison)
+	for _, c := range comparisons {
+		byLabel[c.Label] = c
+	}
+
+	home := byLabel["home"]
+	require.False(t, home.Regressed)
+	require.Len(t, home.Deltas, 6)
+	require.Equal(t, "average", home.Deltas[0].Metric)
+	require.InDelta(t, 5, home.Deltas[0].DeltaPercent, 0.0001)
+
+	login := byLabel["login"]
+	require.True(t, login.Regressed)
+	regressed := make(map[string]bool)
+	for _, d := range login.Deltas {
+		regressed[d.Metric] = d.Regressed
+	}
+	require.Equal(t, map[string]bool{
+		"average":       false,
+		"ninetyPercent": false,
+		"ninetyFive":    true,
+		"ninetyNine":    false,
+		"errorPercent":  true,
+		"throughput":    true,
+	}, regressed)
+
+	// the increase from zero is regressed even though its percent is left 0
+	cold := byLabel["cold"]
+	require.True(t, cold.Regressed)
+	require.True(t, cold.Deltas[0].FromZero)
+	require.True(t, cold.Deltas[0].Regressed)
+	require.Zero(t, cold.Deltas[0].DeltaPercent)
+	require.False(t, cold.Deltas[1].FromZero)
+	require.False(t, cold.Deltas[1].Regressed)
+	require.True(t, cold.Deltas[5].FromZero)
+	require.False(t, cold.Deltas[5].Regressed)
+
+	require.Nil(t, byLabel["removed"].Target)
+	require.Empty(t, byLabel["removed"].Deltas)
+	require.Equal(t, "removed", byLabel["removed"].Change)
+	require.True(t, byLabel["removed"].Regressed)
+	require.Nil(t, byLabel["added"].Base)
+	require.Equal(t, "added", byLabel["added"].Change)
+	require.False(t, byLabel["added"].Regressed)
+}
+
+func TestTimeSeries(t *testing.T) {
+	base := time.Date(2024, 7, 10, 13, 0, 0, 0, time.UTC)
+	at := func(ms int) time.Time { return base.Add(time.Duration(ms) * time.Millisecond) }
+
+	summaries := []ResultSummary{
+		{
+			Label: "home",
+			Results: []*ResultRawData{
+				{Timestamp: at(100), Elapsed: 200},
+				{Timestamp: at(200), Elapsed: 400, IsError: true},
+				{Timestamp: at(900), Elapsed: 300},
+				{Timestamp: at(3500), Elapsed: 100},
+			},
+		},
+	}
+
+	series, err := timeSeries(summaries, time.Second)
+	require.NoError(t, err)
+	require.Len(t, series, 1)
+	require.Equal(t, "1s", series[0].Interval)
+
+	buckets := series[0].Buckets
+	require.Len(t, buckets, 4)
+
+	require.Equal(t, base, buckets[0].Timestamp)
+	require.Equal(t, 3, buckets[0].RequestCount)
+	require.Equal(t, 1, buckets[0].ErrorCount)
+	require.InDelta(t, 300, buckets[0].Average, 0.0001)
+	require.Equal(t, float64(400), buckets[0].NinetyFive)
+	require.Equal(t, float64(3), buckets[0].Throughput)
+	// 100~600ms and 900~1000ms (clipped)
+	require.Equal(t, int64(600), buckets[0].ActiveTime)
+
+	require.Equal(t, 0, buckets[1].RequestCount)
+	require.Equal(t, 0, buckets[2].RequestCount)
+	require.Equal(t, 1, buckets[3].RequestCount)
+	require.Equal(t, int64(100), buckets[3].ActiveTime)
+
+	_, err = timeSeries(summaries, 0)
+	require.Error(t, err)
+}
+
+func TestTimeSeriesOutlier(t *testing.T) {
+	base := time.Date(2024, 7, 10, 13, 0, 0, 0, time.UTC)
+
+	// one broken timestamp years apart must not allocate a bucket per second between them
+	summaries := []ResultSummary{
+		{Label: "home", Results: []*ResultRawData{
+			{Timestamp: base, Elapsed: 100},
+			{Timestamp: base.Add(500 * time.Millisecond), Elapsed: 200},
+			{Timestamp: base.AddDate(10, 0, 0), Elapsed: 300},
+		}},
+		{Label: "login", Results: []*ResultRawData{
+			{Timestamp: base.Add(time.Second), Elapsed: 100},
+		}},
+	}
+
+	series, err := timeSeries(summaries, time.Second)
+	require.NoError(t, err)
+	require.Len(t, series, 2)
+
+	require.LessOrEqual(t, len(series[0].Buckets), maxTimeSeriesBuckets)
+	require.Equal(t, series[0].Interval, series[1].Interval)
+
+	interval, err := time.ParseDuration(series[0].Interval)
+	require.NoError(t, err)
+	require.Zero(t, interval%time.Second)
+
+	count := 0
+	for _, b := range series[0].Buckets {
+		count += b.RequestCount
+	}
+	require.Equal(t, 3, count)
+
+	// the results within the maximum buckets keep the interval
+	series, err = timeSeries(summaries[1:], time.Second)
+	require.NoError(t, err)
+	require.Equal(t, "1s", series[0].Interval)
+}
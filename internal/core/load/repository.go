@@ -3,7 +3,9 @@ package load
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/cloud-barista/cm-ant/internal/core/common/constant"
 	"gorm.io/gorm"
 )
 
@@ -144,7 +146,7 @@ func (r *LoadRepository) GetOrInsertLoadGeneratorInstallInfoTx(ctx context.Conte
 
 		err := d.
 			Preload("LoadGeneratorServers").
-			Where("install_location = ? AND status = ?", param.InstallLocation, "installed").
+			Where("install_location = ? AND COALESCE(region_name, '') = ? AND status = ?", param.InstallLocation, param.RegionName, "installed").
 			First(&existing).
 			Error
 
@@ -169,6 +171,29 @@ func (r *LoadRepository) GetOrInsertLoadGeneratorInstallInfoTx(ctx context.Conte
 	return nil
 }
 
+func (r *LoadRepository) InsertLoadGeneratorInstallInfoTx(ctx context.Context, param *LoadGeneratorInstallInfo) error {
+	return r.execInTransaction(ctx, func(d *gorm.DB) error {
+		return d.Create(param).Error
+	})
+}
+
+// GetInstalledLoadGeneratorInstallInfoByHostTx returns the installed load generator of the location which has the server on the host.
+func (r *LoadRepository) GetInstalledLoadGeneratorInstallInfoByHostTx(ctx context.Context, installLocation constant.InstallLocation, publicIp, sshPort string) (LoadGeneratorInstallInfo, error) {
+	var loadGeneratorInstallInfo LoadGeneratorInstallInfo
+
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		return d.
+			Preload("LoadGeneratorServers").
+			Joins("JOIN load_generator_servers ON load_generator_servers.load_generator_install_info_id = load_generator_install_infos.id").
+			Where("load_generator_install_infos.install_location = ? AND load_generator_install_infos.status = ?", installLocation, "installed").
+			Where("load_generator_servers.public_ip = ? AND load_generator_servers.ssh_port = ? AND load_generator_servers.deleted_at IS NULL", publicIp, sshPort).
+			First(&loadGeneratorInstallInfo).
+			Error
+	})
+
+	return loadGeneratorInstallInfo, err
+}
+
 func (r *LoadRepository) DeleteLoadGeneratorServerTx(ctx context.Context, deleteIds []uint) error {
 	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
 		return d.
@@ -222,6 +247,115 @@ func (r *LoadRepository) GetValidLoadGeneratorInstallInfoByIdTx(ctx context.Cont
 	return loadGeneratorInstallInfo, err
 }
 
+// GetRunningLoadGeneratorInstallInfosTx returns the installed load generators of the install locations whose vms are not suspended.
+func (r *LoadRepository) GetRunningLoadGeneratorInstallInfosTx(ctx context.Context, installLocations []constant.InstallLocation) ([]LoadGeneratorInstallInfo, error) {
+	var loadGeneratorInstallInfos []LoadGeneratorInstallInfo
+
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		return d.
+			Preload("LoadGeneratorServers").
+			Where("install_location IN (?) AND status = ? AND COALESCE(lifecycle_status, '') <> ?", installLocations, "installed", generatorSuspended).
+			Find(&loadGeneratorInstallInfos).
+			Error
+	})
+
+	return loadGeneratorInstallInfos, err
+}
+
+func (r *LoadRepository) UpdateLoadGeneratorInstallTypeTx(ctx context.Context, loadGeneratorInstallInfoId uint, installType string) error {
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		return d.
+			Model(&LoadGeneratorInstallInfo{}).
+			Where("id = ?", loadGeneratorInstallInfoId).
+			Update("install_type", installType).
+			Error
+	})
+
+	return err
+}
+
+func (r *LoadRepository) UpdateLoadGeneratorLastUsedAtTx(ctx context.Context, loadGeneratorInstallInfoId uint, lastUsedAt time.Time) error {
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		return d.
+			Model(&LoadGeneratorInstallInfo{}).
+			Where("id = ?", loadGeneratorInstallInfoId).
+			Update("last_used_at", lastUsedAt).
+			Error
+	})
+
+	return err
+}
+
+// UpdateLoadGeneratorHealthTx saves the health of the load generator and the status of its servers.
+// The columns are selected, so the health message is cleared when the load generator is healthy.
+func (r *LoadRepository) UpdateLoadGeneratorHealthTx(ctx context.Context, param *LoadGeneratorInstallInfo) error {
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		err := d.
+			Model(param).
+			Select("health_message", "health_checked_at").
+			Updates(param).
+			Error
+		if err != nil {
+			return err
+		}
+
+		for i := range param.LoadGeneratorServers {
+			s := &param.LoadGeneratorServers[i]
+			if err := d.Model(s).Update("status", s.Status).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+func (r *LoadRepository) InsertLoadGeneratorKeyTx(ctx context.Context, param *LoadGeneratorKey) error {
+	return r.execInTransaction(ctx, func(d *gorm.DB) error {
+		return d.Create(param).Error
+	})
+}
+
+func (r *LoadRepository) UpdateLoadGeneratorKeyTx(ctx context.Context, param *LoadGeneratorKey) error {
+	return r.execInTransaction(ctx, func(d *gorm.DB) error {
+		return d.Save(param).Error
+	})
+}
+
+// GetActiveLoadGeneratorKeyTx returns the active key of the load generator.
+func (r *LoadRepository) GetActiveLoadGeneratorKeyTx(ctx context.Context, loadGeneratorInstallInfoId uint) (LoadGeneratorKey, error) {
+	var key LoadGeneratorKey
+
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		return d.
+			Where("load_generator_install_info_id = ? AND status = ?", loadGeneratorInstallInfoId, generatorKeyActive).
+			Order("id DESC").
+			First(&key).
+			Error
+	})
+
+	return key, err
+}
+
+// ActivateLoadGeneratorKeyTx activates the key and revokes the other active keys of its load generator.
+func (r *LoadRepository) ActivateLoadGeneratorKeyTx(ctx context.Context, param *LoadGeneratorKey, revokedAt time.Time) error {
+	return r.execInTransaction(ctx, func(d *gorm.DB) error {
+		err := d.
+			Model(&LoadGeneratorKey{}).
+			Where("load_generator_install_info_id = ? AND status = ? AND id <> ?", param.LoadGeneratorInstallInfoId, generatorKeyActive, param.ID).
+			Updates(map[string]interface{}{"status": generatorKeyRevoked, "revoked_at": revokedAt}).
+			Error
+		if err != nil {
+			return err
+		}
+
+		param.Status = generatorKeyActive
+		return d.Save(param).Error
+	})
+}
+
 func (r *LoadRepository) GetPagingLoadGeneratorInstallInfosTx(ctx context.Context, param GetAllLoadGeneratorInstallInfoParam) ([]LoadGeneratorInstallInfo, int64, error) {
 	var loadGeneratorInstallInfos []LoadGeneratorInstallInfo
 	var totalRows int64
@@ -312,6 +446,76 @@ func (r *LoadRepository) UpdateLoadTestExecutionStateTx(ctx context.Context, par
 	return err
 }
 
+// SaveLoadTestFileTransfersTx saves the transfer status of the result files of the load test.
+// The status of the file from the same host is updated in place, so the fetch after the restart of the server keeps one status per file.
+func (r *LoadRepository) SaveLoadTestFileTransfersTx(ctx context.Context, loadTestKey string, transfers []*LoadTestFileTransfer) error {
+	return r.execInTransaction(ctx, func(d *gorm.DB) error {
+		var state LoadTestExecutionState
+		err := d.Select("id").Where("load_test_key = ?", loadTestKey).First(&state).Error
+		if err != nil {
+			return err
+		}
+
+		for _, t := range transfers {
+			t.LoadTestExecutionStateId = state.ID
+
+			if t.ID == 0 {
+				var existing LoadTestFileTransfer
+				err := d.
+					Where("load_test_execution_state_id = ? AND host = ? AND file_name = ?", state.ID, t.Host, t.FileName).
+					First(&existing).
+					Error
+
+				if err == nil {
+					t.ID = existing.ID
+					t.CreatedAt = existing.CreatedAt
+				} else if err != gorm.ErrRecordNotFound {
+					return err
+				}
+			}
+
+			if err := d.Save(t).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *LoadRepository) UpdateLoadTestExecutionStatusTx(ctx context.Context, loadTestKey string, status constant.ExecutionStatus) error {
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		return d.
+			Model(&LoadTestExecutionState{}).
+			Where("load_test_key = ?", loadTestKey).
+			Update("execution_status", status).
+			Error
+	})
+
+	return err
+}
+
+// UpdateLoadTestQueuePositionsTx marks the load tests queued at the positions of the given order.
+func (r *LoadRepository) UpdateLoadTestQueuePositionsTx(ctx context.Context, loadTestKeys []string) error {
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		for i, key := range loadTestKeys {
+			err := d.
+				Model(&LoadTestExecutionState{}).
+				Where("load_test_key = ?", key).
+				Updates(map[string]interface{}{"execution_status": constant.Queued, "queue_position": i + 1}).
+				Error
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return err
+}
+
 func (r *LoadRepository) UpdateLoadTestExecutionInfoDuration(ctx context.Context, loadTestKey, compileDuration, executionDuration string) error {
 	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
 		err := d.
@@ -338,6 +542,8 @@ func (r *LoadRepository) GetPagingLoadTestExecutionStateTx(ctx context.Context,
 
 	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
 		q := d.Model(&LoadTestExecutionState{}).
+			Preload("SloResults").
+			Preload("FileTransfers").
 			Preload("LoadGeneratorInstallInfo").
 			Preload("LoadGeneratorInstallInfo.LoadGeneratorServers").
 			Order("load_test_execution_states.created_at desc")
@@ -371,6 +577,8 @@ func (r *LoadRepository) GetLoadTestExecutionStateTx(ctx context.Context, param
 
 	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
 		return d.Model(&loadTestExecutionState).
+			Preload("SloResults").
+			Preload("FileTransfers").
 			Preload("LoadGeneratorInstallInfo").
 			Preload("LoadGeneratorInstallInfo.LoadGeneratorServers").
 			First(&loadTestExecutionState, "load_test_execution_states.load_test_key = ?", param.LoadTestKey).
@@ -387,6 +595,8 @@ func (r *LoadRepository) GetPagingLoadTestExecutionHistoryTx(ctx context.Context
 	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
 		q := d.Model(&LoadTestExecutionInfo{}).
 			Preload("LoadTestExecutionState").
+			Preload("LoadTestExecutionState.SloResults").
+			Preload("LoadTestExecutionState.FileTransfers").
 			Preload("LoadTestExecutionHttpInfos").
 			Preload("LoadGeneratorInstallInfo").
 			Preload("LoadGeneratorInstallInfo.LoadGeneratorServers").
@@ -414,6 +624,8 @@ func (r *LoadRepository) GetLoadTestExecutionInfoTx(ctx context.Context, param G
 	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
 		return d.Model(&loadTestExecutionInfo).
 			Preload("LoadTestExecutionState").
+			Preload("LoadTestExecutionState.SloResults").
+			Preload("LoadTestExecutionState.FileTransfers").
 			Preload("LoadTestExecutionHttpInfos").
 			Preload("LoadGeneratorInstallInfo").
 			Preload("LoadGeneratorInstallInfo.LoadGeneratorServers").
@@ -423,3 +635,500 @@ func (r *LoadRepository) GetLoadTestExecutionInfoTx(ctx context.Context, param G
 
 	return loadTestExecutionInfo, err
 }
+
+// GetActiveLoadTestExecutionInfosTx returns the load tests whose execution state is not finished yet.
+func (r *LoadRepository) GetActiveLoadTestExecutionInfosTx(ctx context.Context) ([]LoadTestExecutionInfo, error) {
+	var loadTestExecutionInfos []LoadTestExecutionInfo
+
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		activeKeys := d.Model(&LoadTestExecutionState{}).
+			Select("load_test_key").
+			Where("execution_status IN (?)", activeExecutionStatuses)
+
+		return d.Model(&LoadTestExecutionInfo{}).
+			Preload("LoadTestExecutionState").
+			Preload("LoadGeneratorInstallInfo").
+			Preload("LoadGeneratorInstallInfo.LoadGeneratorServers").
+			Where("load_test_key IN (?)", activeKeys).
+			Order("load_test_execution_infos.created_at asc").
+			Find(&loadTestExecutionInfos).
+			Error
+	})
+
+	return loadTestExecutionInfos, err
+}
+
+// CountRunningLoadTestExecutionStateTx counts the load tests which are using the load generator, not waiting in the queue.
+func (r *LoadRepository) CountRunningLoadTestExecutionStateTx(ctx context.Context, loadGeneratorInstallInfoId uint) (int64, error) {
+	var count int64
+
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		return d.Model(&LoadTestExecutionState{}).
+			Where(
+				"load_generator_install_info_id = ? AND execution_status IN (?)",
+				loadGeneratorInstallInfoId, runningExecutionStatuses,
+			).
+			Count(&count).
+			Error
+	})
+
+	return count, err
+}
+
+func (r *LoadRepository) CountActiveLoadTestExecutionStateTx(ctx context.Context, loadGeneratorInstallInfoId uint) (int64, error) {
+	var count int64
+
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		return d.Model(&LoadTestExecutionState{}).
+			Where(
+				"load_generator_install_info_id = ? AND execution_status IN (?)",
+				loadGeneratorInstallInfoId, activeExecutionStatuses,
+			).
+			Count(&count).
+			Error
+	})
+
+	return count, err
+}
+
+func (r *LoadRepository) InsertLoadTestScheduleTx(ctx context.Context, param *LoadTestSchedule) error {
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		return d.Create(param).Error
+	})
+
+	return err
+}
+
+func (r *LoadRepository) UpdateLoadTestScheduleTx(ctx context.Context, param *LoadTestSchedule) error {
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		return d.
+			Model(param).
+			Save(param).
+			Error
+	})
+
+	return err
+}
+
+// UpdateLoadTestScheduleRunTx records the run of the schedule without overwriting the changes made while it ran.
+// The next run time is moved only when the cron expression and the enabled flag are not changed meanwhile,
+// and gorm.ErrRecordNotFound is returned when the schedule is deleted.
+func (r *LoadRepository) UpdateLoadTestScheduleRunTx(ctx context.Context, schedule *LoadTestSchedule, cronExpression string) error {
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		next := map[string]interface{}{
+			"next_run_at": schedule.NextRunAt,
+		}
+		if !schedule.Enabled {
+			next["enabled"] = false
+		}
+
+		err := d.
+			Model(&LoadTestSchedule{}).
+			Where("id = ? AND cron_expression = ? AND enabled = ?", schedule.ID, cronExpression, true).
+			UpdateColumns(next).
+			Error
+		if err != nil {
+			return err
+		}
+
+		res := d.
+			Model(&LoadTestSchedule{}).
+			Where("id = ?", schedule.ID).
+			UpdateColumns(map[string]interface{}{
+				"last_run_at":        schedule.LastRunAt,
+				"last_load_test_key": schedule.LastLoadTestKey,
+				"last_run_message":   schedule.LastRunMessage,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+func (r *LoadRepository) DeleteLoadTestScheduleTx(ctx context.Context, id uint) error {
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		return d.
+			Delete(&LoadTestSchedule{}, id).
+			Error
+	})
+
+	return err
+}
+
+func (r *LoadRepository) GetLoadTestScheduleTx(ctx context.Context, id uint) (LoadTestSchedule, error) {
+	var loadTestSchedule LoadTestSchedule
+
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		return d.
+			First(&loadTestSchedule, "id = ?", id).
+			Error
+	})
+
+	return loadTestSchedule, err
+}
+
+func (r *LoadRepository) GetPagingLoadTestSchedulesTx(ctx context.Context, param GetAllLoadTestSchedulesParam) ([]LoadTestSchedule, int64, error) {
+	var loadTestSchedules []LoadTestSchedule
+	var totalRows int64
+
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		q := d.Model(&LoadTestSchedule{}).
+			Order("load_test_schedules.created_at desc")
+
+		if err := q.Count(&totalRows).Error; err != nil {
+			return err
+		}
+
+		offset := (param.Page - 1) * param.Size
+		return q.Offset(offset).
+			Limit(param.Size).
+			Find(&loadTestSchedules).Error
+	})
+
+	return loadTestSchedules, totalRows, err
+}
+
+func (r *LoadRepository) GetDueLoadTestSchedulesTx(ctx context.Context, now time.Time) ([]LoadTestSchedule, error) {
+	var loadTestSchedules []LoadTestSchedule
+
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		return d.
+			Where("enabled = ? AND next_run_at IS NOT NULL AND next_run_at <= ?", true, now).
+			Order("next_run_at asc").
+			Find(&loadTestSchedules).
+			Error
+	})
+
+	return loadTestSchedules, err
+}
+
+func (r *LoadRepository) InsertLoadTestCapacitySearchTx(ctx context.Context, param *LoadTestCapacitySearch) error {
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		return d.Create(param).Error
+	})
+
+	return err
+}
+
+// UpdateLoadTestCapacitySearchTx saves the capacity search without its steps, which are inserted one by one.
+func (r *LoadRepository) UpdateLoadTestCapacitySearchTx(ctx context.Context, param *LoadTestCapacitySearch) error {
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		return d.
+			Omit("Steps").
+			Save(param).
+			Error
+	})
+
+	return err
+}
+
+func (r *LoadRepository) InsertLoadTestCapacitySearchStepTx(ctx context.Context, param *LoadTestCapacitySearchStep) error {
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		return d.Create(param).Error
+	})
+
+	return err
+}
+
+func (r *LoadRepository) GetLoadTestCapacitySearchTx(ctx context.Context, id uint) (LoadTestCapacitySearch, error) {
+	var capacitySearch LoadTestCapacitySearch
+
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		return d.
+			Preload("Steps", func(db *gorm.DB) *gorm.DB {
+				return db.Order("step asc")
+			}).
+			First(&capacitySearch, "id = ?", id).
+			Error
+	})
+
+	return capacitySearch, err
+}
+
+func (r *LoadRepository) GetPagingLoadTestCapacitySearchesTx(ctx context.Context, param GetAllCapacitySearchesParam) ([]LoadTestCapacitySearch, int64, error) {
+	var capacitySearches []LoadTestCapacitySearch
+	var totalRows int64
+
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		q := d.Model(&LoadTestCapacitySearch{}).
+			Preload("Steps", func(db *gorm.DB) *gorm.DB {
+				return db.Order("step asc")
+			}).
+			Order("load_test_capacity_searches.created_at desc")
+
+		if err := q.Count(&totalRows).Error; err != nil {
+			return err
+		}
+
+		offset := (param.Page - 1) * param.Size
+		return q.Offset(offset).
+			Limit(param.Size).
+			Find(&capacitySearches).Error
+	})
+
+	return capacitySearches, totalRows, err
+}
+
+// FailRunningLoadTestCapacitySearchesTx fails the capacity searches which are still running with the message.
+func (r *LoadRepository) FailRunningLoadTestCapacitySearchesTx(ctx context.Context, message string, finishAt time.Time) (int64, error) {
+	var affected int64
+
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		res := d.
+			Model(&LoadTestCapacitySearch{}).
+			Where("status = ?", constant.OnRunning).
+			Updates(map[string]interface{}{"status": constant.Failed, "failure_message": message, "finish_at": finishAt})
+
+		affected = res.RowsAffected
+		return res.Error
+	})
+
+	return affected, err
+}
+
+func (r *LoadRepository) InsertMultiRegionLoadTestTx(ctx context.Context, param *MultiRegionLoadTest) error {
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		return d.Create(param).Error
+	})
+
+	return err
+}
+
+// UpdateMultiRegionLoadTestTx saves the multi region load test with its regions.
+func (r *LoadRepository) UpdateMultiRegionLoadTestTx(ctx context.Context, param *MultiRegionLoadTest) error {
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		return d.
+			Session(&gorm.Session{FullSaveAssociations: true}).
+			Save(param).
+			Error
+	})
+
+	return err
+}
+
+func (r *LoadRepository) GetMultiRegionLoadTestTx(ctx context.Context, id uint) (MultiRegionLoadTest, error) {
+	var multiRegionLoadTest MultiRegionLoadTest
+
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		return d.
+			Preload("Regions", func(db *gorm.DB) *gorm.DB {
+				return db.Order("id asc")
+			}).
+			First(&multiRegionLoadTest, "id = ?", id).
+			Error
+	})
+
+	return multiRegionLoadTest, err
+}
+
+func (r *LoadRepository) GetPagingMultiRegionLoadTestsTx(ctx context.Context, param GetAllMultiRegionLoadTestsParam) ([]MultiRegionLoadTest, int64, error) {
+	var multiRegionLoadTests []MultiRegionLoadTest
+	var totalRows int64
+
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		q := d.Model(&MultiRegionLoadTest{}).
+			Preload("Regions", func(db *gorm.DB) *gorm.DB {
+				return db.Order("id asc")
+			}).
+			Order("multi_region_load_tests.created_at desc")
+
+		if err := q.Count(&totalRows).Error; err != nil {
+			return err
+		}
+
+		offset := (param.Page - 1) * param.Size
+		return q.Offset(offset).
+			Limit(param.Size).
+			Find(&multiRegionLoadTests).Error
+	})
+
+	return multiRegionLoadTests, totalRows, err
+}
+
+// FailRunningMultiRegionLoadTestsTx fails the multi region load tests which are still running with the message.
+func (r *LoadRepository) FailRunningMultiRegionLoadTestsTx(ctx context.Context, message string, finishAt time.Time) (int64, error) {
+	var affected int64
+
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		res := d.
+			Model(&MultiRegionLoadTest{}).
+			Where("status = ?", constant.OnRunning).
+			Updates(map[string]interface{}{"status": constant.Failed, "failure_message": message, "finish_at": finishAt})
+
+		affected = res.RowsAffected
+		return res.Error
+	})
+
+	return affected, err
+}
+
+const resultRawDataBatchSize = 1000
+
+// SaveLoadTestResultRawDataTx replaces the stored result and metrics of the load test with the given rows.
+func (r *LoadRepository) SaveLoadTestResultRawDataTx(ctx context.Context, loadTestKey string, results []LoadTestResultRawData, metrics []LoadTestMetricsRawData) error {
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		if err := d.Where("load_test_key = ?", loadTestKey).Delete(&LoadTestResultRawData{}).Error; err != nil {
+			return err
+		}
+
+		if err := d.Where("load_test_key = ?", loadTestKey).Delete(&LoadTestMetricsRawData{}).Error; err != nil {
+			return err
+		}
+
+		if len(results) > 0 {
+			if err := d.CreateInBatches(results, resultRawDataBatchSize).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(metrics) > 0 {
+			if err := d.CreateInBatches(metrics, resultRawDataBatchSize).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+func (r *LoadRepository) InsertLoadGeneratorMetricsRawDataTx(ctx context.Context, metrics []LoadGeneratorMetricsRawData) error {
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		return d.Create(&metrics).Error
+	})
+
+	return err
+}
+
+func (r *LoadRepository) GetLoadGeneratorMetricsRawDataTx(ctx context.Context, loadTestKey string) ([]LoadGeneratorMetricsRawData, error) {
+	var metrics []LoadGeneratorMetricsRawData
+
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		return d.
+			Where("load_test_key = ?", loadTestKey).
+			Order("timestamp asc").
+			Find(&metrics).
+			Error
+	})
+
+	return metrics, err
+}
+
+// ScanLoadTestResultRawDataTx passes the raw results of the load test to the scan in batches in the order of the id,
+// so the result of the long load test is not read whole.
+// Only the columns are read when they are given.
+func (r *LoadRepository) ScanLoadTestResultRawDataTx(ctx context.Context, loadTestKey string, columns []string, scan func([]LoadTestResultRawData) error) error {
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		q := d.Where("load_test_key = ?", loadTestKey)
+		if len(columns) > 0 {
+			q = q.Select(append([]string{"id"}, columns...))
+		}
+
+		var results []LoadTestResultRawData
+		return q.
+			FindInBatches(&results, resultRawDataBatchSize, func(_ *gorm.DB, _ int) error {
+				return scan(results)
+			}).
+			Error
+	})
+
+	return err
+}
+
+func (r *LoadRepository) GetLoadTestMetricsRawDataTx(ctx context.Context, loadTestKey string) ([]LoadTestMetricsRawData, error) {
+	var metrics []LoadTestMetricsRawData
+
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		return d.
+			Where("load_test_key = ?", loadTestKey).
+			Order("id").
+			Find(&metrics).
+			Error
+	})
+
+	return metrics, err
+}
+
+func (r *LoadRepository) InsertLoadTestPlanTx(ctx context.Context, param *LoadTestPlan) error {
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		return d.Create(param).Error
+	})
+
+	return err
+}
+
+func (r *LoadRepository) GetLoadTestPlanTx(ctx context.Context, id uint) (LoadTestPlan, error) {
+	var loadTestPlan LoadTestPlan
+
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		return d.
+			Preload("DataFiles").
+			First(&loadTestPlan, "id = ?", id).
+			Error
+	})
+
+	return loadTestPlan, err
+}
+
+// GetPagingLoadTestPlansTx returns the stored test plans without the content of the plan and data files.
+func (r *LoadRepository) GetPagingLoadTestPlansTx(ctx context.Context, param GetAllLoadTestPlansParam) ([]LoadTestPlan, int64, error) {
+	var loadTestPlans []LoadTestPlan
+	var totalRows int64
+
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		q := d.Model(&LoadTestPlan{}).
+			Omit("content").
+			Order("load_test_plans.created_at desc")
+
+		if err := q.Count(&totalRows).Error; err != nil {
+			return err
+		}
+
+		offset := (param.Page - 1) * param.Size
+		return q.Offset(offset).
+			Limit(param.Size).
+			Preload("DataFiles", func(db *gorm.DB) *gorm.DB {
+				return db.Omit("content")
+			}).
+			Find(&loadTestPlans).Error
+	})
+
+	return loadTestPlans, totalRows, err
+}
+
+func (r *LoadRepository) DeleteLoadTestPlanTx(ctx context.Context, id uint) error {
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		if err := d.Where("load_test_plan_id = ?", id).Delete(&LoadTestPlanDataFile{}).Error; err != nil {
+			return err
+		}
+
+		return d.
+			Delete(&LoadTestPlan{}, id).
+			Error
+	})
+
+	return err
+}
+
+// GetLoadTestEngineTx returns the engine recorded on the execution info of the load test.
+func (r *LoadRepository) GetLoadTestEngineTx(ctx context.Context, loadTestKey string) (constant.LoadGeneratorType, error) {
+	var loadTestExecutionInfo LoadTestExecutionInfo
+
+	err := r.execInTransaction(ctx, func(d *gorm.DB) error {
+		return d.
+			Select("id", "engine").
+			First(&loadTestExecutionInfo, "load_test_key = ?", loadTestKey).
+			Error
+	})
+
+	return loadTestExecutionInfo.Engine, err
+}
@@ -0,0 +1,452 @@
+package load
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/cloud-barista/cm-ant/internal/core/common/constant"
+	"github.com/cloud-barista/cm-ant/internal/utils"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	transferFetching = "fetching"
+	transferFailed   = "failed"
+	transferDone     = "done"
+
+	localFetchHost = "localhost"
+
+	fetchRetryAttempts  = 3
+	fetchRetryBaseDelay = time.Second
+)
+
+type fetchDataParam struct {
+	LoadTestDone    <-chan bool
+	FetchDone       chan struct{}
+	LoadTestKey     string
+	LoadGeneratorId uint
+	InstallLocation constant.InstallLocation
+	InstallPath     string
+	PrivateKeyName  string
+	Master          LoadGeneratorServer
+	AgentInstalled  bool
+	fetchMx         sync.Mutex
+	fetchRunning    bool
+	Workers         []LoadGeneratorServer
+	Engine          LoadGeneratorEngine
+
+	// privateKey reaches the servers in the current fetch, which is read again by each fetch
+	// because the key of the load generator may be rotated while the load test runs.
+	privateKey []byte
+
+	// transfers are the transfer status of the result files by the host and the file name,
+	// whose bytes are the offset where the next fetch starts.
+	transfers map[string]*LoadTestFileTransfer
+}
+
+// newFetchDataParam prepares the fetch of the result files from the load generator.
+// The fetch ends after the last fetch once the load test done channel receives, and closes the fetch done channel.
+func newFetchDataParam(loadTestKey string, agentInstalled bool, loadGeneratorInstallInfo *LoadGeneratorInstallInfo, engine LoadGeneratorEngine, loadTestDone <-chan bool) *fetchDataParam {
+	var master LoadGeneratorServer
+	var workers []LoadGeneratorServer
+	for _, s := range loadGeneratorInstallInfo.LoadGeneratorServers {
+		if s.IsMaster {
+			master = s
+		} else if s.IsCluster {
+			workers = append(workers, s)
+		}
+	}
+
+	return &fetchDataParam{
+		LoadTestDone:    loadTestDone,
+		FetchDone:       make(chan struct{}),
+		LoadTestKey:     loadTestKey,
+		LoadGeneratorId: loadGeneratorInstallInfo.ID,
+		InstallLocation: loadGeneratorInstallInfo.InstallLocation,
+		InstallPath:     loadGeneratorInstallInfo.InstallPath,
+		PrivateKeyName:  loadGeneratorInstallInfo.PrivateKeyName,
+		Master:          master,
+		AgentInstalled:  agentInstalled,
+		Workers:         workers,
+		Engine:          engine,
+		transfers:       make(map[string]*LoadTestFileTransfer),
+	}
+}
+
+func (f *fetchDataParam) setFetchRunning(running bool) {
+	f.fetchMx.Lock()
+	defer f.fetchMx.Unlock()
+	f.fetchRunning = running
+}
+
+func (f *fetchDataParam) isRunning() bool {
+	f.fetchMx.Lock()
+	defer f.fetchMx.Unlock()
+	return f.fetchRunning
+}
+
+func (f *fetchDataParam) transferOf(host, fileName string) *LoadTestFileTransfer {
+	key := host + "/" + fileName
+	t, ok := f.transfers[key]
+	if !ok {
+		t = &LoadTestFileTransfer{Host: host, FileName: fileName}
+		f.transfers[key] = t
+	}
+	return t
+}
+
+const (
+	defaultFetchIntervalSec = 30
+)
+
+func (l *LoadService) fetchData(f *fetchDataParam) {
+	ticker := time.NewTicker(defaultFetchIntervalSec * time.Second)
+	defer ticker.Stop()
+
+	done := f.LoadTestDone
+	if f.FetchDone != nil {
+		defer close(f.FetchDone)
+	}
+
+	for {
+		select {
+		case <-ticker.C:
+			if !f.isRunning() {
+				f.setFetchRunning(true)
+				if err := l.fetchFiles(f, false); err != nil {
+					utils.LogWarnf("Error fetching result of load test %s: %v", f.LoadTestKey, err)
+				}
+				f.setFetchRunning(false)
+			}
+		case <-done:
+			retry := 3
+			for retry > 0 {
+				if !f.isRunning() {
+					if err := l.fetchFiles(f, true); err != nil {
+						utils.LogWarnf("Error fetching result of load test %s: %v", f.LoadTestKey, err)
+					}
+					break
+				}
+				time.Sleep(time.Duration(1<<4-retry) * time.Second)
+				retry--
+			}
+
+			if err := l.ingestLoadTestResult(f.Engine, f.LoadTestKey); err != nil {
+				utils.LogErrorf("Error ingesting result of load test %s: %v", f.LoadTestKey, err)
+			}
+
+			return
+		}
+	}
+}
+
+// fetchFiles fetches the bytes of the result files written since the last fetch and saves the transfer status of each file.
+// The remote files are read over sftp, and the transfers are marked done by the final fetch.
+func (l *LoadService) fetchFiles(f *fetchDataParam, final bool) error {
+	resultFolderPath := utils.JoinRootPathWith("/result/" + f.LoadTestKey)
+
+	err := utils.CreateFolderIfNotExist(utils.JoinRootPathWith("/result"))
+	if err != nil {
+		return err
+	}
+
+	err = utils.CreateFolderIfNotExist(resultFolderPath)
+	if err != nil {
+		return err
+	}
+
+	resultsPrefix := []string{""}
+	if f.AgentInstalled {
+		resultsPrefix = append(resultsPrefix, "_cpu", "_disk", "_memory", "_network")
+	}
+
+	if f.InstallLocation != constant.Local {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		f.privateKey, err = l.loadGeneratorPrivateKey(ctx, f.LoadGeneratorId, f.PrivateKeyName)
+		cancel()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	var errs []error
+	for _, prefix := range resultsPrefix {
+		fileName := fmt.Sprintf("%s%s_result.csv", f.LoadTestKey, prefix)
+		fromFilePath := fmt.Sprintf("%s/result/%s", f.InstallPath, fileName)
+		toFilePath := fmt.Sprintf("%s/%s", resultFolderPath, fileName)
+
+		if f.InstallLocation == constant.Local {
+			errs = append(errs, f.fetchFile(localFileSource{}, localFetchHost, fromFilePath, toFilePath, final))
+			continue
+		}
+
+		// in distributed mode the sample results are collected on the master,
+		// but the listeners such as perfmon write their files on every worker.
+		if prefix != "" && len(f.Workers) > 0 {
+			errs = append(errs, f.fetchAndMergeWorkerFiles(fromFilePath, toFilePath, resultFolderPath, fileName, final))
+			continue
+		}
+
+		errs = append(errs, f.fetchRemoteFile(f.Master, fromFilePath, toFilePath, final))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	transfers := make([]*LoadTestFileTransfer, 0, len(f.transfers))
+	for _, t := range f.transfers {
+		transfers = append(transfers, t)
+	}
+
+	if err := l.loadRepo.SaveLoadTestFileTransfersTx(ctx, f.LoadTestKey, transfers); err != nil {
+		utils.LogErrorf("Error saving file transfers of load test %s: %v", f.LoadTestKey, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// fetchRemoteFile fetches the result file from the server over sftp.
+func (f *fetchDataParam) fetchRemoteFile(server LoadGeneratorServer, fromFilePath, toFilePath string, final bool) error {
+	port := server.SshPort
+	if port == "" {
+		port = defaultSshPort
+	}
+	host := fmt.Sprintf("%s:%s", server.PublicIp, port)
+
+	var sshClient *ssh.Client
+	var client *sftp.Client
+	err := withBackoff(fetchRetryAttempts, fetchRetryBaseDelay, func() error {
+		var err error
+		sshClient, err = sshClientOf(server, f.privateKey)
+		if err != nil {
+			return err
+		}
+
+		client, err = sftp.NewClient(sshClient)
+		if err != nil {
+			sshClient.Close()
+		}
+		return err
+	})
+
+	if err != nil {
+		t := f.transferOf(host, path.Base(fromFilePath))
+		t.Status = transferFailed
+		t.Message = fmt.Sprintf("failed to connect by ssh; %s", err)
+		return fmt.Errorf("failed to connect to %s; %w", host, err)
+	}
+	defer sshClient.Close()
+	defer client.Close()
+
+	return f.fetchFile(sftpFileSource{client}, host, fromFilePath, toFilePath, final)
+}
+
+// fetchFile appends the new bytes of the file from the source to the local file with the retries,
+// and updates the transfer status of the file.
+func (f *fetchDataParam) fetchFile(src resultFileSource, host, fromFilePath, toFilePath string, final bool) error {
+	t := f.transferOf(host, path.Base(fromFilePath))
+
+	err := withBackoff(fetchRetryAttempts, fetchRetryBaseDelay, func() error {
+		offset, err := appendNewBytes(src, fromFilePath, toFilePath, t.Bytes, final)
+		t.Bytes = offset
+		return err
+	})
+
+	switch {
+	case errors.Is(err, os.ErrNotExist) && !final:
+		// the engine has not written the file yet
+		t.Status = transferFetching
+		t.Message = "waiting for the file"
+		return nil
+	case err != nil:
+		t.Status = transferFailed
+		t.Message = err.Error()
+		return fmt.Errorf("failed to fetch %s from %s; %w", fromFilePath, host, err)
+	}
+
+	now := time.Now()
+	t.TransferredAt = &now
+	t.Message = ""
+	t.Status = transferFetching
+	if final {
+		t.Status = transferDone
+	}
+
+	return nil
+}
+
+// fetchAndMergeWorkerFiles fetches the same result file from every worker into its own folder
+// and merges them into a single csv file so that the result parser can read it as usual.
+// The files are merged only after the final fetch succeeded on every worker, so the merged file is never partial.
+func (f *fetchDataParam) fetchAndMergeWorkerFiles(fromFilePath, toFilePath, resultFolderPath, fileName string, final bool) error {
+	workerFolderPath := fmt.Sprintf("%s/workers", resultFolderPath)
+	err := utils.CreateFolderIfNotExist(workerFolderPath)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	var fetchedFiles []string
+	for _, w := range f.Workers {
+		folderPath := fmt.Sprintf("%s/%s", workerFolderPath, w.VmId)
+		err := utils.CreateFolderIfNotExist(folderPath)
+		if err != nil {
+			return err
+		}
+
+		workerFilePath := fmt.Sprintf("%s/%s", folderPath, fileName)
+		err = f.fetchRemoteFile(w, fromFilePath, workerFilePath, final)
+		if err != nil {
+			utils.LogErrorf("Error fetching %s from worker %s: %v", fileName, w.VmId, err)
+			errs = append(errs, err)
+			continue
+		}
+
+		if utils.ExistCheck(workerFilePath) {
+			fetchedFiles = append(fetchedFiles, workerFilePath)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s is not merged, because it is not fetched from every worker; %w", fileName, errors.Join(errs...))
+	}
+
+	if !final {
+		return nil
+	}
+
+	if len(fetchedFiles) == 0 {
+		return fmt.Errorf("%s is not fetched from any worker", fileName)
+	}
+
+	return utils.MergeCSVFiles(toFilePath, fetchedFiles)
+}
+
+// resultFileSource is the file system of the load generator which the result files are fetched from.
+type resultFileSource interface {
+	Stat(path string) (os.FileInfo, error)
+	Open(path string) (io.ReadSeekCloser, error)
+}
+
+type localFileSource struct{}
+
+func (localFileSource) Stat(path string) (os.FileInfo, error) {
+	return os.Stat(path)
+}
+
+func (localFileSource) Open(path string) (io.ReadSeekCloser, error) {
+	return os.Open(path)
+}
+
+type sftpFileSource struct {
+	client *sftp.Client
+}
+
+func (s sftpFileSource) Stat(path string) (os.FileInfo, error) {
+	return s.client.Stat(path)
+}
+
+func (s sftpFileSource) Open(path string) (io.ReadSeekCloser, error) {
+	return s.client.Open(path)
+}
+
+// appendNewBytes appends the complete lines of the source file after the offset to the local file and returns the new offset.
+// The partial last line which the engine is still writing is left for the next fetch, and is appended by the final fetch.
+// The local file is written again from the start when the source file is shorter than the offset, which means it is rewritten.
+func appendNewBytes(src resultFileSource, fromFilePath, toFilePath string, offset int64, final bool) (int64, error) {
+	info, err := src.Stat(fromFilePath)
+	if err != nil {
+		return offset, err
+	}
+
+	size := info.Size()
+	if size < offset {
+		offset = 0
+	}
+
+	local, err := os.OpenFile(toFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return offset, err
+	}
+	defer local.Close()
+
+	// the local file may be longer than the offset when the former append failed halfway
+	if err := local.Truncate(offset); err != nil {
+		return offset, err
+	}
+
+	if size == offset {
+		return offset, nil
+	}
+
+	remote, err := src.Open(fromFilePath)
+	if err != nil {
+		return offset, err
+	}
+	defer remote.Close()
+
+	if _, err := remote.Seek(offset, io.SeekStart); err != nil {
+		return offset, err
+	}
+
+	w := &lineEndWriter{w: local}
+	n, err := io.Copy(w, io.LimitReader(remote, size-offset))
+
+	// the bytes after the last line end are dropped, so the local file has only the complete lines
+	end := offset + w.lineEnd
+	if err == nil && final {
+		end = offset + n
+	}
+
+	if terr := local.Truncate(end); terr != nil && err == nil {
+		err = terr
+	}
+
+	return end, err
+}
+
+// lineEndWriter remembers the count of the bytes written up to the last line end.
+type lineEndWriter struct {
+	w       io.Writer
+	n       int64
+	lineEnd int64
+}
+
+func (l *lineEndWriter) Write(p []byte) (int, error) {
+	n, err := l.w.Write(p)
+	if i := bytes.LastIndexByte(p[:n], '\n'); i >= 0 {
+		l.lineEnd = l.n + int64(i) + 1
+	}
+	l.n += int64(n)
+	return n, err
+}
+
+// withBackoff calls fn until it succeeds up to the attempts, doubling the delay from the base delay between the attempts.
+// The missing file is not retried, because it does not appear by the retry soon.
+func withBackoff(attempts int, baseDelay time.Duration, fn func() error) error {
+	var err error
+	delay := baseDelay
+
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			time.Sleep(delay)
+			delay *= 2
+		}
+
+		err = fn()
+		if err == nil || errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	return err
+}
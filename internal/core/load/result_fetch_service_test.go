@@ -0,0 +1,103 @@
+package load
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendNewBytes(t *testing.T) {
+	dir := t.TempDir()
+	from := filepath.Join(dir, "remote.csv")
+	to := filepath.Join(dir, "local.csv")
+
+	_, err := appendNewBytes(localFileSource{}, from, to, 0, false)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	require.NoError(t, os.WriteFile(from, []byte("a,b\n1,2\n"), 0644))
+	offset, err := appendNewBytes(localFileSource{}, from, to, 0, false)
+	require.NoError(t, err)
+	require.EqualValues(t, 8, offset)
+
+	// only the bytes after the offset are appended
+	require.NoError(t, os.WriteFile(from, []byte("a,b\n1,2\n3,4\n"), 0644))
+	offset, err = appendNewBytes(localFileSource{}, from, to, offset, false)
+	require.NoError(t, err)
+	require.EqualValues(t, 12, offset)
+
+	local, err := os.ReadFile(to)
+	require.NoError(t, err)
+	require.Equal(t, "a,b\n1,2\n3,4\n", string(local))
+
+	// the bytes appended after the offset by the failed fetch are dropped
+	require.NoError(t, os.WriteFile(to, []byte("a,b\n1,2\n3,4\nbroken"), 0644))
+	offset, err = appendNewBytes(localFileSource{}, from, to, offset, false)
+	require.NoError(t, err)
+	require.EqualValues(t, 12, offset)
+
+	local, err = os.ReadFile(to)
+	require.NoError(t, err)
+	require.Equal(t, "a,b\n1,2\n3,4\n", string(local))
+
+	// the rewritten file is fetched again from the start
+	require.NoError(t, os.WriteFile(from, []byte("c,d\n"), 0644))
+	offset, err = appendNewBytes(localFileSource{}, from, to, offset, false)
+	require.NoError(t, err)
+	require.EqualValues(t, 4, offset)
+
+	local, err = os.ReadFile(to)
+	require.NoError(t, err)
+	require.Equal(t, "c,d\n", string(local))
+
+	// the partial last line is left for the next fetch
+	require.NoError(t, os.WriteFile(from, []byte("c,d\n5,6\n7,"), 0644))
+	offset, err = appendNewBytes(localFileSource{}, from, to, offset, false)
+	require.NoError(t, err)
+	require.EqualValues(t, 8, offset)
+
+	local, err = os.ReadFile(to)
+	require.NoError(t, err)
+	require.Equal(t, "c,d\n5,6\n", string(local))
+
+	// the final fetch appends the last line without the line end
+	require.NoError(t, os.WriteFile(from, []byte("c,d\n5,6\n7,8"), 0644))
+	offset, err = appendNewBytes(localFileSource{}, from, to, offset, true)
+	require.NoError(t, err)
+	require.EqualValues(t, 11, offset)
+
+	local, err = os.ReadFile(to)
+	require.NoError(t, err)
+	require.Equal(t, "c,d\n5,6\n7,8", string(local))
+}
+
+func TestWithBackoff(t *testing.T) {
+	calls := 0
+	err := withBackoff(3, 0, func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("connection reset")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, calls)
+
+	calls = 0
+	err = withBackoff(3, 0, func() error {
+		calls++
+		return errors.New("connection reset")
+	})
+	require.Error(t, err)
+	require.Equal(t, 3, calls)
+
+	calls = 0
+	err = withBackoff(3, 0, func() error {
+		calls++
+		return os.ErrNotExist
+	})
+	require.ErrorIs(t, err, os.ErrNotExist)
+	require.Equal(t, 1, calls)
+}
@@ -0,0 +1,228 @@
+package load
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloud-barista/cm-ant/internal/utils"
+)
+
+const (
+	resultIngestionTimeout = 5 * time.Minute
+)
+
+// ingestLoadTestResult parses the fetched result and metrics csv files of the load test
+// and stores them into the database, so the result survives the loss of the result folder.
+// Metrics files are optional because they only exist when the monitoring agent is installed.
+func (l *LoadService) ingestLoadTestResult(engine LoadGeneratorEngine, loadTestKey string) error {
+	resultFolderPath := utils.JoinRootPathWith("/result/" + loadTestKey)
+	resultFilePath := fmt.Sprintf("%s/%s", resultFolderPath, resultFileNameOf(loadTestKey))
+
+	resultMap, err := engine.ParseResult(resultFilePath)
+	if err != nil {
+		return err
+	}
+
+	var results []LoadTestResultRawData
+	for label, rows := range resultMap {
+		for _, r := range rows {
+			results = append(results, LoadTestResultRawData{
+				LoadTestKey: loadTestKey,
+				Label:       label,
+				No:          r.No,
+				Elapsed:     r.Elapsed,
+				Bytes:       r.Bytes,
+				SentBytes:   r.SentBytes,
+				URL:         r.URL,
+				Latency:     r.Latency,
+				IdleTime:    r.IdleTime,
+				Connection:  r.Connection,
+				IsError:     r.IsError,
+				Timestamp:   r.Timestamp,
+			})
+		}
+	}
+
+	metricsMap := make(map[string][]*MetricsRawData)
+	for _, v := range []string{"cpu", "disk", "memory", "network"} {
+		metricsFilePath := fmt.Sprintf("%s/%s_%s_result.csv", resultFolderPath, loadTestKey, v)
+		if !utils.ExistCheck(metricsFilePath) {
+			continue
+		}
+
+		m, err := appendMetricsRawData(metricsMap, metricsFilePath)
+		if err != nil {
+			utils.LogWarnf("Skip metrics file %s: %v", metricsFilePath, err)
+			continue
+		}
+		metricsMap = m
+	}
+
+	var metrics []LoadTestMetricsRawData
+	for label, rows := range metricsMap {
+		for _, m := range rows {
+			metrics = append(metrics, LoadTestMetricsRawData{
+				LoadTestKey: loadTestKey,
+				Label:       label,
+				Value:       m.Value,
+				Unit:        m.Unit,
+				IsError:     m.IsError,
+				Timestamp:   m.Timestamp,
+			})
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), resultIngestionTimeout)
+	defer cancel()
+
+	err = l.loadRepo.SaveLoadTestResultRawDataTx(ctx, loadTestKey, results, metrics)
+	if err != nil {
+		return err
+	}
+
+	utils.LogInfof("Ingested %d results and %d metrics of load test %s", len(results), len(metrics), loadTestKey)
+	return nil
+}
+
+// loadResultSummaries returns the result of the load test from the database.
+// It falls back to the csv file when the result is not ingested yet, such as while the load test is running.
+func (l *LoadService) loadResultSummaries(loadTestKey string) ([]ResultSummary, error) {
+	var resultSummaries []ResultSummary
+	indexes := make(map[string]int)
+
+	err := l.scanResult(loadTestKey, nil, func(label string, r *ResultRawData) {
+		i, ok := indexes[label]
+		if !ok {
+			i = len(resultSummaries)
+			indexes[label] = i
+			resultSummaries = append(resultSummaries, ResultSummary{Label: label})
+		}
+
+		resultSummaries[i].Results = append(resultSummaries[i].Results, r)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resultSummaries, nil
+}
+
+// loadResultStatistics aggregates the result of the load test under the labels returned by labelOf, see newResultStatistics.
+// The result is scanned without the columns the statistics do not use.
+func (l *LoadService) loadResultStatistics(loadTestKey string, labelOf func(string) string) ([]*LoadTestStatistics, error) {
+	statistics := newResultStatistics(labelOf)
+	columns := []string{"label", "elapsed", "bytes", "sent_bytes", "is_error", "timestamp"}
+
+	if err := l.scanResult(loadTestKey, columns, statistics.add); err != nil {
+		return nil, err
+	}
+
+	return statistics.statistics(), nil
+}
+
+// loadResultTimeSeries buckets the result of the load test by the interval, which is scanned without the columns the buckets do not use.
+func (l *LoadService) loadResultTimeSeries(loadTestKey string, interval time.Duration) ([]ResultTimeSeries, error) {
+	builder, err := newTimeSeriesBuilder(interval)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := []string{"label", "elapsed", "is_error", "timestamp"}
+	if err := l.scanResult(loadTestKey, columns, builder.add); err != nil {
+		return nil, err
+	}
+
+	return builder.series(), nil
+}
+
+// scanResult passes the samples of the load test to the scan with their labels. The samples are read from the database
+// in batches with only the columns when they are given, and from the csv file when the result is not ingested yet.
+func (l *LoadService) scanResult(loadTestKey string, columns []string, scan func(label string, r *ResultRawData)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), resultIngestionTimeout)
+	defer cancel()
+
+	found := false
+	err := l.loadRepo.ScanLoadTestResultRawDataTx(ctx, loadTestKey, columns, func(rows []LoadTestResultRawData) error {
+		found = found || len(rows) > 0
+		for _, r := range rows {
+			scan(r.Label, &ResultRawData{
+				No:         r.No,
+				Elapsed:    r.Elapsed,
+				Bytes:      r.Bytes,
+				SentBytes:  r.SentBytes,
+				URL:        r.URL,
+				Latency:    r.Latency,
+				IdleTime:   r.IdleTime,
+				Connection: r.Connection,
+				IsError:    r.IsError,
+				Timestamp:  r.Timestamp,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		utils.LogErrorf("Error fetching result of load test %s: %v", loadTestKey, err)
+		return err
+	}
+
+	if found {
+		return nil
+	}
+
+	engine, err := l.loadTestEngine(loadTestKey)
+	if err != nil {
+		return err
+	}
+
+	resultSummaries, err := readResultSummaries(engine, loadTestKey)
+	if err != nil {
+		return err
+	}
+
+	for _, summary := range resultSummaries {
+		for _, r := range summary.Results {
+			scan(summary.Label, r)
+		}
+	}
+
+	return nil
+}
+
+// loadMetricsSummaries returns the metrics of the load test from the database.
+// It falls back to the csv files when the metrics are not ingested yet.
+func (l *LoadService) loadMetricsSummaries(loadTestKey string) ([]MetricsSummary, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), resultIngestionTimeout)
+	defer cancel()
+
+	rows, err := l.loadRepo.GetLoadTestMetricsRawDataTx(ctx, loadTestKey)
+	if err != nil {
+		utils.LogErrorf("Error fetching metrics of load test %s: %v", loadTestKey, err)
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return readMetricsSummaries(loadTestKey)
+	}
+
+	var metricsSummaries []MetricsSummary
+	indexes := make(map[string]int)
+
+	for _, m := range rows {
+		i, ok := indexes[m.Label]
+		if !ok {
+			i = len(metricsSummaries)
+			indexes[m.Label] = i
+			metricsSummaries = append(metricsSummaries, MetricsSummary{Label: m.Label})
+		}
+
+		metricsSummaries[i].Metrics = append(metricsSummaries[i].Metrics, &MetricsRawData{
+			Value:     m.Value,
+			Unit:      m.Unit,
+			IsError:   m.IsError,
+			Timestamp: m.Timestamp,
+		})
+	}
+
+	return metricsSummaries, nil
+}
@@ -0,0 +1,132 @@
+package load
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/cloud-barista/cm-ant/internal/core/common/constant"
+)
+
+var sloMetricValues = map[string]func(s *LoadTestStatistics) float64{
+	"average":       func(s *LoadTestStatistics) float64 { return s.Average },
+	"median":        func(s *LoadTestStatistics) float64 { return s.Median },
+	"p90":           func(s *LoadTestStatistics) float64 { return s.NinetyPercent },
+	"ninetyPercent": func(s *LoadTestStatistics) float64 { return s.NinetyPercent },
+	"p95":           func(s *LoadTestStatistics) float64 { return s.NinetyFive },
+	"ninetyFive":    func(s *LoadTestStatistics) float64 { return s.NinetyFive },
+	"p99":           func(s *LoadTestStatistics) float64 { return s.NinetyNine },
+	"ninetyNine":    func(s *LoadTestStatistics) float64 { return s.NinetyNine },
+	"minTime":       func(s *LoadTestStatistics) float64 { return s.MinTime },
+	"maxTime":       func(s *LoadTestStatistics) float64 { return s.MaxTime },
+	"errorPercent":  func(s *LoadTestStatistics) float64 { return s.ErrorPercent },
+	"throughput":    func(s *LoadTestStatistics) float64 { return s.Throughput },
+	"receivedKB":    func(s *LoadTestStatistics) float64 { return s.ReceivedKB },
+	"sentKB":        func(s *LoadTestStatistics) float64 { return s.SentKB },
+}
+
+var sloOperators = map[string]func(actual, threshold float64) bool{
+	"<":  func(actual, threshold float64) bool { return actual < threshold },
+	"<=": func(actual, threshold float64) bool { return actual <= threshold },
+	">":  func(actual, threshold float64) bool { return actual > threshold },
+	">=": func(actual, threshold float64) bool { return actual >= threshold },
+}
+
+// ValidateSloRules checks that every rule has a supported metric and operator.
+func ValidateSloRules(rules []SloRuleParam) error {
+	for _, r := range rules {
+		if _, ok := sloMetricValues[r.Metric]; !ok {
+			return fmt.Errorf("slo metric %q is not supported", r.Metric)
+		}
+
+		if _, ok := sloOperators[r.Operator]; !ok {
+			return fmt.Errorf("slo operator %q is not supported", r.Operator)
+		}
+	}
+
+	return nil
+}
+
+// evaluateSloRules checks the rules against the aggregated statistics and returns the results with the verdict.
+// A rule without label is checked against every label and a rule for a missing label is failed.
+func evaluateSloRules(rules []SloRuleParam, statistics []*LoadTestStatistics) ([]LoadTestSloResult, constant.Verdict) {
+	statMap := make(map[string]*LoadTestStatistics)
+	var labels []string
+	for _, s := range statistics {
+		statMap[s.Label] = s
+		labels = append(labels, s.Label)
+	}
+	sort.Strings(labels)
+
+	var results []LoadTestSloResult
+	verdict := constant.VerdictPassed
+
+	for _, r := range rules {
+		valueOf, ok := sloMetricValues[r.Metric]
+		compare, ok2 := sloOperators[r.Operator]
+		if !ok || !ok2 {
+			results = append(results, newSloResult(r, r.Label, 0, false, "unsupported metric or operator"))
+			verdict = constant.VerdictFailed
+			continue
+		}
+
+		targets := labels
+		if r.Label != "" {
+			targets = []string{r.Label}
+		}
+
+		if len(targets) == 0 {
+			results = append(results, newSloResult(r, r.Label, 0, false, "no result to evaluate"))
+			verdict = constant.VerdictFailed
+			continue
+		}
+
+		for _, label := range targets {
+			s, ok := statMap[label]
+			if !ok {
+				results = append(results, newSloResult(r, label, 0, false, "no result for the label"))
+				verdict = constant.VerdictFailed
+				continue
+			}
+
+			actual := valueOf(s)
+			if math.IsInf(actual, 0) || math.IsNaN(actual) {
+				results = append(results, newSloResult(r, label, 0, false, "metric is not measurable"))
+				verdict = constant.VerdictFailed
+				continue
+			}
+
+			passed := compare(actual, r.Threshold)
+			if !passed {
+				verdict = constant.VerdictFailed
+			}
+
+			results = append(results, newSloResult(r, label, actual, passed, ""))
+		}
+	}
+
+	return results, verdict
+}
+
+func newSloResult(r SloRuleParam, label string, actual float64, passed bool, message string) LoadTestSloResult {
+	return LoadTestSloResult{
+		Label:     label,
+		Metric:    r.Metric,
+		Operator:  r.Operator,
+		Threshold: r.Threshold,
+		Actual:    actual,
+		Passed:    passed,
+		Message:   message,
+	}
+}
+
+// evaluateSloOfLoadTest aggregates the fetched result of the load test and evaluates the slo rules.
+func (l *LoadService) evaluateSloOfLoadTest(loadTestKey string, rules []SloRuleParam) ([]LoadTestSloResult, constant.Verdict, error) {
+	statistics, err := l.loadResultStatistics(loadTestKey, nil)
+	if err != nil {
+		return nil, constant.VerdictError, err
+	}
+
+	results, verdict := evaluateSloRules(rules, statistics)
+	return results, verdict, nil
+}
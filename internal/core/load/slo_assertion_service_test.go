@@ -0,0 +1,50 @@
+package load
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cloud-barista/cm-ant/internal/core/common/constant"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateSloRules(t *testing.T) {
+	statistics := []*LoadTestStatistics{
+		{Label: "home", NinetyFive: 250, ErrorPercent: 0.5, Throughput: 300},
+		{Label: "login", NinetyFive: 350, ErrorPercent: 0, Throughput: 100},
+	}
+
+	results, verdict := evaluateSloRules([]SloRuleParam{
+		{Label: "home", Metric: "p95", Operator: "<", Threshold: 300},
+		{Metric: "errorPercent", Operator: "<", Threshold: 1},
+	}, statistics)
+	require.Equal(t, constant.VerdictPassed, verdict)
+	require.Len(t, results, 3)
+
+	results, verdict = evaluateSloRules([]SloRuleParam{
+		{Metric: "p95", Operator: "<", Threshold: 300},
+		{Label: "home", Metric: "throughput", Operator: ">", Threshold: 200},
+		{Label: "missing", Metric: "p95", Operator: "<", Threshold: 300},
+	}, statistics)
+	require.Equal(t, constant.VerdictFailed, verdict)
+	require.Len(t, results, 4)
+	require.True(t, results[0].Passed)
+	require.Equal(t, "login", results[1].Label)
+	require.False(t, results[1].Passed)
+	require.Equal(t, float64(350), results[1].Actual)
+	require.True(t, results[2].Passed)
+	require.False(t, results[3].Passed)
+	require.NotEmpty(t, results[3].Message)
+
+	results, verdict = evaluateSloRules([]SloRuleParam{
+		{Metric: "throughput", Operator: ">", Threshold: 1},
+	}, []*LoadTestStatistics{{Label: "home", Throughput: math.Inf(1)}})
+	require.Equal(t, constant.VerdictFailed, verdict)
+	require.False(t, results[0].Passed)
+}
+
+func TestValidateSloRules(t *testing.T) {
+	require.NoError(t, ValidateSloRules([]SloRuleParam{{Metric: "p99", Operator: "<="}}))
+	require.Error(t, ValidateSloRules([]SloRuleParam{{Metric: "p42", Operator: "<"}}))
+	require.Error(t, ValidateSloRules([]SloRuleParam{{Metric: "p95", Operator: "=="}}))
+}
@@ -26,6 +26,19 @@ func migrateDB(defaultDb *gorm.DB) error {
 		&load.LoadTestExecutionInfo{},
 		&load.LoadTestExecutionHttpInfo{},
 		&load.LoadTestExecutionState{},
+		&load.LoadTestSloResult{},
+		&load.LoadTestFileTransfer{},
+		&load.LoadGeneratorKey{},
+		&load.LoadTestSchedule{},
+		&load.LoadTestCapacitySearch{},
+		&load.LoadTestCapacitySearchStep{},
+		&load.MultiRegionLoadTest{},
+		&load.MultiRegionLoadTestRegion{},
+		&load.LoadTestResultRawData{},
+		&load.LoadTestMetricsRawData{},
+		&load.LoadGeneratorMetricsRawData{},
+		&load.LoadTestPlan{},
+		&load.LoadTestPlanDataFile{},
 
 		&cost.EstimateCostInfo{},
 		&cost.EstimateForecastCostInfo{},
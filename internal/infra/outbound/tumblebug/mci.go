@@ -215,6 +215,21 @@ func (t *TumblebugClient) ControlLifecycleWithContext(ctx context.Context, nsId,
 	return nil
 }
 
+// DeleteMciWithContext call tumblebug's api which delete the mci.
+// option should be one of terminate | force, and terminate deletes the mci after its vms are terminated.
+func (t *TumblebugClient) DeleteMciWithContext(ctx context.Context, nsId, mciId, option string) error {
+	url := t.withUrl(fmt.Sprintf("/ns/%s/mci/%s?option=%s", nsId, mciId, option))
+
+	_, err := t.requestWithBaseAuthWithContext(ctx, http.MethodDelete, url, nil)
+
+	if err != nil {
+		utils.LogError("error sending delete mci request:", err)
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+
+	return nil
+}
+
 // DeleteAllMciWithContext call tumblebug's api which delete all mci in ns.
 // This should call after all the vm's in mci is the status of terminate or suspend.
 // If you want to change mci's vm lifecycle use ControlLifecycleWithContext.
@@ -245,3 +260,53 @@ func (t *TumblebugClient) DeleteAllResourcesWithContext(ctx context.Context, nsI
 
 	return nil
 }
+
+func (t *TumblebugClient) GetSecurityGroupWithContext(ctx context.Context, nsId, securityGroupId string) (SecurityGroupRes, error) {
+	var res SecurityGroupRes
+
+	url := t.withUrl(fmt.Sprintf("/ns/%s/resources/securityGroup/%s", nsId, securityGroupId))
+	resBytes, err := t.requestWithBaseAuthWithContext(ctx, http.MethodGet, url, nil)
+
+	if err != nil {
+		utils.LogError("error sending get security group request:", err)
+		return res, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	err = json.Unmarshal(resBytes, &res)
+
+	if err != nil {
+		utils.LogError("error unmarshaling response body:", err)
+		return res, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	return res, nil
+}
+
+// CreateFirewallRulesWithContext call tumblebug's api which adds the firewall rules to the security group.
+func (t *TumblebugClient) CreateFirewallRulesWithContext(ctx context.Context, nsId, securityGroupId string, body FirewallRulesReq) error {
+	return t.firewallRulesWithContext(ctx, http.MethodPost, nsId, securityGroupId, body)
+}
+
+// DeleteFirewallRulesWithContext call tumblebug's api which removes the firewall rules from the security group.
+func (t *TumblebugClient) DeleteFirewallRulesWithContext(ctx context.Context, nsId, securityGroupId string, body FirewallRulesReq) error {
+	return t.firewallRulesWithContext(ctx, http.MethodDelete, nsId, securityGroupId, body)
+}
+
+func (t *TumblebugClient) firewallRulesWithContext(ctx context.Context, method, nsId, securityGroupId string, body FirewallRulesReq) error {
+	url := t.withUrl(fmt.Sprintf("/ns/%s/resources/securityGroup/%s/rules", nsId, securityGroupId))
+
+	marshalledBody, err := json.Marshal(body)
+	if err != nil {
+		utils.LogError("error marshaling request body:", err)
+		return err
+	}
+
+	_, err = t.requestWithBaseAuthWithContext(ctx, method, url, marshalledBody)
+
+	if err != nil {
+		utils.LogError("error sending firewall rules request:", err)
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+
+	return nil
+}
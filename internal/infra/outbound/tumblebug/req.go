@@ -40,6 +40,10 @@ type SecurityGroupReq struct {
 	FirewallRules  []FirewallRuleReq `json:"firewallRules"`
 }
 
+type FirewallRulesReq struct {
+	FirewallRules []FirewallRuleReq `json:"firewallRules"`
+}
+
 type FirewallRuleReq struct {
 	FromPort   string `json:"fromPort"`
 	ToPort     string `json:"toPort"`
@@ -168,3 +168,10 @@ type GetNsRes struct {
 	Name         string `json:"name"`
 	Description  string `json:"description"`
 }
+
+type SecurityGroupRes struct {
+	Id            string            `json:"id"`
+	Name          string            `json:"name"`
+	VNetId        string            `json:"vNetId"`
+	FirewallRules []FirewallRuleReq `json:"firewallRules"`
+}
@@ -39,6 +39,20 @@ func Script(scriptPath string, envs []string, args ...string) error {
 	return nil
 }
 
+// InlineCmdOutput runs the command and returns the combined output.
+func InlineCmdOutput(cmdStr string) (string, error) {
+	cmd := exec.Command("bash", "-c", cmdStr)
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		log.Println("error while execute bash call,", err)
+		log.Println(string(out))
+		return string(out), err
+	}
+
+	return string(out), nil
+}
+
 func InlineCmdAsync(cmdStr string) error {
 	cmd := exec.Command("bash", "-c", cmdStr)
 
@@ -0,0 +1,184 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a parsed standard 5 field cron expression.
+// minute hour day-of-month month day-of-week
+type CronSchedule struct {
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+
+	domStar bool
+	dowStar bool
+}
+
+type cronBound struct {
+	min, max int
+}
+
+var (
+	cronMinuteBound = cronBound{0, 59}
+	cronHourBound   = cronBound{0, 23}
+	cronDomBound    = cronBound{1, 31}
+	cronMonthBound  = cronBound{1, 12}
+	cronDowBound    = cronBound{0, 7}
+
+	cronMacros = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+)
+
+// ParseCron parses a standard 5 field cron expression.
+// Each field supports `*`, single values, ranges (`1-5`), lists (`1,3,5`) and steps (`*/15`, `0-30/10`).
+// Macros such as @daily, @hourly and @weekly are also supported.
+func ParseCron(expr string) (*CronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression must have 5 fields but got %d: %s", len(fields), expr)
+	}
+
+	var c CronSchedule
+	var err error
+
+	if c.minute, err = parseCronField(fields[0], cronMinuteBound); err != nil {
+		return nil, fmt.Errorf("invalid minute field; %w", err)
+	}
+	if c.hour, err = parseCronField(fields[1], cronHourBound); err != nil {
+		return nil, fmt.Errorf("invalid hour field; %w", err)
+	}
+	if c.dom, err = parseCronField(fields[2], cronDomBound); err != nil {
+		return nil, fmt.Errorf("invalid day of month field; %w", err)
+	}
+	if c.month, err = parseCronField(fields[3], cronMonthBound); err != nil {
+		return nil, fmt.Errorf("invalid month field; %w", err)
+	}
+	if c.dow, err = parseCronField(fields[4], cronDowBound); err != nil {
+		return nil, fmt.Errorf("invalid day of week field; %w", err)
+	}
+
+	// sunday can be written as both 0 and 7
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+
+	c.domStar = strings.HasPrefix(fields[2], "*")
+	c.dowStar = strings.HasPrefix(fields[4], "*")
+
+	return &c, nil
+}
+
+func parseCronField(field string, bound cronBound) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(field, ",") {
+		rangePart := part
+		step := 1
+
+		if i := strings.Index(part, "/"); i >= 0 {
+			s, err := strconv.Atoi(part[i+1:])
+			if err != nil || s <= 0 {
+				return 0, fmt.Errorf("invalid step: %s", part)
+			}
+			step = s
+			rangePart = part[:i]
+		}
+
+		start, end := bound.min, bound.max
+		if rangePart != "*" {
+			if i := strings.Index(rangePart, "-"); i >= 0 {
+				var err error
+				if start, err = strconv.Atoi(rangePart[:i]); err != nil {
+					return 0, fmt.Errorf("invalid range: %s", part)
+				}
+				if end, err = strconv.Atoi(rangePart[i+1:]); err != nil {
+					return 0, fmt.Errorf("invalid range: %s", part)
+				}
+			} else {
+				v, err := strconv.Atoi(rangePart)
+				if err != nil {
+					return 0, fmt.Errorf("invalid value: %s", part)
+				}
+				start = v
+				end = v
+				if step > 1 {
+					end = bound.max
+				}
+			}
+		}
+
+		if start < bound.min || end > bound.max || start > end {
+			return 0, fmt.Errorf("value out of range [%d-%d]: %s", bound.min, bound.max, part)
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+
+	return bits, nil
+}
+
+// Next returns the first activation time which is strictly after the given time.
+// It returns zero time if there is no activation time within five years.
+func (c *CronSchedule) Next(from time.Time) time.Time {
+	t := from.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// dayMatches follows the cron convention that if both day of month and day of week are restricted,
+// the day matches when either of them matches.
+func (c *CronSchedule) dayMatches(t time.Time) bool {
+	domMatch := c.dom&(1<<uint(t.Day())) != 0
+	dowMatch := c.dow&(1<<uint(t.Weekday())) != 0
+
+	if c.domStar || c.dowStar {
+		return domMatch && dowMatch
+	}
+
+	return domMatch || dowMatch
+}
@@ -78,6 +78,43 @@ func ReadCSV(filename string) (*[][]string, error) {
 	return &parsedCsv, nil
 }
 
+// MergeCSVFiles writes the rows of every source csv file into the destination file.
+// The header is taken from the first source file and skipped for the others.
+func MergeCSVFiles(toFilePath string, fromFilePaths []string) error {
+	file, err := os.Create(toFilePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	headerWritten := false
+
+	for _, fromFilePath := range fromFilePaths {
+		rows, err := ReadCSV(fromFilePath)
+		if err != nil {
+			return err
+		}
+
+		if rows == nil || len(*rows) == 0 {
+			continue
+		}
+
+		records := *rows
+		if headerWritten {
+			records = records[1:]
+		}
+
+		if err := writer.WriteAll(records); err != nil {
+			return err
+		}
+		headerWritten = true
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
 func ExistCheck(path string) bool {
 	fileInfo, err := os.Stat(path)
 
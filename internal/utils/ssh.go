@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"crypto"
+	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -11,6 +13,9 @@ import (
 	"log"
 	"net"
 	"os"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/melbahja/goph"
 	"github.com/pkg/sftp"
@@ -24,11 +29,14 @@ func AddToKnownHost(pemFilePath, publicIp, username string) error {
 	}
 
 	client, err := goph.NewConn(&goph.Config{
-		User:     username,
-		Addr:     publicIp,
-		Port:     22,
-		Auth:     auth,
-		Callback: VerifyHost,
+		User: username,
+		Addr: publicIp,
+		Port: 22,
+		Auth: auth,
+		// the caller adds the host explicitly, so the unknown host is trusted
+		Callback: func(host string, remote net.Addr, key ssh.PublicKey) error {
+			return verifyKnownHost(host, remote, key, true)
+		},
 	})
 
 	if err != nil {
@@ -48,76 +56,131 @@ func AddToKnownHost(pemFilePath, publicIp, username string) error {
 	return nil
 }
 
-func VerifyHost(host string, remote net.Addr, key ssh.PublicKey) error {
+// knownHostsMx serializes the host key checks, because the unknown host may be appended to known_hosts.
+var knownHostsMx sync.Mutex
+
+// HostKeyCallbackOf returns the callback which accepts only the pinned host key in authorized_keys format.
+// The host without the pinned key is verified by known_hosts of the server, and the unknown host is rejected
+// unless trustUnknown, which adds the host to known_hosts at the first connection.
+func HostKeyCallbackOf(pinnedHostKey string, trustUnknown bool) (ssh.HostKeyCallback, error) {
+	if pinnedHostKey != "" {
+		key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(pinnedHostKey))
+		if err != nil {
+			return nil, fmt.Errorf("pinned host key is broken; %w", err)
+		}
+		return ssh.FixedHostKey(key), nil
+	}
 
-	hostFound, err := goph.CheckKnownHost(host, remote, key, "")
+	return func(host string, remote net.Addr, key ssh.PublicKey) error {
+		return verifyKnownHost(host, remote, key, trustUnknown)
+	}, nil
+}
 
-	if hostFound && err != nil {
+func verifyKnownHost(host string, remote net.Addr, key ssh.PublicKey, trustUnknown bool) error {
+	knownHostsMx.Lock()
+	defer knownHostsMx.Unlock()
 
-		return err
+	found, err := goph.CheckKnownHost(host, remote, key, "")
+	if found && err != nil {
+		return fmt.Errorf("host key of %s does not match known_hosts; %w", host, err)
 	}
 
-	if hostFound && err == nil {
-
+	if found {
 		return nil
 	}
 
+	if !trustUnknown {
+		return fmt.Errorf("host key %s of %s is unknown", ssh.FingerprintSHA256(key), host)
+	}
+
 	return goph.AddKnownHost(host, remote, key, "")
 }
 
-// GenerateSSHKeyPair generates an RSA SSH key pair of the specified bits.
-func GenerateSSHKeyPair(bits int, privKeyPath, pubKeyPath string) error {
-	reader := rand.Reader
-
-	key, err := rsa.GenerateKey(reader, bits)
+// ScanHostKey connects to the host with the private key and returns the host key in authorized_keys format,
+// so the host key is pinned while the host is registered by the user and verified by it afterwards.
+func ScanHostKey(publicIp, port, username string, privateKey []byte) (string, error) {
+	var hostKey ssh.PublicKey
+	client, err := GetClientWithKey(publicIp, port, username, privateKey, func(_ string, _ net.Addr, key ssh.PublicKey) error {
+		hostKey = key
+		return nil
+	})
 	if err != nil {
-		return err
+		return "", err
 	}
+	client.Close()
 
-	pub, err := ssh.NewPublicKey(key.Public())
-	if err != nil {
-		return err
-	}
-	err = savePrivateKey(key, privKeyPath)
-	if err != nil {
-		return nil
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(hostKey))), nil
+}
+
+// VerifyHost accepts only the host in known_hosts.
+func VerifyHost(host string, remote net.Addr, key ssh.PublicKey) error {
+	return verifyKnownHost(host, remote, key, false)
+}
+
+const (
+	RSAKey     = "rsa"
+	ED25519Key = "ed25519"
+)
+
+// GenerateSSHKey generates an SSH key of the key type, and returns the private key in PEM format
+// and the public key in authorized_keys format. The bits are only used by the RSA key.
+func GenerateSSHKey(keyType string, bits int) ([]byte, []byte, error) {
+	var block *pem.Block
+	var publicKey crypto.PublicKey
+
+	switch keyType {
+	case RSAKey:
+		key, err := rsa.GenerateKey(rand.Reader, bits)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		block = &pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(key),
+		}
+		publicKey = key.Public()
+	case ED25519Key:
+		pub, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		block, err = ssh.MarshalPrivateKey(key, "")
+		if err != nil {
+			return nil, nil, err
+		}
+		publicKey = pub
+	default:
+		return nil, nil, fmt.Errorf("key type %q is not one of rsa and ed25519", keyType)
 	}
 
-	err = savePublicKey(pub, pubKeyPath)
+	pub, err := ssh.NewPublicKey(publicKey)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	return nil
+	return pem.EncodeToMemory(block), ssh.MarshalAuthorizedKey(pub), nil
 }
 
-// savePrivateKey saves the private key to a file.
-func savePrivateKey(key *rsa.PrivateKey, filename string) error {
-	// Marshal private key to PEM format
-	keyBytes := x509.MarshalPKCS1PrivateKey(key)
-	block := &pem.Block{
-		Type:  "RSA PRIVATE KEY",
-		Bytes: keyBytes,
+// GenerateSSHKeyPair generates an SSH key pair of the key type and saves them to the files.
+// The bits are only used by the RSA key.
+func GenerateSSHKeyPair(keyType string, bits int, privKeyPath, pubKeyPath string) error {
+	privateKey, publicKey, err := GenerateSSHKey(keyType, bits)
+	if err != nil {
+		return err
 	}
 
-	// Write to file
-	err := os.WriteFile(filename, pem.EncodeToMemory(block), 0600)
+	err = os.WriteFile(privKeyPath, privateKey, 0600)
 	if err != nil {
 		return fmt.Errorf("failed to save private key: %w", err)
 	}
-	return nil
-}
-
-// savePublicKey saves the public key to a file.
-func savePublicKey(key ssh.PublicKey, filename string) error {
-	// Marshal public key to authorized_keys format
-	keyBytes := ssh.MarshalAuthorizedKey(key)
 
-	// Write to file
-	err := os.WriteFile(filename, keyBytes, 0644)
+	err = os.WriteFile(pubKeyPath, publicKey, 0644)
 	if err != nil {
 		return fmt.Errorf("failed to save public key: %w", err)
 	}
+
 	return nil
 }
 
@@ -159,19 +222,33 @@ func DownloadFile(client *ssh.Client, toFilePath string, fromFilePath string) er
 	return nil
 }
 
-func GetClient(publicIp, port, username, privateKeyName string) (*ssh.Client, error) {
+// ReadPrivateKey reads the private key file of the name in ~/.ssh of the server.
+func ReadPrivateKey(privateKeyName string) ([]byte, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return nil, err
 	}
 
-	privateKeyPath := fmt.Sprintf("%s/.ssh/%s", home, privateKeyName)
+	return os.ReadFile(fmt.Sprintf("%s/.ssh/%s", home, privateKeyName))
+}
+
+func GetClient(publicIp, port, username, privateKeyName string) (*ssh.Client, error) {
+	privateKey, err := ReadPrivateKey(privateKeyName)
+	if err != nil {
+		return nil, err
+	}
 
-	privateKey, err := os.ReadFile(privateKeyPath)
+	callback, err := HostKeyCallbackOf("", false)
 	if err != nil {
 		return nil, err
 	}
 
+	return GetClientWithKey(publicIp, port, username, privateKey, callback)
+}
+
+// GetClientWithKey connects to the host by ssh with the private key in PEM format,
+// and verifies the host key with the callback.
+func GetClientWithKey(publicIp, port, username string, privateKey []byte, hostKeyCallback ssh.HostKeyCallback) (*ssh.Client, error) {
 	signer, err := ssh.ParsePrivateKey(privateKey)
 	if err != nil {
 		return nil, err
@@ -182,7 +259,8 @@ func GetClient(publicIp, port, username, privateKeyName string) (*ssh.Client, er
 		Auth: []ssh.AuthMethod{
 			ssh.PublicKeys(signer),
 		},
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         30 * time.Second,
 	}
 
 	client, err := ssh.Dial("tcp", fmt.Sprintf("%s:%s", publicIp, port), sshConfig)
@@ -1,16 +1,79 @@
 package utils
 
 import (
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
 )
 
 func TestGenerateSSHKeyPair(t *testing.T) {
+	dir := t.TempDir()
 	bits := 4096
-	err := GenerateSSHKeyPair(bits, "./id_rsa", "./id_rsa.pub")
+	err := GenerateSSHKeyPair(RSAKey, bits, filepath.Join(dir, "id_rsa"), filepath.Join(dir, "id_rsa.pub"))
 	if err != nil {
 		t.Fatalf("Failed to generate SSH key pair: %v", err)
 	}
 	require.NoError(t, err)
+
+	err = GenerateSSHKeyPair(ED25519Key, 0, filepath.Join(dir, "id_ed25519"), filepath.Join(dir, "id_ed25519.pub"))
+	require.NoError(t, err)
+
+	_, _, err = GenerateSSHKey("dsa", 0)
+	require.Error(t, err)
+}
+
+func TestGenerateSSHKey(t *testing.T) {
+	for _, keyType := range []string{RSAKey, ED25519Key} {
+		privateKey, publicKey, err := GenerateSSHKey(keyType, 2048)
+		require.NoError(t, err)
+
+		signer, err := ssh.ParsePrivateKey(privateKey)
+		require.NoError(t, err)
+
+		pub, _, _, _, err := ssh.ParseAuthorizedKey(publicKey)
+		require.NoError(t, err)
+		require.Equal(t, ssh.FingerprintSHA256(pub), ssh.FingerprintSHA256(signer.PublicKey()))
+	}
+}
+
+func TestHostKeyCallbackOf(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".ssh"), 0700))
+
+	hostKeyOf := func() ssh.PublicKey {
+		_, pub, err := GenerateSSHKey(ED25519Key, 0)
+		require.NoError(t, err)
+		key, _, _, _, err := ssh.ParseAuthorizedKey(pub)
+		require.NoError(t, err)
+		return key
+	}
+	hostKey, otherKey := hostKeyOf(), hostKeyOf()
+	remote := &net.TCPAddr{IP: net.ParseIP("10.0.0.5"), Port: 22}
+
+	pinned, err := HostKeyCallbackOf(strings.TrimSpace(string(ssh.MarshalAuthorizedKey(hostKey))), false)
+	require.NoError(t, err)
+	require.NoError(t, pinned("10.0.0.5:22", remote, hostKey))
+	require.Error(t, pinned("10.0.0.5:22", remote, otherKey))
+
+	// the unknown host is rejected unless it is trusted at the first connection
+	strict, err := HostKeyCallbackOf("", false)
+	require.NoError(t, err)
+	require.Error(t, strict("10.0.0.5:22", remote, hostKey))
+
+	trusting, err := HostKeyCallbackOf("", true)
+	require.NoError(t, err)
+	require.NoError(t, trusting("10.0.0.5:22", remote, hostKey))
+
+	require.NoError(t, strict("10.0.0.5:22", remote, hostKey))
+	require.Error(t, strict("10.0.0.5:22", remote, otherKey))
+	require.Error(t, trusting("10.0.0.5:22", remote, otherKey))
+
+	_, err = HostKeyCallbackOf("broken", false)
+	require.Error(t, err)
 }
@@ -0,0 +1,48 @@
+#!/bin/bash
+
+set -e
+echo "[CM-ANT] K6 Installation"
+
+# Base setup
+K6_WORK_DIR=${K6_WORK_DIR:="/opt/ant/jmeter"}
+K6_VERSION=${K6_VERSION:="0.54.0"}
+K6_FOLDER="k6-v${K6_VERSION}-linux-amd64"
+K6_FULL_PATH="${K6_WORK_DIR}/${K6_FOLDER}"
+K6_INSTALL_URL="https://github.com/grafana/k6/releases/download/v${K6_VERSION}/${K6_FOLDER}.tar.gz"
+
+echo "This is k6 working directory >>>>>>>>>>>>>>>>> ${K6_WORK_DIR}"
+echo "This is k6 folder name >>>>>>>>>>>>>>>>>>>>>>> ${K6_FOLDER}"
+
+echo
+echo
+
+if [ -x "${K6_FULL_PATH}/k6" ]; then
+  echo "[CM-ANT] K6 is already installed."
+  exit 0
+fi
+
+for dir in "${K6_WORK_DIR}" "${K6_WORK_DIR}/result" "${K6_WORK_DIR}/test_plan"; do
+  if [ ! -e "${dir}" ]; then
+    sudo mkdir -p "${dir}"
+    echo "${dir} folder created"
+  fi
+done
+
+echo "[CM-ANT] [Step 1/2] Downloading and Extracting K6..."
+if ! command -v wget > /dev/null; then
+  sudo apt-get update -y
+  sudo apt-get install -y wget
+fi
+
+sudo wget "${K6_INSTALL_URL}" -P "${K6_WORK_DIR}"
+sudo tar -xzf "${K6_FULL_PATH}.tar.gz" -C "${K6_WORK_DIR}" && sudo rm "${K6_FULL_PATH}.tar.gz"
+sudo chmod -R 777 ${K6_WORK_DIR}
+
+echo
+echo
+
+echo "[CM-ANT] [Step 2/2] Configuring K6..."
+sudo chmod +x "${K6_FULL_PATH}/k6"
+"${K6_FULL_PATH}"/k6 version
+
+echo "[CM-ANT] K6 is completely installed on ${K6_FULL_PATH}"
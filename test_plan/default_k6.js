@@ -0,0 +1,104 @@
+import http from 'k6/http';
+import { group, sleep } from 'k6';
+
+export const options = {
+  scenarios: {
+    default: {{.Scenario}},
+  },
+};
+
+const steps = {{.Steps}};
+const cookies = {{.Cookies}};
+
+// extracted variables are kept per virtual user across the iterations like jmeter variables
+const vars = {};
+
+function substitute(value) {
+  if (typeof value !== 'string') {
+    return value;
+  }
+  return value.replace(/\$\{(\w+)\}/g, (m, name) => (name in vars ? vars[name] : m));
+}
+
+function extract(res, e) {
+  let values = [];
+  if (e.type === 'jsonpath') {
+    try {
+      const path = e.expression.replace(/^\$\.?/, '').replace(/\[(\d+)\]/g, '.$1');
+      const v = res.json(path);
+      if (Array.isArray(v)) {
+        values = v;
+      } else if (v !== undefined && v !== null) {
+        values = [v];
+      }
+    } catch (err) {
+      values = [];
+    }
+  } else {
+    const source = e.useHeaders
+      ? Object.entries(res.headers).map(([k, v]) => `${k}: ${v}`).join('\n')
+      : res.body || '';
+    const re = new RegExp(e.expression, 'g');
+    let m;
+    while ((m = re.exec(source)) !== null) {
+      const match = m;
+      values.push(e.template.replace(/\$(\d+)\$/g, (_, g) => match[Number(g)] || ''));
+      if (m[0] === '') {
+        re.lastIndex++;
+      }
+    }
+  }
+
+  if (values.length === 0) {
+    return e.defaultValue;
+  }
+  if (e.matchNumber === 0) {
+    return String(values[Math.floor(Math.random() * values.length)]);
+  }
+  return values.length >= e.matchNumber ? String(values[e.matchNumber - 1]) : e.defaultValue;
+}
+
+function run(step) {
+  const headers = {};
+  for (const h of step.headers) {
+    headers[h.name] = substitute(h.value);
+  }
+
+  const params = { headers: headers, tags: { name: step.label }, timeout: step.timeout };
+  if (step.redirects !== null) {
+    params.redirects = step.redirects;
+  }
+
+  const res = http.request(step.method, substitute(step.url), substitute(step.body), params);
+  for (const e of step.extractors) {
+    vars[e.variable] = extract(res, e);
+  }
+
+  if (step.thinkTime > 0) {
+    sleep(step.thinkTime / 1000);
+  }
+}
+
+export default function () {
+  const jar = http.cookieJar();
+  for (const c of cookies) {
+    jar.set(c.url, c.name, c.value);
+  }
+
+  let i = 0;
+  while (i < steps.length) {
+    const transaction = steps[i].transaction;
+    if (!transaction) {
+      run(steps[i]);
+      i++;
+      continue;
+    }
+
+    group(transaction, () => {
+      while (i < steps.length && steps[i].transaction === transaction) {
+        run(steps[i]);
+        i++;
+      }
+    });
+  }
+}